@@ -6,12 +6,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/Eagle-Konbu/sanat/internal/config"
 	"github.com/Eagle-Konbu/sanat/internal/gofile"
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
 )
 
 var errPathOutsideWorkDir = errors.New("path is outside working directory")
@@ -23,6 +25,11 @@ var (
 	keywordCaseFlag string
 	commaStyleFlag  string
 	sqlModeFlag     string
+	detectFlag      float64
+	collapseFlag    bool
+	convertFlag     bool
+	quotingFlag     string
+	rowAliasFlag    string
 	configFlag      string
 )
 
@@ -49,7 +56,17 @@ func init() {
 	rootCmd.Flags().StringVar(&commaStyleFlag, "comma-style", config.CommaStyleTrailing,
 		"comma placement in lists (trailing, leading)")
 	rootCmd.Flags().StringVar(&sqlModeFlag, "sql-mode", config.SQLModeDefault,
-		"SQL mode for string-literal parsing (default, no_backslash_escapes)")
+		"SQL modes, comma-separated (default, no_backslash_escapes, ansi_quotes, pipes_as_concat)")
+	rootCmd.Flags().Float64Var(&detectFlag, "detect-threshold", config.DefaultDetectThreshold,
+		"minimum SQL detection score (0 < t <= 1) for a string literal to be formatted")
+	rootCmd.Flags().BoolVar(&collapseFlag, "collapse-concat", false,
+		"collapse a + chain of SQL string literals into a single raw string literal")
+	rootCmd.Flags().BoolVar(&convertFlag, "convert-interpreted", false,
+		"format SQL in interpreted (double-quoted) string literals, converting them to raw string literals")
+	rootCmd.Flags().StringVar(&quotingFlag, "identifier-quoting", config.IdentifierQuotingWarn,
+		"handling of SQL that needs a backtick in a raw string literal (warn, ansi, concat)")
+	rootCmd.Flags().StringVar(&rowAliasFlag, "values-row-alias", "",
+		"rewrite VALUES(col) in ON DUPLICATE KEY UPDATE to this INSERT row alias (empty: keep VALUES(col))")
 	rootCmd.Flags().StringVarP(&configFlag, "config", "c", "", "path to config file")
 }
 
@@ -116,7 +133,32 @@ func mergeConfig(cmd *cobra.Command, cfg config.Config) {
 		}},
 		{"sql-mode", func() {
 			if cfg.SQLMode != nil {
-				sqlModeFlag = *cfg.SQLMode
+				sqlModeFlag = cfg.SQLMode.String()
+			}
+		}},
+		{"detect-threshold", func() {
+			if cfg.DetectThreshold != nil {
+				detectFlag = *cfg.DetectThreshold
+			}
+		}},
+		{"collapse-concat", func() {
+			if cfg.CollapseConcat != nil {
+				collapseFlag = *cfg.CollapseConcat
+			}
+		}},
+		{"convert-interpreted", func() {
+			if cfg.ConvertInterpreted != nil {
+				convertFlag = *cfg.ConvertInterpreted
+			}
+		}},
+		{"identifier-quoting", func() {
+			if cfg.IdentifierQuoting != nil {
+				quotingFlag = *cfg.IdentifierQuoting
+			}
+		}},
+		{"values-row-alias", func() {
+			if cfg.ValuesRowAlias != nil {
+				rowAliasFlag = *cfg.ValuesRowAlias
 			}
 		}},
 	}
@@ -141,10 +183,38 @@ func validateFlags() error {
 		return fmt.Errorf("%w: %q", config.ErrInvalidCommaStyle, commaStyleFlag)
 	}
 
-	switch sqlModeFlag {
-	case config.SQLModeDefault, config.SQLModeNoBackslashEscapes:
+	for mode := range strings.SplitSeq(sqlModeFlag, ",") {
+		switch strings.TrimSpace(mode) {
+		case config.SQLModeDefault, config.SQLModeNoBackslashEscapes, config.SQLModeANSIQuotes,
+			config.SQLModePipesAsConcat:
+		default:
+			return fmt.Errorf("%w: %q", config.ErrInvalidSQLMode, mode)
+		}
+	}
+
+	if detectFlag <= 0 || detectFlag > 1 {
+		return fmt.Errorf("%w: %v", config.ErrInvalidDetectThreshold, detectFlag)
+	}
+
+	return validateIdentifierFlags()
+}
+
+// validateIdentifierFlags validates the flags that control how identifiers
+// are emitted: --identifier-quoting and --values-row-alias.
+func validateIdentifierFlags() error {
+	switch quotingFlag {
+	case config.IdentifierQuotingWarn, config.IdentifierQuotingConcat:
+	case config.IdentifierQuotingANSI:
+		modes := strings.Split(sqlModeFlag, ",")
+		if !slices.ContainsFunc(modes, func(m string) bool { return strings.TrimSpace(m) == config.SQLModeANSIQuotes }) {
+			return config.ErrANSIQuotingNeedsMode
+		}
 	default:
-		return fmt.Errorf("%w: %q", config.ErrInvalidSQLMode, sqlModeFlag)
+		return fmt.Errorf("%w: %q", config.ErrInvalidIdentifierQuoting, quotingFlag)
+	}
+
+	if !sqlfmt.ValidValuesRowAlias(rowAliasFlag) {
+		return fmt.Errorf("%w: %q", config.ErrInvalidValuesRowAlias, rowAliasFlag)
 	}
 
 	return nil
@@ -152,11 +222,17 @@ func validateFlags() error {
 
 func opts() gofile.Options {
 	return gofile.Options{
-		Indent:      indentFlag,
-		Newline:     newlineFlag,
-		KeywordCase: keywordCaseFlag,
-		CommaStyle:  commaStyleFlag,
-		SQLMode:     sqlModeFlag,
+		Indent:             indentFlag,
+		Newline:            newlineFlag,
+		KeywordCase:        keywordCaseFlag,
+		CommaStyle:         commaStyleFlag,
+		SQLMode:            sqlModeFlag,
+		DetectThreshold:    detectFlag,
+		CollapseConcat:     collapseFlag,
+		ConvertInterpreted: convertFlag,
+		IdentifierQuoting:  quotingFlag,
+		ValuesRowAlias:     rowAliasFlag,
+		Warnings:           os.Stderr,
 	}
 }
 
@@ -229,12 +305,7 @@ func processFile(path, baseDir string) error {
 		return err
 	}
 
-	file, fset, literals, err := gofile.FindSQLLiterals(src, cleanPath)
-	if err != nil {
-		return err
-	}
-
-	out, err := gofile.RewriteFile(fset, file, literals, opts())
+	out, err := rewriteSource(src, cleanPath)
 	if err != nil {
 		return err
 	}
@@ -248,6 +319,22 @@ func processFile(path, baseDir string) error {
 	return err
 }
 
+// rewriteSource formats src, the contents of the file at path: a
+// gofile.SQLTemplateExt file as a single SQL template, anything else as Go
+// source.
+func rewriteSource(src []byte, path string) ([]byte, error) {
+	if strings.HasSuffix(path, gofile.SQLTemplateExt) {
+		return gofile.RewriteSQLTemplate(src, opts()), nil
+	}
+
+	file, fset, literals, err := gofile.FindSQLLiterals(src, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return gofile.RewriteFile(fset, file, literals, opts())
+}
+
 func safePath(path, baseDir string) (string, error) {
 	abs, err := filepath.Abs(path)
 	if err != nil {
@@ -319,7 +406,7 @@ func resolvePattern(pattern string) ([]string, error) {
 	var goFiles []string
 
 	for _, m := range matches {
-		if strings.HasSuffix(m, ".go") {
+		if isSourceFile(m) {
 			goFiles = append(goFiles, m)
 		}
 	}
@@ -327,6 +414,12 @@ func resolvePattern(pattern string) ([]string, error) {
 	return goFiles, nil
 }
 
+// isSourceFile reports whether path names a file sanat formats: Go source
+// or a gofile.SQLTemplateExt template.
+func isSourceFile(path string) bool {
+	return strings.HasSuffix(path, ".go") || strings.HasSuffix(path, gofile.SQLTemplateExt)
+}
+
 func walkDir(root string) ([]string, error) {
 	var files []string
 
@@ -339,7 +432,7 @@ func walkDir(root string) ([]string, error) {
 			return filepath.SkipDir
 		}
 
-		if !d.IsDir() && strings.HasSuffix(path, ".go") {
+		if !d.IsDir() && isSourceFile(path) {
 			files = append(files, path)
 		}
 
@@ -144,6 +144,28 @@ EOF
   grep -qF "  'it\'s'" "${BATS_TEST_TMPDIR}/got.go"
 }
 
+@test "sql_mode: a list in the config file combines ansi_quotes with no_backslash_escapes" {
+  cat > "${BATS_TEST_TMPDIR}/.sanat.yml" <<'EOF'
+version: 1
+sql_mode: [ansi_quotes, no_backslash_escapes]
+EOF
+
+  cat > "${BATS_TEST_TMPDIR}/quote.go" <<'EOF'
+package sample
+
+import "database/sql"
+
+func query(db *sql.DB) {
+	db.Query(`select "order" from t where c = 'it''s'`)
+}
+EOF
+
+  (cd "${BATS_TEST_TMPDIR}" && "${SANAT_BIN}" quote.go > got.go)
+
+  grep -qF '  "order"' "${BATS_TEST_TMPDIR}/got.go"
+  grep -qF "  c = 'it''s'" "${BATS_TEST_TMPDIR}/got.go"
+}
+
 @test "an invalid --sql-mode flag value fails with a clear error" {
   run --separate-stderr "${SANAT_BIN}" --sql-mode sideways "${BATS_TEST_TMPDIR}/sample.go"
 
@@ -152,6 +174,69 @@ EOF
   [[ "$stderr" == *"sideways"* ]]
 }
 
+@test "detect_threshold in the config file leaves a keyword-only literal untouched" {
+  cat > "${BATS_TEST_TMPDIR}/.sanat.yml" <<'EOF'
+version: 1
+detect_threshold: 0.9
+EOF
+
+  cat > "${BATS_TEST_TMPDIR}/bare.go" <<'EOF'
+package sample
+
+var q = `select 1`
+EOF
+
+  (cd "${BATS_TEST_TMPDIR}" && "${SANAT_BIN}" bare.go > got.go)
+
+  grep -qF 'var q = `select 1`' "${BATS_TEST_TMPDIR}/got.go"
+}
+
+@test "an out-of-range --detect-threshold flag value fails with a clear error" {
+  run --separate-stderr "${SANAT_BIN}" --detect-threshold 1.5 "${BATS_TEST_TMPDIR}/sample.go"
+
+  [ "$status" -ne 0 ]
+  [[ "$stderr" == *"detect_threshold"* ]]
+}
+
+@test "convert_interpreted: true in the config file converts a double-quoted SQL literal" {
+  cat > "${BATS_TEST_TMPDIR}/.sanat.yml" <<'EOF'
+version: 1
+convert_interpreted: true
+newline: false
+EOF
+
+  cat > "${BATS_TEST_TMPDIR}/interpreted.go" <<'EOF'
+package sample
+
+var q = "select id from users"
+EOF
+
+  (cd "${BATS_TEST_TMPDIR}" && "${SANAT_BIN}" interpreted.go > got.go)
+
+  grep -qF 'var q = `SELECT' "${BATS_TEST_TMPDIR}/got.go"
+}
+
+@test "a converted literal with a quoted identifier is left unchanged with a warning by default" {
+  cat > "${BATS_TEST_TMPDIR}/quoted.go" <<'EOF'
+package sample
+
+var q = "select `order` from t"
+EOF
+
+  (cd "${BATS_TEST_TMPDIR}" && "${SANAT_BIN}" --convert-interpreted quoted.go > got.go 2> stderr.txt)
+
+  grep -qF 'var q = "select `order` from t"' "${BATS_TEST_TMPDIR}/got.go"
+  grep -q 'warning: .*quoted.go:3:9: .*identifier_quoting' "${BATS_TEST_TMPDIR}/stderr.txt"
+}
+
+@test "an invalid --identifier-quoting flag value fails with a clear error" {
+  run --separate-stderr "${SANAT_BIN}" --identifier-quoting brackets "${BATS_TEST_TMPDIR}/sample.go"
+
+  [ "$status" -ne 0 ]
+  [[ "$stderr" == *"identifier_quoting"* ]]
+  [[ "$stderr" == *"brackets"* ]]
+}
+
 @test "an unsupported config version fails with a clear error" {
   cat > "${BATS_TEST_TMPDIR}/.sanat.yml" <<'EOF'
 version: 99
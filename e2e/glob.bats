@@ -19,3 +19,11 @@ setup() {
   grep -q '^SELECT' "${BATS_TEST_TMPDIR}/globdir/a.go"
   grep -q '^SELECT' "${BATS_TEST_TMPDIR}/globdir/nested/b.go"
 }
+
+@test "./... also formats .sql.tmpl templates, keeping their actions" {
+  (cd "${BATS_TEST_TMPDIR}/globdir" && "${SANAT_BIN}" -w ./...)
+
+  grep -q '^SELECT' "${BATS_TEST_TMPDIR}/globdir/nested/query.sql.tmpl"
+  grep -q '^  {{.Table}}$' "${BATS_TEST_TMPDIR}/globdir/nested/query.sql.tmpl"
+  grep -q '^{{if .ID}}$' "${BATS_TEST_TMPDIR}/globdir/nested/query.sql.tmpl"
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
 )
 
 // CurrentVersion is the latest supported config schema version.
@@ -24,34 +28,123 @@ const (
 
 	SQLModeDefault            = "default"
 	SQLModeNoBackslashEscapes = "no_backslash_escapes"
+	SQLModeANSIQuotes         = "ansi_quotes"
+	SQLModePipesAsConcat      = "pipes_as_concat"
+
+	DefaultDetectThreshold = 0.5
+
+	IdentifierQuotingWarn   = "warn"
+	IdentifierQuotingANSI   = "ansi"
+	IdentifierQuotingConcat = "concat"
 )
 
 var (
-	ErrUnsupportedVersion = errors.New("unsupported config version")
-	ErrInvalidIndent      = errors.New("indent must be a positive integer")
-	ErrInvalidKeywordCase = errors.New("keyword_case must be one of: upper, lower, preserve")
-	ErrInvalidCommaStyle  = errors.New("comma_style must be one of: trailing, leading")
-	ErrInvalidSQLMode     = errors.New("sql_mode must be one of: default, no_backslash_escapes")
+	ErrUnsupportedVersion       = errors.New("unsupported config version")
+	ErrInvalidIndent            = errors.New("indent must be a positive integer")
+	ErrInvalidKeywordCase       = errors.New("keyword_case must be one of: upper, lower, preserve")
+	ErrInvalidCommaStyle        = errors.New("comma_style must be one of: trailing, leading")
+	ErrInvalidDetectThreshold   = errors.New("detect_threshold must be greater than 0 and at most 1")
+	ErrInvalidIdentifierQuoting = errors.New("identifier_quoting must be one of: warn, ansi, concat")
+	ErrANSIQuotingNeedsMode     = errors.New("identifier_quoting ansi requires sql_mode to include ansi_quotes")
+	ErrInvalidValuesRowAlias    = errors.New("values_row_alias must be an unquoted identifier that isn't a keyword")
+	ErrInvalidSQLMode           = errors.New(
+		"sql_mode must be one or more of: default, no_backslash_escapes, ansi_quotes, pipes_as_concat")
 )
 
 var knownFields = map[string]bool{
-	"version":      true,
-	"write":        true,
-	"indent":       true,
-	"newline":      true,
-	"keyword_case": true,
-	"comma_style":  true,
-	"sql_mode":     true,
+	"version":             true,
+	"write":               true,
+	"indent":              true,
+	"newline":             true,
+	"keyword_case":        true,
+	"comma_style":         true,
+	"sql_mode":            true,
+	"detect_threshold":    true,
+	"collapse_concat":     true,
+	"convert_interpreted": true,
+	"identifier_quoting":  true,
+	"values_row_alias":    true,
 }
 
 type Config struct {
-	Version     *int    `toml:"version,omitempty"      yaml:"version,omitempty"`
-	Write       *bool   `toml:"write,omitempty"        yaml:"write,omitempty"`
-	Indent      *int    `toml:"indent,omitempty"       yaml:"indent,omitempty"`
-	Newline     *bool   `toml:"newline,omitempty"      yaml:"newline,omitempty"`
-	KeywordCase *string `toml:"keyword_case,omitempty" yaml:"keyword_case,omitempty"`
-	CommaStyle  *string `toml:"comma_style,omitempty"  yaml:"comma_style,omitempty"`
-	SQLMode     *string `toml:"sql_mode,omitempty"     yaml:"sql_mode,omitempty"`
+	Version            *int     `toml:"version,omitempty"             yaml:"version,omitempty"`
+	Write              *bool    `toml:"write,omitempty"               yaml:"write,omitempty"`
+	Indent             *int     `toml:"indent,omitempty"              yaml:"indent,omitempty"`
+	Newline            *bool    `toml:"newline,omitempty"             yaml:"newline,omitempty"`
+	KeywordCase        *string  `toml:"keyword_case,omitempty"        yaml:"keyword_case,omitempty"`
+	CommaStyle         *string  `toml:"comma_style,omitempty"         yaml:"comma_style,omitempty"`
+	SQLMode            SQLModes `toml:"sql_mode,omitempty"            yaml:"sql_mode,omitempty"`
+	DetectThreshold    *float64 `toml:"detect_threshold,omitempty"    yaml:"detect_threshold,omitempty"`
+	CollapseConcat     *bool    `toml:"collapse_concat,omitempty"     yaml:"collapse_concat,omitempty"`
+	ConvertInterpreted *bool    `toml:"convert_interpreted,omitempty" yaml:"convert_interpreted,omitempty"`
+	IdentifierQuoting  *string  `toml:"identifier_quoting,omitempty"  yaml:"identifier_quoting,omitempty"`
+	ValuesRowAlias     *string  `toml:"values_row_alias,omitempty"    yaml:"values_row_alias,omitempty"`
+}
+
+// SQLModes is the sql_mode config field: a list of modes, combined like
+// MySQL's sql_mode. It also decodes from a single string, either one mode
+// or several separated by commas, so `sql_mode: default` keeps working. Nil
+// means the field is unset.
+type SQLModes []string
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting a string or a
+// sequence of strings.
+func (m *SQLModes) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*m = splitSQLModes(node.Value)
+
+		return nil
+	}
+
+	var modes []string
+	if err := node.Decode(&modes); err != nil {
+		return err
+	}
+
+	*m = modes
+
+	return nil
+}
+
+// UnmarshalTOML implements toml.Unmarshaler, accepting a string or an array
+// of strings.
+func (m *SQLModes) UnmarshalTOML(data any) error {
+	switch v := data.(type) {
+	case string:
+		*m = splitSQLModes(v)
+	case []any:
+		modes := make(SQLModes, 0, len(v))
+
+		for _, elem := range v {
+			mode, ok := elem.(string)
+			if !ok {
+				return fmt.Errorf("%w: %v", ErrInvalidSQLMode, elem)
+			}
+
+			modes = append(modes, mode)
+		}
+
+		*m = modes
+	default:
+		return fmt.Errorf("%w: %v", ErrInvalidSQLMode, data)
+	}
+
+	return nil
+}
+
+// String joins m with commas, the form the --sql-mode flag takes.
+func (m SQLModes) String() string {
+	return strings.Join(m, ",")
+}
+
+func splitSQLModes(s string) SQLModes {
+	modes := SQLModes{}
+
+	for mode := range strings.SplitSeq(s, ",") {
+		modes = append(modes, strings.TrimSpace(mode))
+	}
+
+	return modes
 }
 
 var configFiles = []string{
@@ -123,6 +216,9 @@ func validate(cfg Config) error {
 		validateKeywordCase,
 		validateCommaStyle,
 		validateSQLMode,
+		validateDetectThreshold,
+		validateIdentifierQuoting,
+		validateValuesRowAlias,
 	} {
 		if err := check(cfg); err != nil {
 			return err
@@ -175,16 +271,56 @@ func validateCommaStyle(cfg Config) error {
 }
 
 func validateSQLMode(cfg Config) error {
-	if cfg.SQLMode == nil {
+	for _, mode := range cfg.SQLMode {
+		switch mode {
+		case SQLModeDefault, SQLModeNoBackslashEscapes, SQLModeANSIQuotes, SQLModePipesAsConcat:
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidSQLMode, mode)
+		}
+	}
+
+	return nil
+}
+
+func validateDetectThreshold(cfg Config) error {
+	if cfg.DetectThreshold == nil {
+		return nil
+	}
+
+	if t := *cfg.DetectThreshold; t <= 0 || t > 1 {
+		return fmt.Errorf("%w: %v", ErrInvalidDetectThreshold, t)
+	}
+
+	return nil
+}
+
+func validateIdentifierQuoting(cfg Config) error {
+	if cfg.IdentifierQuoting == nil {
 		return nil
 	}
 
-	switch *cfg.SQLMode {
-	case SQLModeDefault, SQLModeNoBackslashEscapes:
+	switch *cfg.IdentifierQuoting {
+	case IdentifierQuotingWarn, IdentifierQuotingConcat:
+		return nil
+	case IdentifierQuotingANSI:
+		// "order" is only an identifier under ANSI_QUOTES; otherwise it's a
+		// string, and a column reference would silently become a constant.
+		if !slices.Contains(cfg.SQLMode, SQLModeANSIQuotes) {
+			return ErrANSIQuotingNeedsMode
+		}
+
 		return nil
 	default:
-		return fmt.Errorf("%w: %q", ErrInvalidSQLMode, *cfg.SQLMode)
+		return fmt.Errorf("%w: %q", ErrInvalidIdentifierQuoting, *cfg.IdentifierQuoting)
+	}
+}
+
+func validateValuesRowAlias(cfg Config) error {
+	if cfg.ValuesRowAlias != nil && !sqlfmt.ValidValuesRowAlias(*cfg.ValuesRowAlias) {
+		return fmt.Errorf("%w: %q", ErrInvalidValuesRowAlias, *cfg.ValuesRowAlias)
 	}
+
+	return nil
 }
 
 // warn prints deprecation and forward-compatibility warnings for the decoded
@@ -305,12 +305,198 @@ func TestLoad_CommaStyle(t *testing.T) {
 }
 
 func TestLoad_SQLMode(t *testing.T) {
-	valid := []string{config.SQLModeDefault, config.SQLModeNoBackslashEscapes}
-	get := func(cfg config.Config) *string { return cfg.SQLMode }
+	valid := []string{
+		config.SQLModeDefault, config.SQLModeNoBackslashEscapes, config.SQLModeANSIQuotes, config.SQLModePipesAsConcat,
+	}
+	get := func(cfg config.Config) *string {
+		if cfg.SQLMode == nil {
+			return nil
+		}
+
+		s := cfg.SQLMode.String()
+
+		return &s
+	}
 
 	assertValidatedStringField(t, "sql_mode", valid, get, config.ErrInvalidSQLMode)
 }
 
+func TestLoad_SQLMode_List(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    config.SQLModes
+		wantErr error
+	}{
+		{"yaml sequence", ".sanat.yml", "sql_mode: [ansi_quotes, no_backslash_escapes]\n",
+			config.SQLModes{"ansi_quotes", "no_backslash_escapes"}, nil},
+		{"yaml comma-separated string", ".sanat.yml", "sql_mode: ansi_quotes,no_backslash_escapes\n",
+			config.SQLModes{"ansi_quotes", "no_backslash_escapes"}, nil},
+		{"toml array", ".sanat.toml", "sql_mode = [\"ansi_quotes\", \"no_backslash_escapes\"]\n",
+			config.SQLModes{"ansi_quotes", "no_backslash_escapes"}, nil},
+		{"toml string", ".sanat.toml", "sql_mode = \"ansi_quotes\"\n",
+			config.SQLModes{"ansi_quotes"}, nil},
+		{"invalid element", ".sanat.yml", "sql_mode: [ansi_quotes, sideways]\n",
+			nil, config.ErrInvalidSQLMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := config.Load(dir)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && cfg.SQLMode.String() != tt.want.String() {
+				t.Errorf("sql_mode: got %q, want %q", cfg.SQLMode, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_IdentifierQuoting(t *testing.T) {
+	valid := []string{config.IdentifierQuotingWarn, config.IdentifierQuotingConcat}
+	get := func(cfg config.Config) *string { return cfg.IdentifierQuoting }
+
+	assertValidatedStringField(t, "identifier_quoting", valid, get, config.ErrInvalidIdentifierQuoting)
+}
+
+func TestLoad_IdentifierQuotingANSI(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"with ansi_quotes", "identifier_quoting: ansi\nsql_mode: [no_backslash_escapes, ansi_quotes]\n", nil},
+		{"without sql_mode", "identifier_quoting: ansi\n", config.ErrANSIQuotingNeedsMode},
+		{"without ansi_quotes", "identifier_quoting: ansi\nsql_mode: no_backslash_escapes\n", config.ErrANSIQuotingNeedsMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			if err := os.WriteFile(filepath.Join(dir, ".sanat.yml"), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := config.Load(dir)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_ValuesRowAlias(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		{"identifier", "new", nil},
+		{"empty disables", `""`, nil},
+		{"keyword", "values", config.ErrInvalidValuesRowAlias},
+		{"quoted", "'`new`'", config.ErrInvalidValuesRowAlias},
+		{"not an identifier", `"new row"`, config.ErrInvalidValuesRowAlias},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			content := "values_row_alias: " + tt.value + "\n"
+
+			if err := os.WriteFile(filepath.Join(dir, ".sanat.yml"), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := config.Load(dir)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_DetectThreshold(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".sanat.yml"), []byte("detect_threshold: 0.8\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.DetectThreshold == nil || *cfg.DetectThreshold != 0.8 {
+		t.Errorf("detect_threshold: got %v, want 0.8", cfg.DetectThreshold)
+	}
+}
+
+func TestLoad_DetectThreshold_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"zero", "detect_threshold: 0\n"},
+		{"negative", "detect_threshold: -0.5\n"},
+		{"above one", "detect_threshold: 1.5\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, ".sanat.yml"), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := config.Load(dir)
+			if !errors.Is(err, config.ErrInvalidDetectThreshold) {
+				t.Errorf("got %v, want ErrInvalidDetectThreshold", err)
+			}
+		})
+	}
+}
+
+func TestLoad_CollapseConcat(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".sanat.toml"), []byte("collapse_concat = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.CollapseConcat == nil || !*cfg.CollapseConcat {
+		t.Errorf("collapse_concat: got %v, want true", cfg.CollapseConcat)
+	}
+}
+
+func TestLoad_ConvertInterpreted(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".sanat.yml"), []byte("convert_interpreted: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.ConvertInterpreted == nil || !*cfg.ConvertInterpreted {
+		t.Errorf("convert_interpreted: got %v, want true", cfg.ConvertInterpreted)
+	}
+}
+
 func TestLoad_UnknownField_WarnsButSucceeds(t *testing.T) {
 	dir := t.TempDir()
 	content := "version: 1\nindent: 4\nnot_a_real_field: true\n"
@@ -0,0 +1,250 @@
+package gofile
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
+)
+
+// SQLConcat is a `+` chain of raw string literals that may build one SQL
+// statement, e.g. `SELECT * FROM ` + table + ` WHERE id = ?`. Operands that
+// aren't string literals (table above) are splices: their value is unknown,
+// so they're formatted as opaque identifiers.
+type SQLConcat struct {
+	Root     *ast.BinaryExpr
+	Operands []ast.Expr
+
+	// Printf reports whether the chain is the format string of a fmt call,
+	// as for SQLLiteral.Printf.
+	Printf bool
+}
+
+// FindSQLConcats returns every `+` chain in file that concatOperands
+// accepts, outermost first. FindSQLLiterals skips the literal operands of
+// these chains; RewriteFile formats them as a unit instead.
+func FindSQLConcats(file *ast.File) []SQLConcat {
+	var concats []SQLConcat
+
+	formats := printfFormats(file)
+
+	inspectSQLCandidates(file, func(*ast.BasicLit) {}, func(c SQLConcat) {
+		c.Printf = formats[c.Root]
+		concats = append(concats, c)
+	})
+
+	return concats
+}
+
+// concatOperands flattens the left-associative `+` chain rooted at expr into
+// its operands, reporting whether it is a chain worth formatting: every
+// string literal operand is a raw string, at least one operand is, and no
+// two non-literal operands are adjacent (their sentinels would have nothing
+// between them to tell them apart). Any other literal operand — an
+// interpreted string or a number — means expr isn't a SQL concatenation, or
+// is one sanat never rewrites, the same way it skips a lone interpreted
+// string.
+func concatOperands(expr *ast.BinaryExpr) ([]ast.Expr, bool) {
+	if expr.Op != token.ADD {
+		return nil, false
+	}
+
+	operands := addOperands(expr)
+	hasRawString := false
+
+	for i, op := range operands {
+		lit, isLit := op.(*ast.BasicLit)
+
+		switch {
+		case isLit && (lit.Kind != token.STRING || !isRawStringLit(lit.Value)):
+			return nil, false
+		case isLit:
+			hasRawString = true
+		case i > 0:
+			if _, prevIsLit := operands[i-1].(*ast.BasicLit); !prevIsLit {
+				return nil, false
+			}
+		}
+	}
+
+	return operands, hasRawString
+}
+
+// addOperands flattens the left-associative `+` chain rooted at expr into
+// its operands, in source order.
+func addOperands(expr *ast.BinaryExpr) []ast.Expr {
+	var operands []ast.Expr
+
+	for e := ast.Expr(expr); ; {
+		bin, ok := e.(*ast.BinaryExpr)
+		if !ok || bin.Op != token.ADD {
+			operands = append(operands, e)
+
+			break
+		}
+
+		operands = append(operands, bin.Y)
+		e = bin.X
+	}
+
+	for i, j := 0, len(operands)-1; i < j; i, j = i+1, j-1 {
+		operands[i], operands[j] = operands[j], operands[i]
+	}
+
+	return operands
+}
+
+// rewriteConcat formats c as one statement. By default each literal operand
+// keeps its place in the chain and receives its share of the formatted text
+// (see sqlfmt.FormatSQLFragments), and c is left untouched when a fragment
+// boundary has no natural place in the output. With opts.CollapseConcat,
+// adjacent literal operands are first merged into one, so a chain with no
+// splices collapses into a single raw string literal, unless a comment sits
+// inside the chain: the merged literal would leave it nothing to follow, so
+// go/printer would move it after the statement.
+func rewriteConcat(file *ast.File, c SQLConcat, opts Options) {
+	operands := c.Operands
+	if opts.CollapseConcat && !hasCommentWithin(file, c.Root) {
+		operands = mergeAdjacentLiterals(operands)
+	}
+
+	fragments := make([]sqlfmt.Fragment, len(operands))
+
+	for i, op := range operands {
+		if lit, ok := op.(*ast.BasicLit); ok {
+			fragments[i] = sqlfmt.Fragment{Text: lit.Value[1 : len(lit.Value)-1]}
+		} else {
+			fragments[i] = sqlfmt.Fragment{Splice: true}
+		}
+	}
+
+	dopts := detectOptions(opts)
+	dopts.PrintfTemplate = c.Printf
+
+	if !sqlfmt.MightBeSQLWithOptions(sqlfmt.JoinFragments(fragments), dopts) {
+		return
+	}
+
+	fopts := sqlfmtOptions(opts)
+	fopts.PrintfTemplate = c.Printf
+
+	formatted, ok := sqlfmt.FormatSQLFragments(fragments, fopts)
+	if !ok {
+		return
+	}
+
+	last := len(formatted) - 1
+
+	for i, frag := range formatted {
+		lit, ok := operands[i].(*ast.BasicLit)
+		if !ok {
+			continue
+		}
+
+		text := frag.Text
+		if i == last {
+			text = strings.TrimRight(text, "\n")
+		}
+
+		if opts.Newline && i == 0 {
+			text = "\n" + text
+		}
+
+		if opts.Newline && i == last {
+			text += "\n"
+		}
+
+		lit.Value = "`" + text + "`"
+	}
+
+	if len(operands) != len(c.Operands) {
+		replaceExpr(file, c.Root, chainOf(operands))
+	}
+}
+
+// mergeAdjacentLiterals returns operands with every run of adjacent raw
+// string literals replaced by a single literal holding their concatenated
+// text, positioned at the first literal of the run.
+func mergeAdjacentLiterals(operands []ast.Expr) []ast.Expr {
+	var merged []ast.Expr
+
+	for _, op := range operands {
+		lit, isLit := op.(*ast.BasicLit)
+
+		if prev := len(merged) - 1; isLit && prev >= 0 {
+			if prevLit, ok := merged[prev].(*ast.BasicLit); ok {
+				merged[prev] = &ast.BasicLit{
+					ValuePos: prevLit.ValuePos,
+					Kind:     token.STRING,
+					Value:    prevLit.Value[:len(prevLit.Value)-1] + lit.Value[1:],
+				}
+
+				continue
+			}
+		}
+
+		merged = append(merged, op)
+	}
+
+	return merged
+}
+
+// hasCommentWithin reports whether any comment of file lies inside node.
+func hasCommentWithin(file *ast.File, node ast.Node) bool {
+	for _, group := range file.Comments {
+		if group.Pos() >= node.Pos() && group.End() <= node.End() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// chainOf builds the left-associative `+` chain of operands, or returns the
+// sole operand itself when there's only one.
+func chainOf(operands []ast.Expr) ast.Expr {
+	chain := operands[0]
+
+	for _, op := range operands[1:] {
+		chain = &ast.BinaryExpr{X: chain, OpPos: op.Pos(), Op: token.ADD, Y: op}
+	}
+
+	return chain
+}
+
+var exprType = reflect.TypeFor[ast.Expr]()
+
+// replaceExpr replaces every reference to old in file's AST with
+// replacement. A collapsed chain can change node type (a BinaryExpr becoming
+// a BasicLit), so it can't be updated in place the way a literal's Value is;
+// go/ast has no parent links, so this finds the referencing field by
+// reflecting over every node's ast.Expr and []ast.Expr fields.
+func replaceExpr(file *ast.File, old, replacement ast.Expr) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		v := reflect.ValueOf(n)
+		if n == nil || v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+			return true
+		}
+
+		v = v.Elem()
+
+		for i := range v.NumField() {
+			field := v.Field(i)
+
+			switch {
+			case field.Type() == exprType && !field.IsNil() && field.Interface() == old:
+				field.Set(reflect.ValueOf(&replacement).Elem())
+			case field.Kind() == reflect.Slice && field.Type().Elem() == exprType:
+				for j := range field.Len() {
+					if elem := field.Index(j); !elem.IsNil() && elem.Interface() == old {
+						elem.Set(reflect.ValueOf(&replacement).Elem())
+					}
+				}
+			}
+		}
+
+		return true
+	})
+}
@@ -2,9 +2,11 @@ package gofile
 
 import (
 	"bytes"
+	"fmt"
 	"go/ast"
 	"go/format"
 	"go/token"
+	"io"
 	"strings"
 
 	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
@@ -16,30 +18,59 @@ type Options struct {
 	KeywordCase string
 	CommaStyle  string
 	SQLMode     string
+
+	// DetectThreshold is the minimum sqlfmt.DetectionScore a literal must
+	// reach to be formatted. Zero selects sqlfmt.DefaultDetectThreshold.
+	DetectThreshold float64
+
+	// CollapseConcat merges the adjacent literal operands of a `+` chain
+	// into one raw string literal before formatting (see rewriteConcat),
+	// instead of redistributing the formatted text across them.
+	CollapseConcat bool
+
+	// ConvertInterpreted also formats interpreted (double-quoted) string
+	// literals that a raw string literal can hold (see
+	// FindInterpretedSQLLiterals), emitting them as raw string literals.
+	ConvertInterpreted bool
+
+	// IdentifierQuoting is the policy for formatted SQL that needs a
+	// backtick, which a raw string literal can't hold: IdentifierQuotingWarn
+	// (the default), IdentifierQuotingANSI, which needs SQLMode to include
+	// sqlfmt.SQLModeANSIQuotes, or IdentifierQuotingConcat. See
+	// rewriteLiteral.
+	IdentifierQuoting string
+
+	// ValuesRowAlias, when non-empty, rewrites the deprecated VALUES(col)
+	// references in an INSERT's ON DUPLICATE KEY UPDATE clause to the row
+	// alias form. See sqlfmt.Options.ValuesRowAlias.
+	ValuesRowAlias string
+
+	// Warnings receives a line for every literal IdentifierQuotingWarn
+	// leaves unchanged. Nil discards them.
+	Warnings io.Writer
 }
 
-func RewriteFile(fset *token.FileSet, file *ast.File, literals []SQLLiteral, opts Options) ([]byte, error) {
-	for _, lit := range literals {
-		if !sqlfmt.MightBeSQL(lit.Original) {
-			continue
-		}
+const (
+	IdentifierQuotingWarn   = "warn"
+	IdentifierQuotingANSI   = "ansi"
+	IdentifierQuotingConcat = "concat"
+)
 
-		formatted, ok := sqlfmt.FormatSQLWithOptions(lit.Original, sqlfmt.Options{
-			Indent:      opts.Indent,
-			KeywordCase: opts.KeywordCase,
-			CommaStyle:  opts.CommaStyle,
-			SQLMode:     opts.SQLMode,
-		})
-		if !ok {
-			continue
-		}
+// RewriteFile formats every SQL literal in literals, plus every `+` chain of
+// raw string literals in file (see FindSQLConcats) and, with
+// opts.ConvertInterpreted, every convertible interpreted string literal,
+// and returns the resulting source.
+func RewriteFile(fset *token.FileSet, file *ast.File, literals []SQLLiteral, opts Options) ([]byte, error) {
+	if opts.ConvertInterpreted {
+		literals = append(literals, FindInterpretedSQLLiterals(file)...)
+	}
 
-		formatted = strings.TrimRight(formatted, "\n")
-		if opts.Newline {
-			formatted = "\n" + formatted + "\n"
-		}
+	for _, lit := range literals {
+		rewriteLiteral(fset, file, lit, opts)
+	}
 
-		lit.Node.Value = "`" + formatted + "`"
+	for _, c := range FindSQLConcats(file) {
+		rewriteConcat(file, c, opts)
 	}
 
 	var buf bytes.Buffer
@@ -49,3 +80,129 @@ func RewriteFile(fset *token.FileSet, file *ast.File, literals []SQLLiteral, opt
 
 	return buf.Bytes(), nil
 }
+
+// rewriteLiteral formats lit in place. Formatted SQL can only contain a
+// backtick when lit's value did, which means lit is a converted interpreted
+// string (see Options.ConvertInterpreted) with a quoted identifier or a
+// backtick inside a SQL string, and opts.IdentifierQuoting decides what
+// happens then:
+//
+//   - IdentifierQuotingConcat replaces lit with a `+` chain that spells each
+//     backtick as "`".
+//   - IdentifierQuotingANSI relies on the ANSI_QUOTES SQL mode, which it
+//     requires, to quote identifiers with double quotes instead, so only a
+//     backtick inside a SQL string is left, and that is handled as for
+//     IdentifierQuotingWarn. Without that mode it is IdentifierQuotingWarn:
+//     "order" would be a string there, not an identifier.
+//   - IdentifierQuotingWarn leaves lit unchanged and reports it to
+//     opts.Warnings.
+//
+// Under the ANSI_QUOTES SQL mode, identifiers are double-quoted whatever
+// the policy, so the question doesn't come up for them.
+func rewriteLiteral(fset *token.FileSet, file *ast.File, lit SQLLiteral, opts Options) {
+	dopts := detectOptions(opts)
+	dopts.PrintfTemplate = lit.Printf
+	dopts.TextTemplate = lit.Template
+
+	if !sqlfmt.MightBeSQLWithOptions(lit.Original, dopts) {
+		return
+	}
+
+	fopts := sqlfmtOptions(opts)
+	fopts.PrintfTemplate = lit.Printf
+	fopts.TextTemplate = lit.Template
+
+	formatted, ok := sqlfmt.FormatSQLWithOptions(lit.Original, fopts)
+	if !ok {
+		return
+	}
+
+	formatted = strings.TrimRight(formatted, "\n")
+	if opts.Newline {
+		formatted = "\n" + formatted + "\n"
+	}
+
+	if !strings.Contains(formatted, "`") {
+		lit.Node.Value = "`" + formatted + "`"
+
+		return
+	}
+
+	if opts.IdentifierQuoting == IdentifierQuotingConcat {
+		replaceExpr(file, lit.Node, backtickConcat(formatted, lit.Node.ValuePos))
+
+		return
+	}
+
+	if opts.Warnings != nil {
+		fmt.Fprintf(opts.Warnings,
+			"sanat: warning: %s: formatted SQL needs a backtick, which a raw string literal can't hold; "+
+				"left unchanged (set identifier_quoting to ansi or concat to format it)\n",
+			fset.Position(lit.Node.ValuePos))
+	}
+}
+
+// backtickConcat returns a `+` chain that evaluates to text: raw string
+// literals for the runs between backticks, and the interpreted string "`"
+// for each backtick.
+func backtickConcat(text string, pos token.Pos) ast.Expr {
+	var operands []ast.Expr
+
+	runs := strings.Split(text, "`")
+
+	for i, run := range runs {
+		if run != "" {
+			operands = append(operands, &ast.BasicLit{ValuePos: pos, Kind: token.STRING, Value: "`" + run + "`"})
+		}
+
+		if i < len(runs)-1 {
+			operands = append(operands, &ast.BasicLit{ValuePos: pos, Kind: token.STRING, Value: "\"`\""})
+		}
+	}
+
+	return chainOf(operands)
+}
+
+// SQLTemplateExt is the file extension of a standalone text/template SQL
+// template, which RewriteSQLTemplate formats as a whole.
+const SQLTemplateExt = ".sql.tmpl"
+
+// RewriteSQLTemplate formats src, the contents of a SQLTemplateExt file, as
+// a single text/template SQL statement (see sqlfmt.Options.TextTemplate).
+// src is returned unchanged when it isn't SQL or fails to format.
+// opts.Newline doesn't apply: the file has no literal to open.
+func RewriteSQLTemplate(src []byte, opts Options) []byte {
+	dopts := detectOptions(opts)
+	dopts.TextTemplate = true
+
+	if !sqlfmt.MightBeSQLWithOptions(string(src), dopts) {
+		return src
+	}
+
+	fopts := sqlfmtOptions(opts)
+	fopts.TextTemplate = true
+
+	formatted, ok := sqlfmt.FormatSQLWithOptions(string(src), fopts)
+	if !ok {
+		return src
+	}
+
+	return []byte(formatted)
+}
+
+func detectOptions(opts Options) sqlfmt.DetectOptions {
+	return sqlfmt.DetectOptions{
+		Threshold: opts.DetectThreshold,
+		SQLMode:   opts.SQLMode,
+	}
+}
+
+func sqlfmtOptions(opts Options) sqlfmt.Options {
+	return sqlfmt.Options{
+		Indent:         opts.Indent,
+		KeywordCase:    opts.KeywordCase,
+		CommaStyle:     opts.CommaStyle,
+		SQLMode:        opts.SQLMode,
+		ValuesRowAlias: opts.ValuesRowAlias,
+	}
+}
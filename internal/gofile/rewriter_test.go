@@ -1,10 +1,12 @@
 package gofile_test
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
 	"github.com/Eagle-Konbu/sanat/internal/gofile"
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
 )
 
 func TestRewriteFile_WithNewline(t *testing.T) {
@@ -76,6 +78,126 @@ func TestRewriteFile_DoubleQuotedSQLNotChanged(t *testing.T) {
 	}
 }
 
+func TestRewriteFile_ConvertInterpreted(t *testing.T) {
+	src := []byte(`package main
+
+var (
+	a = "select id from users where name = 'a\tb'"
+	b = "select ` + "`id`" + ` from users"
+	c = "select id from users\r\n"
+	d = "hello world"
+	e = "select id from users " + "where id = ?"
+	f = sqlOf("select id from users") + suffix
+)
+`)
+
+	file, fset, literals, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{Indent: 2, ConvertInterpreted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := string(out)
+
+	// The Go escape is decoded, and the SQL formatter escapes the tab again.
+	if want := "a = `SELECT\n  id\nFROM\n  users\nWHERE\n  name = 'a\\tb'`"; !strings.Contains(result, want) {
+		t.Errorf("convertible literal not converted, got:\n%s", result)
+	}
+
+	// A chain's operands are fragments, but a call spliced into one isn't.
+	if want := "f = sqlOf(`SELECT\n  id\nFROM\n  users`) + suffix"; !strings.Contains(result, want) {
+		t.Errorf("literal inside a chain's call operand not converted, got:\n%s", result)
+	}
+
+	for _, unchanged := range []string{
+		"b = \"select `id` from users\"",
+		`c = "select id from users\r\n"`,
+		`d = "hello world"`,
+		`e = "select id from users " + "where id = ?"`,
+	} {
+		if !strings.Contains(result, unchanged) {
+			t.Errorf("want %s unchanged, got:\n%s", unchanged, result)
+		}
+	}
+}
+
+func TestRewriteFile_IdentifierQuoting(t *testing.T) {
+	src := "package main\n\nvar q = \"select `order` from t\"\n"
+
+	tests := []struct {
+		name    string
+		policy  string
+		sqlMode string
+		want    string
+		warning bool
+	}{
+		{"warn", gofile.IdentifierQuotingWarn, "", "var q = \"select `order` from t\"\n", true},
+		{
+			"ansi", gofile.IdentifierQuotingANSI, sqlfmt.SQLModeANSIQuotes,
+			"var q = `SELECT\n  \"order\"\nFROM\n  t`\n", false,
+		},
+		// "order" would be a string without ANSI_QUOTES, so ansi warns instead.
+		{"ansi without ansi_quotes", gofile.IdentifierQuotingANSI, "", "var q = \"select `order` from t\"\n", true},
+		{
+			"concat", gofile.IdentifierQuotingConcat, "",
+			"var q = `SELECT\n  ` +\n\t\"`\" + `order` + \"`\" + `\nFROM\n  t`\n", false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, fset, literals, err := gofile.FindSQLLiterals([]byte(src), "test.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var warnings bytes.Buffer
+
+			out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{
+				Indent:             2,
+				ConvertInterpreted: true,
+				SQLMode:            tt.sqlMode,
+				IdentifierQuoting:  tt.policy,
+				Warnings:           &warnings,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result := string(out); !strings.HasSuffix(result, tt.want) {
+				t.Errorf("got:\n%s\nwant suffix:\n%s", result, tt.want)
+			}
+
+			if got := strings.Contains(warnings.String(), "test.go:3:9"); got != tt.warning {
+				t.Errorf("warning reported = %v, want %v (warnings: %q)", got, tt.warning, warnings.String())
+			}
+		})
+	}
+}
+
+func TestRewriteFile_ValuesRowAlias(t *testing.T) {
+	src := []byte("package main\n\nvar q = `insert into t (a) values (?) on duplicate key update a = values(a)`\n")
+
+	file, fset, literals, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{Indent: 2, ValuesRowAlias: "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "AS new\nON DUPLICATE KEY UPDATE\n  a = new.a`\n"
+	if result := string(out); !strings.HasSuffix(result, want) {
+		t.Errorf("got:\n%s\nwant suffix:\n%s", result, want)
+	}
+}
+
 func TestRewriteFile_BacktickIdentifiersStripped(t *testing.T) {
 	// "status" is a MySQL keyword that vitess backtick-quotes
 	src := []byte("package main\n\nvar q = `select status from users`\n")
@@ -100,3 +222,238 @@ func TestRewriteFile_BacktickIdentifiersStripped(t *testing.T) {
 		t.Errorf("should contain status column name, got:\n%s", result)
 	}
 }
+
+func TestRewriteFile_DetectThreshold(t *testing.T) {
+	// A leading keyword alone clears the default threshold, but not a raised one.
+	src := []byte("package main\n\nvar q = `select 1`\n")
+
+	for _, tt := range []struct {
+		name      string
+		threshold float64
+		want      string
+	}{
+		{"default", 0, "`\nSELECT\n  1\n`"},
+		{"raised", 0.9, "`select 1`"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			file, fset, literals, err := gofile.FindSQLLiterals(src, "test.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := gofile.Options{Indent: 2, Newline: true, DetectThreshold: tt.threshold}
+
+			out, err := gofile.RewriteFile(fset, file, literals, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result := string(out); !strings.Contains(result, tt.want) {
+				t.Errorf("expected %q in output, got:\n%s", tt.want, result)
+			}
+		})
+	}
+}
+
+func TestRewriteFile_Concat(t *testing.T) {
+	src := "package main\n\nvar q = `select id ` +\n\t`from users ` +\n\t`where id = ?`\n"
+
+	tests := []struct {
+		name     string
+		collapse bool
+		want     string
+	}{
+		{
+			name: "redistributed across fragments",
+			want: "var q = `\nSELECT\n  id\n` +\n\t`FROM\n  users\n` +\n\t`WHERE\n  id = ?\n`\n",
+		},
+		{
+			name:     "collapsed into one literal",
+			collapse: true,
+			want:     "var q = `\nSELECT\n  id\nFROM\n  users\nWHERE\n  id = ?\n`\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, fset, literals, err := gofile.FindSQLLiterals([]byte(src), "test.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := gofile.Options{Indent: 2, Newline: true, CollapseConcat: tt.collapse}
+
+			out, err := gofile.RewriteFile(fset, file, literals, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result := string(out); !strings.HasSuffix(result, tt.want) {
+				t.Errorf("got:\n%s\nwant suffix:\n%s", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteFile_ConcatWithSplice(t *testing.T) {
+	src := []byte("package main\n\nvar q = `select * from ` + table + ` where id = ?`\n")
+
+	file, fset, literals, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{Indent: 2, CollapseConcat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "var q = `SELECT\n  *\nFROM\n  ` +\n\ttable + `\nWHERE\n  id = ?`\n"
+	if result := string(out); !strings.HasSuffix(result, want) {
+		t.Errorf("got:\n%s\nwant suffix:\n%s", result, want)
+	}
+}
+
+func TestRewriteFile_CollapseConcatKeepsCommentsInPlace(t *testing.T) {
+	src := "package main\n\nvar d = `select id from users ` + // comment\n\t`where id = ?`\n"
+
+	file, fset, literals, err := gofile.FindSQLLiterals([]byte(src), "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{Indent: 2, CollapseConcat: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "var d = `SELECT\n  id\nFROM\n  users\n` + // comment\n\t`WHERE\n  id = ?`\n"
+	if result := string(out); !strings.HasSuffix(result, want) {
+		t.Errorf("got:\n%s\nwant suffix:\n%s", result, want)
+	}
+}
+
+func TestRewriteFile_ConcatWithoutNaturalBoundaryUnchanged(t *testing.T) {
+	src := "package main\n\nvar q = `select id, na` + `me from users`\n"
+
+	file, fset, literals, err := gofile.FindSQLLiterals([]byte(src), "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{Indent: 2, Newline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != src {
+		t.Errorf("got:\n%s\nwant unchanged:\n%s", out, src)
+	}
+}
+
+func TestRewriteFile_PrintfFormat(t *testing.T) {
+	src := []byte("package main\n\nimport \"fmt\"\n\nvar q = fmt.Sprintf(`select %s from %s where id = %d`, cols, table, id)\n")
+
+	file, fset, literals, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{Indent: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "var q = fmt.Sprintf(`SELECT\n  %s\nFROM\n  %s\nWHERE\n  id = %d`, cols, table, id)\n"
+	if result := string(out); !strings.HasSuffix(result, want) {
+		t.Errorf("got:\n%s\nwant suffix:\n%s", result, want)
+	}
+}
+
+func TestRewriteFile_ConstPrintfTemplateUnchanged(t *testing.T) {
+	// The verbs are only known to be verbs where the literal is passed to
+	// fmt.Sprintf directly; formatting the const would turn amount_%s into
+	// amount_ % s and c%d into c % d.
+	src := "package main\n\nimport \"fmt\"\n\n" +
+		"const q = `SELECT amount_%s, c%d FROM t WHERE id = ?`\n\n" +
+		"var s = fmt.Sprintf(q, \"usd\", 1)\n"
+
+	file, fset, literals, err := gofile.FindSQLLiterals([]byte(src), "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{Indent: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != src {
+		t.Errorf("got:\n%s\nwant unchanged:\n%s", out, src)
+	}
+}
+
+func TestRewriteFile_TextTemplate(t *testing.T) {
+	src := []byte("package main\n\nimport \"text/template\"\n\nvar q = template.New(\"q\").Parse(`select * from {{.Table}} {{if .ID}}where id = ?{{end}}`)\n")
+
+	file, fset, literals, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := gofile.RewriteFile(fset, file, literals, gofile.Options{Indent: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "Parse(`SELECT\n  *\nFROM\n  {{.Table}}\n{{if .ID}}\nWHERE\n  id = ?\n{{end}}`)\n"
+	if result := string(out); !strings.HasSuffix(result, want) {
+		t.Errorf("got:\n%s\nwant suffix:\n%s", result, want)
+	}
+}
+
+func TestRewriteSQLTemplate(t *testing.T) {
+	got := string(gofile.RewriteSQLTemplate([]byte("select id from {{.Table}}\n"), gofile.Options{Indent: 2, Newline: true}))
+	want := "SELECT\n  id\nFROM\n  {{.Table}}\n"
+
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	notSQL := "Dear {{.Name}},\n"
+	if got := string(gofile.RewriteSQLTemplate([]byte(notSQL), gofile.Options{Indent: 2})); got != notSQL {
+		t.Errorf("got:\n%s\nwant unchanged:\n%s", got, notSQL)
+	}
+}
+
+// TestRewriteFile_ANSIQuotes covers the ANSI_QUOTES SQL mode, under which a
+// double-quoted token is an identifier, alone and with the ansi identifier
+// quoting policy, which requires it: SQL it has already formatted reads
+// back unchanged rather than having its identifiers turned into strings.
+func TestRewriteFile_ANSIQuotes(t *testing.T) {
+	src := "package main\n\nvar q = `select \"order\" from t`\n"
+	want := "var q = `SELECT\n  \"order\"\nFROM\n  t`\n"
+
+	for _, opts := range []gofile.Options{
+		{Indent: 2, SQLMode: sqlfmt.SQLModeANSIQuotes},
+		{Indent: 2, SQLMode: sqlfmt.SQLModeANSIQuotes, IdentifierQuoting: gofile.IdentifierQuotingANSI},
+		{
+			Indent:            2,
+			SQLMode:           sqlfmt.SQLModeNoBackslashEscapes + "," + sqlfmt.SQLModeANSIQuotes,
+			IdentifierQuoting: gofile.IdentifierQuotingANSI,
+		},
+	} {
+		file, fset, literals, err := gofile.FindSQLLiterals([]byte(src), "test.go")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := gofile.RewriteFile(fset, file, literals, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result := string(out); !strings.HasSuffix(result, want) {
+			t.Errorf("%+v: got:\n%s\nwant suffix:\n%s", opts, result, want)
+		}
+	}
+}
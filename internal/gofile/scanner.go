@@ -4,11 +4,33 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 type SQLLiteral struct {
 	Node     *ast.BasicLit
 	Original string
+
+	// Printf reports whether the literal is the format string of a
+	// fmt.Sprintf, fmt.Fprintf, or fmt.Errorf call, so its verbs must be
+	// masked while it is formatted (see sqlfmt.Options.PrintfTemplate).
+	Printf bool
+
+	// Template reports whether the literal is the text of a text/template
+	// template.New(...).Parse call, so its actions must be masked while it
+	// is formatted (see sqlfmt.Options.TextTemplate).
+	Template bool
+}
+
+// printfFormatArg maps each fmt function whose format string sanat formats
+// to the index of that argument.
+var printfFormatArg = map[string]int{
+	"Sprintf": 0,
+	"Errorf":  0,
+	"Fprintf": 1,
 }
 
 func isRawStringLit(value string) bool {
@@ -25,21 +47,225 @@ func FindSQLLiterals(src []byte, filename string) (*ast.File, *token.FileSet, []
 
 	var literals []SQLLiteral
 
+	formats := printfFormats(file)
+	templates := templateSources(file)
+
+	inspectSQLCandidates(file, func(lit *ast.BasicLit) {
+		val := lit.Value[1 : len(lit.Value)-1]
+		literals = append(literals, SQLLiteral{
+			Node:     lit,
+			Original: val,
+			Printf:   formats[lit],
+			Template: templates[lit],
+		})
+	}, func(SQLConcat) {})
+
+	return file, fset, literals, nil
+}
+
+// FindInterpretedSQLLiterals returns every interpreted (double-quoted)
+// string literal in file whose value a raw string literal can hold (see
+// fitsRawString), with Original set to the decoded value. Import paths are
+// skipped, and so are the operands of a `+` chain: like the literal
+// operands of a raw string chain (see inspectSQLCandidates), each is only a
+// fragment of the statement the chain builds. RewriteFile only considers
+// these literals with Options.ConvertInterpreted.
+func FindInterpretedSQLLiterals(file *ast.File) []SQLLiteral {
+	var literals []SQLLiteral
+
+	formats := printfFormats(file)
+	templates := templateSources(file)
+
+	var visit func(n ast.Node) bool
+
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ImportSpec:
+			return false
+		case *ast.BinaryExpr:
+			if n.Op != token.ADD {
+				return true
+			}
+
+			for _, op := range addOperands(n) {
+				if _, isLit := op.(*ast.BasicLit); !isLit {
+					ast.Inspect(op, visit)
+				}
+			}
+
+			return false
+		case *ast.BasicLit:
+			if n.Kind != token.STRING || isRawStringLit(n.Value) {
+				return true
+			}
+
+			val, err := strconv.Unquote(n.Value)
+			if err != nil || !fitsRawString(val) {
+				return true
+			}
+
+			literals = append(literals, SQLLiteral{
+				Node:     n,
+				Original: val,
+				Printf:   formats[n],
+				Template: templates[n],
+			})
+		}
+
+		return true
+	}
+
+	ast.Inspect(file, visit)
+
+	return literals
+}
+
+// fitsRawString reports whether s can be written as a raw string literal
+// with the same value, backticks aside (see rewriteLiteral): it has no
+// carriage return (which the Go compiler drops from raw strings), no NUL
+// or byte order mark (which Go source can't contain), and is valid UTF-8.
+func fitsRawString(s string) bool {
+	return utf8.ValidString(s) && !strings.ContainsAny(s, "\r\x00\uFEFF")
+}
+
+// inspectSQLCandidates walks root, calling onLiteral for every raw string
+// literal and onConcat for every `+` chain concatOperands accepts. A chain's
+// own literal operands are reported only through onConcat — formatting a
+// fragment like `WHERE id = ?` on its own would either fail or produce a
+// broken statement — but its other operands are still walked, since a
+// spliced call like quote(`name`) can hold candidates of its own.
+func inspectSQLCandidates(root ast.Node, onLiteral func(*ast.BasicLit), onConcat func(SQLConcat)) {
+	ast.Inspect(root, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			operands, ok := concatOperands(n)
+			if !ok {
+				return true
+			}
+
+			onConcat(SQLConcat{Root: n, Operands: operands})
+
+			for _, op := range operands {
+				if _, isLit := op.(*ast.BasicLit); !isLit {
+					inspectSQLCandidates(op, onLiteral, onConcat)
+				}
+			}
+
+			return false
+		case *ast.BasicLit:
+			if n.Kind == token.STRING && isRawStringLit(n.Value) {
+				onLiteral(n)
+			}
+		}
+
+		return true
+	})
+}
+
+// printfFormats returns the format string argument of every fmt.Sprintf,
+// fmt.Fprintf, and fmt.Errorf call in file, under whatever name file imports
+// fmt as. A dot import is ignored: a bare Sprintf call could be anything.
+func printfFormats(file *ast.File) map[ast.Expr]bool {
+	name := importName(file, "fmt")
+	if name == "" {
+		return nil
+	}
+
+	formats := make(map[ast.Expr]bool)
+
 	ast.Inspect(file, func(n ast.Node) bool {
-		lit, ok := n.(*ast.BasicLit)
-		if !ok || lit.Kind != token.STRING {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
 			return true
 		}
 
-		if !isRawStringLit(lit.Value) {
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
 			return true
 		}
 
-		val := lit.Value[1 : len(lit.Value)-1]
-		literals = append(literals, SQLLiteral{Node: lit, Original: val})
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || pkg.Name != name {
+			return true
+		}
+
+		if idx, ok := printfFormatArg[sel.Sel.Name]; ok && idx < len(call.Args) {
+			formats[ast.Unparen(call.Args[idx])] = true
+		}
 
 		return true
 	})
 
-	return file, fset, literals, nil
+	return formats
+}
+
+// templateSources returns the argument of every Parse call on a template
+// built by text/template's New, e.g. template.New("q").Parse(src) or
+// template.New("q").Funcs(fm).Parse(src), under whatever name file imports
+// text/template as.
+func templateSources(file *ast.File) map[ast.Expr]bool {
+	name := importName(file, "text/template")
+	if name == "" {
+		return nil
+	}
+
+	sources := make(map[ast.Expr]bool)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return true
+		}
+
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Parse" && isTemplateNew(sel.X, name) {
+			sources[ast.Unparen(call.Args[0])] = true
+		}
+
+		return true
+	})
+
+	return sources
+}
+
+// isTemplateNew reports whether expr is a chain of method calls rooted at a
+// pkg.New(...) call.
+func isTemplateNew(expr ast.Expr, pkg string) bool {
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+
+		if id, ok := sel.X.(*ast.Ident); ok && id.Name == pkg && sel.Sel.Name == "New" {
+			return true
+		}
+
+		expr = sel.X
+	}
+}
+
+// importName returns the name file refers to the package at importPath by,
+// or "" if file doesn't import it or imports it with a blank or dot import.
+func importName(file *ast.File, importPath string) string {
+	for _, imp := range file.Imports {
+		if p, err := strconv.Unquote(imp.Path.Value); err != nil || p != importPath {
+			continue
+		}
+
+		switch {
+		case imp.Name == nil:
+			return path.Base(importPath)
+		case imp.Name.Name == "_" || imp.Name.Name == ".":
+			return ""
+		default:
+			return imp.Name.Name
+		}
+	}
+
+	return ""
 }
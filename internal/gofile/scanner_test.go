@@ -53,3 +53,103 @@ func example(db *sql.DB) {
 		}
 	}
 }
+
+func TestFindSQLLiterals_SkipsConcatOperands(t *testing.T) {
+	src := []byte("package main\n\nvar q = `select * from ` + quote(`users`) + ` where id = ?`\n\nvar n = 1 + 2\n")
+
+	file, _, literals, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the raw string inside the spliced call is a standalone literal.
+	if len(literals) != 1 || literals[0].Original != "users" {
+		t.Errorf("got %+v, want only the spliced call's literal", literals)
+	}
+
+	concats := gofile.FindSQLConcats(file)
+	if len(concats) != 1 || len(concats[0].Operands) != 3 {
+		t.Fatalf("got %+v, want one chain of three operands", concats)
+	}
+}
+
+func TestFindSQLConcats_RejectsNonSQLChains(t *testing.T) {
+	src := []byte(`package main
+
+var a = "select * from " + ` + "`users`" + `
+var b = x + y + ` + "`where id = ?`" + `
+var c = ` + "`a`" + ` + 1
+`)
+
+	file, _, _, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if concats := gofile.FindSQLConcats(file); len(concats) != 0 {
+		t.Errorf("got %d chains, want none", len(concats))
+	}
+}
+
+func TestFindSQLLiterals_PrintfFormat(t *testing.T) {
+	src := []byte(`package main
+
+import f "fmt"
+
+var (
+	a = f.Sprintf(` + "`select * from %s`" + `, table)
+	b = f.Fprintf(w, ` + "`select %d`" + `, 1)
+	c = f.Sprintf("%s", ` + "`select * from users`" + `)
+	d = fmt.Sprintf(` + "`select 1`" + `)
+)
+`)
+
+	_, _, literals, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{
+		"select * from %s":    true,
+		"select %d":           true,
+		"select * from users": false, // an argument, not the format string
+		"select 1":            false, // fmt isn't imported under this name
+	}
+
+	for _, lit := range literals {
+		if printf, ok := want[lit.Original]; ok && lit.Printf != printf {
+			t.Errorf("literal %q: Printf = %v, want %v", lit.Original, lit.Printf, printf)
+		}
+	}
+}
+
+func TestFindSQLLiterals_TemplateSource(t *testing.T) {
+	src := []byte(`package main
+
+import "text/template"
+
+var (
+	a = template.Must(template.New("a").Funcs(fm).Parse(` + "`select * from {{.Table}}`" + `))
+	b = template.New("b").Parse(` + "`select 1`" + `).Execute(w, ` + "`select 2`" + `)
+	c = t.Parse(` + "`select 3`" + `)
+)
+`)
+
+	_, _, literals, err := gofile.FindSQLLiterals(src, "test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{
+		"select * from {{.Table}}": true,
+		"select 1":                 true,
+		"select 2":                 false, // not a Parse argument
+		"select 3":                 false, // not a template built by New
+	}
+
+	for _, lit := range literals {
+		if tmpl, ok := want[lit.Original]; ok && lit.Template != tmpl {
+			t.Errorf("literal %q: Template = %v, want %v", lit.Original, lit.Template, tmpl)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package sqlfmt
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+// writeComments writes comments on lines of their own at pad, as the
+// leading comments of whatever is written next.
+func writeComments(b *strings.Builder, comments []sqlast.Comment, pad string) {
+	for _, c := range comments {
+		b.WriteString(pad + c.Text + "\n")
+	}
+}
+
+// appendComments returns line, which may span several lines, with comments
+// after it: each on the same line after a space, unless it started its own
+// line in the input or follows a line comment, which nothing can follow on
+// its line, and then on a line of its own at pad.
+func (f *formatter) appendComments(line string, comments []sqlast.Comment, pad string) string {
+	if len(comments) == 0 {
+		return line
+	}
+
+	afterLineComment := f.lineCommentEnds[lastLine(line)]
+
+	for _, c := range comments {
+		if c.OwnLine || afterLineComment {
+			line += "\n" + pad + c.Text
+		} else {
+			line += " " + c.Text
+		}
+
+		afterLineComment = c.IsLine()
+	}
+
+	if afterLineComment {
+		if f.lineCommentEnds == nil {
+			f.lineCommentEnds = make(map[string]bool)
+		}
+
+		f.lineCommentEnds[lastLine(line)] = true
+	}
+
+	return line
+}
+
+// appendTrailing appends comments to the last line written to b, as
+// appendComments does.
+func (f *formatter) appendTrailing(b *strings.Builder, comments []sqlast.Comment, pad string) {
+	if len(comments) == 0 {
+		return
+	}
+
+	s := strings.TrimSuffix(b.String(), "\n")
+	start := strings.LastIndexByte(s, '\n') + 1
+
+	b.Reset()
+	b.WriteString(s[:start] + f.appendComments(s[start:], comments, pad) + "\n")
+}
+
+// writeClause writes a statement's clause keyword line at p, with the
+// comments recorded around the keyword under key in clauses.
+func (f *formatter) writeClause(b *strings.Builder, clauses sqlast.ClauseComments, key, line, p string) {
+	c := clauses[key]
+
+	writeComments(b, c.Leading, p)
+	b.WriteString(f.appendComments(p+line, c.Trailing, p) + "\n")
+}
+
+func lastLine(s string) string {
+	return s[strings.LastIndexByte(s, '\n')+1:]
+}
+
+// commentTexts returns the text of every comment in s, sorted, and whether
+// s could be lexed at all.
+func commentTexts(s string, mode parser.SQLMode) ([]string, bool) {
+	lx := parser.NewWithMode(s, mode)
+
+	var texts []string
+
+	for {
+		tok, err := lx.Next()
+		if err != nil {
+			return nil, false
+		}
+
+		for _, c := range slices.Concat(tok.Leading, tok.Trailing) {
+			texts = append(texts, c.Text)
+		}
+
+		if tok.Type == parser.EOF {
+			slices.Sort(texts)
+
+			return texts, true
+		}
+	}
+}
+
+// keepsComments reports whether formatted, the formatted output of input,
+// holds exactly input's comments. One may be missing because it was inside a
+// construct the formatter writes on one line, where a comment has nowhere to
+// go (see the formatter spec), or have swallowed the code after it.
+func keepsComments(input, formatted string, mode parser.SQLMode) bool {
+	want, ok := commentTexts(input, mode)
+	if !ok || len(want) == 0 {
+		return ok
+	}
+
+	got, ok := commentTexts(formatted, mode)
+
+	return ok && slices.Equal(got, want)
+}
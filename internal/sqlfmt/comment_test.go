@@ -0,0 +1,271 @@
+package sqlfmt_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
+)
+
+func TestFormatSQL_Comments(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "statement and clause comments",
+			in: "-- active users\nselect id, -- the key\n  name /* display */\n" +
+				"from users -- all of them\nwhere active = 1 -- only active\n  and age > 20 # adults\n" +
+				"order by id -- stable\nlimit 10 -- first page\n-- done",
+			want: join(
+				"-- active users",
+				"SELECT",
+				"  id, -- the key",
+				"  name /* display */",
+				"FROM",
+				"  users -- all of them",
+				"WHERE",
+				"  active = 1 -- only active",
+				"  AND age > 20 # adults",
+				"ORDER BY",
+				"  id -- stable",
+				"LIMIT",
+				"  10 -- first page",
+				"-- done",
+			),
+		},
+		{
+			name: "own-line comment before a list item",
+			in:   "select a,\n  -- about b\n  b from t",
+			want: join(
+				"SELECT",
+				"  a,",
+				"  -- about b",
+				"  b",
+				"FROM",
+				"  t",
+			),
+		},
+		{
+			name: "comments around clause keywords",
+			in:   "select a\n-- the source\nfrom /* main */ t",
+			want: join(
+				"SELECT",
+				"  a",
+				"-- the source",
+				"FROM /* main */",
+				"  t",
+			),
+		},
+		{
+			name: "comment before a join keyword stays with its left operand",
+			in:   "select a from t1 -- left\njoin t2 on t1.id = t2.id -- on\n, t3",
+			want: join(
+				"SELECT",
+				"  a",
+				"FROM",
+				"  t1 -- left",
+				"  JOIN",
+				"  t2",
+				"    ON t1.id = t2.id, -- on",
+				"  t3",
+			),
+		},
+		{
+			name: "comment after a join's right operand stays with it",
+			in:   "select a from t join u -- join u\non t.id = u.id",
+			want: join(
+				"SELECT",
+				"  a",
+				"FROM",
+				"  t",
+				"  JOIN",
+				"  u -- join u",
+				"    ON t.id = u.id",
+			),
+		},
+		{
+			name: "comment after an operator ending its line stays on that line",
+			in:   "select a from t where b = 1 and -- and\n c = 2",
+			want: join(
+				"SELECT",
+				"  a",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  b = 1 -- and",
+				"  AND c = 2",
+			),
+		},
+		{
+			name: "case branches",
+			in:   "select case x -- by x\n -- one\n when 1 then 2 -- two\n else 3 -- three\n end as y from t",
+			want: join(
+				"SELECT",
+				"  CASE x -- by x",
+				"    -- one",
+				"    WHEN 1 THEN 2 -- two",
+				"    ELSE 3 -- three",
+				"  END AS y",
+				"FROM",
+				"  t",
+			),
+		},
+		{
+			name: "insert columns",
+			in:   "insert into t (a, -- first\n b) values (1, 2)",
+			want: join(
+				"INSERT INTO",
+				"  t",
+				"(",
+				"  a, -- first",
+				"  b",
+				")",
+				"VALUES",
+				"  (1, 2)",
+			),
+		},
+		{
+			name: "create table elements",
+			in:   "create table t (\n -- the key\n id INT, -- pk\n name TEXT, /* name */\n primary key (id) -- key\n) engine=InnoDB",
+			want: join(
+				"CREATE TABLE t (",
+				"  -- the key",
+				"  id INT, -- pk",
+				"  name TEXT, /* name */",
+				"  PRIMARY KEY (id) -- key",
+				") ENGINE=InnoDB",
+			),
+		},
+		{
+			name: "update assignments",
+			in:   "update t set -- changes\n a = 1, -- first\n b = 2 where id = ? -- one row",
+			want: join(
+				"UPDATE",
+				"  t",
+				"SET -- changes",
+				"  a = 1, -- first",
+				"  b = 2",
+				"WHERE",
+				"  id = ? -- one row",
+			),
+		},
+		{
+			name: "set operator",
+			in:   "select a from t -- first\nunion all -- both\nselect b from u",
+			want: join(
+				"SELECT",
+				"  a",
+				"FROM",
+				"  t -- first",
+				"UNION ALL -- both",
+				"SELECT",
+				"  b",
+				"FROM",
+				"  u",
+			),
+		},
+		{
+			name: "comment after the semicolon",
+			in:   "select a from t; -- done",
+			want: join(
+				"SELECT",
+				"  a",
+				"FROM",
+				"  t -- done",
+			),
+		},
+		{
+			name: "comment inside a construct without comments moves to its carrier's end",
+			in:   "insert into t (a) values (1), -- one\n(2) on duplicate key update a = 1",
+			want: join(
+				"INSERT INTO",
+				"  t",
+				"(",
+				"  a",
+				")",
+				"VALUES",
+				"  (1),",
+				"  (2)",
+				"ON DUPLICATE KEY UPDATE",
+				"  a = 1 -- one",
+			),
+		},
+		{
+			name: "nothing is written after a line comment",
+			in:   "select a from t where b = 1 -- one\n; /* after */",
+			want: join(
+				"SELECT",
+				"  a",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  b = 1 -- one",
+				"/* after */",
+			),
+		},
+		{
+			name: "comments inside a subquery",
+			in:   "select a from t where a in (select b -- inner\nfrom u)",
+			want: join(
+				"SELECT",
+				"  a",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  a IN (",
+				"    SELECT",
+				"      b -- inner",
+				"    FROM",
+				"      u",
+				"  )",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQL(tt.in, 2)
+			if !ok {
+				t.Fatalf("FormatSQL(%q) ok = false, want true", tt.in)
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
+
+func TestFormatSQL_Comments_leadingComma(t *testing.T) {
+	in := "select a, -- first\n  -- about b\n  b from t"
+
+	got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, CommaStyle: sqlfmt.CommaStyleLeading})
+	if !ok {
+		t.Fatalf("FormatSQLWithOptions(%q) ok = false, want true", in)
+	}
+
+	assertSQL(t, got, join(
+		"SELECT",
+		"  a -- first",
+		"  -- about b",
+		", b",
+		"FROM",
+		"  t",
+	))
+}
+
+// TestFormatSQL_Comments_inlineFallback documents that a comment inside a
+// construct the formatter writes on one line, like a JOIN's ON condition,
+// has nowhere to go: FormatSQL falls back to returning the input unchanged
+// rather than drop it.
+func TestFormatSQL_Comments_inlineFallback(t *testing.T) {
+	in := "select a from t join u on t.id = u.id -- match\n and u.ok = 1"
+
+	got, ok := sqlfmt.FormatSQL(in, 2)
+	if ok {
+		t.Fatalf("FormatSQL(%q) ok = true, want false", in)
+	}
+
+	if got != in {
+		t.Errorf("FormatSQL(%q) = %q, want input unchanged", in, got)
+	}
+}
@@ -0,0 +1,389 @@
+package sqlfmt
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+)
+
+// spliceSentinelRe matches the identifier JoinFragments substitutes for a
+// splice fragment. The trailing \b keeps _sqla_splice_1 from matching the
+// front of _sqla_splice_10.
+var spliceSentinelRe = regexp.MustCompile(`_sqla_splice_(\d+)\b`)
+
+// Fragment is one operand of a Go string concatenation that builds a single
+// SQL statement, e.g. `SELECT * FROM ` + table + ` WHERE id = ?`.
+type Fragment struct {
+	// Text is the fragment's SQL text. It is ignored for a splice.
+	Text string
+
+	// Splice marks an operand that isn't a string literal (a constant or
+	// variable holding part of the statement). Its value is unknown to the
+	// formatter, so it is formatted as an opaque identifier.
+	Splice bool
+}
+
+// JoinFragments returns the SQL statement fragments concatenate to, with
+// every splice replaced by a sentinel identifier. The sentinel is padded
+// with spaces so it can't merge with a neighboring token, e.g.
+// `FROM` + table lexing as the single identifier FROM_sqla_splice_1. That
+// padding changes the statement when the splice is part of a name or of
+// quoted or commented text, so FormatSQLFragments refuses such a splice.
+func JoinFragments(fragments []Fragment) string {
+	var b strings.Builder
+
+	for i, frag := range fragments {
+		if frag.Splice {
+			b.WriteString(" " + spliceSentinel(i) + " ")
+
+			continue
+		}
+
+		b.WriteString(frag.Text)
+	}
+
+	return b.String()
+}
+
+func spliceSentinel(i int) string {
+	return fmt.Sprintf("_sqla_splice_%d", i)
+}
+
+// FormatSQLFragments formats the statement JoinFragments(fragments) builds
+// and redistributes the formatted text across the original fragments, so a
+// Go `+` chain keeps its shape: each splice stays where its sentinel ends up,
+// and each boundary between two adjacent text fragments moves to the start
+// of the formatted line holding the token that began the second fragment.
+//
+// A boundary only has a natural place in the output when that token starts
+// its own line — typically a clause keyword (FROM, WHERE, ...) — and isn't
+// split across the two fragments. ok is false when any boundary has no such
+// place, when two splices are adjacent (their sentinels would have to share
+// one position), or when the statement fails to format; callers that want
+// to format such a chain anyway can merge adjacent text fragments first.
+// It is also false when a splice doesn't stand alone (see
+// splicesStandAlone), or when formatting changes the text next to one (see
+// splicesKeepNeighbors). The last fragment keeps the formatted output's
+// trailing newline.
+func FormatSQLFragments(fragments []Fragment, opts Options) ([]Fragment, bool) {
+	mode, ok := parserSQLMode(opts.SQLMode)
+	if !ok || !hasSeparatedSplices(fragments) || !splicesStandAlone(fragments, mode) {
+		return fragments, false
+	}
+
+	joined := JoinFragments(fragments)
+
+	formatted, ok := FormatSQLWithOptions(joined, opts)
+	if !ok {
+		return fragments, false
+	}
+
+	spans, ok := fragmentSpans(fragments, joined, formatted, mode)
+	if !ok {
+		return fragments, false
+	}
+
+	result := make([]Fragment, len(fragments))
+
+	for i, frag := range fragments {
+		if frag.Splice {
+			result[i] = frag
+
+			continue
+		}
+
+		result[i] = Fragment{Text: formatted[spans[i].start:spans[i].end]}
+	}
+
+	if !splicesKeepNeighbors(fragments, result) {
+		return fragments, false
+	}
+
+	return result, true
+}
+
+// splicesStandAlone reports whether every splice of fragments sits outside
+// quotes and comments (see quoteState) and isn't glued to a name character
+// (see gluedToName). Anywhere else, the padding around its sentinel would
+// change the statement: `FROM users_` + suffix would name users_ instead of
+// a table ending in suffix, and `name = '` + name + `'` would add spaces to
+// the string.
+func splicesStandAlone(fragments []Fragment, mode parser.SQLMode) bool {
+	q := quoteState{mode: mode}
+
+	for i, frag := range fragments {
+		if !frag.Splice {
+			for j := 0; j < len(frag.Text); {
+				j, _ = q.advance(frag.Text, j)
+			}
+
+			continue
+		}
+
+		if q.quote != 0 || q.commentEnd != "" {
+			return false
+		}
+
+		if gluedToName(fragmentText(fragments, i-1), fragmentText(fragments, i+1)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splicesKeepNeighbors reports whether formatted, the fragments
+// FormatSQLFragments made of fragments, keeps the text next to every splice
+// byte for byte up to whitespace: the byte on each side of a splice must be
+// whitespace, or the nearest non-whitespace byte on that side before
+// formatting. Formatting may move a splice to a line of its own, but not,
+// say, quote its sentinel as an identifier.
+func splicesKeepNeighbors(fragments, formatted []Fragment) bool {
+	for i, frag := range fragments {
+		if !frag.Splice {
+			continue
+		}
+
+		before := strings.TrimRight(fragmentText(fragments, i-1), spaceBytes)
+		after := strings.TrimLeft(fragmentText(fragments, i+1), spaceBytes)
+		formattedBefore := fragmentText(formatted, i-1)
+		formattedAfter := fragmentText(formatted, i+1)
+
+		if !sameNeighbor(before, formattedBefore, len(before)-1, len(formattedBefore)-1) ||
+			!sameNeighbor(after, formattedAfter, 0, 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+const spaceBytes = " \t\r\n"
+
+// sameNeighbor reports whether formatted[j], the byte next to a splice after
+// formatting, is whitespace or original[i], the one next to it before. A
+// side with no text before formatting must have none after it either.
+func sameNeighbor(original, formatted string, i, j int) bool {
+	if original == "" || formatted == "" {
+		return strings.TrimSpace(formatted) == ""
+	}
+
+	return strings.IndexByte(spaceBytes, formatted[j]) >= 0 || formatted[j] == original[i]
+}
+
+// fragmentText returns the text of fragments[i], or "" when i is out of
+// range or fragments[i] is a splice.
+func fragmentText(fragments []Fragment, i int) string {
+	if i < 0 || i >= len(fragments) || fragments[i].Splice {
+		return ""
+	}
+
+	return fragments[i].Text
+}
+
+// hasSeparatedSplices reports whether fragments holds at least one text
+// fragment and no two adjacent splices.
+func hasSeparatedSplices(fragments []Fragment) bool {
+	hasText := false
+
+	for i, frag := range fragments {
+		if !frag.Splice {
+			hasText = true
+
+			continue
+		}
+
+		if i > 0 && fragments[i-1].Splice {
+			return false
+		}
+	}
+
+	return hasText
+}
+
+// span is a [start, end) byte range of the formatted output.
+type span struct {
+	start, end int
+}
+
+// fragmentSpans maps every fragment to its span of formatted: a splice to
+// its sentinel, and a text fragment to everything between its neighbors'
+// spans (or the start/end of formatted). It fails when the sentinels aren't
+// each present exactly once and in order, when a text boundary has no
+// natural place (see clauseBoundary), or when the spans would overlap or
+// drop non-whitespace text before a leading or after a trailing splice.
+func fragmentSpans(fragments []Fragment, joined, formatted string, mode parser.SQLMode) ([]span, bool) {
+	spans := make([]span, len(fragments))
+
+	if !spliceSpans(fragments, formatted, spans) {
+		return nil, false
+	}
+
+	joinedOffset := 0
+	prevEnd := 0
+
+	for i, frag := range fragments {
+		if frag.Splice {
+			joinedOffset += len(" " + spliceSentinel(i) + " ")
+			prevEnd = spans[i].end
+
+			continue
+		}
+
+		joinedOffset += len(frag.Text)
+		spans[i].start = prevEnd
+
+		switch {
+		case i == len(fragments)-1:
+			spans[i].end = len(formatted)
+		case fragments[i+1].Splice:
+			spans[i].end = spans[i+1].start
+		default:
+			cut, ok := clauseBoundary(joined, joinedOffset, formatted, mode)
+			if !ok {
+				return nil, false
+			}
+
+			spans[i].end = cut
+		}
+
+		if spans[i].end < spans[i].start {
+			return nil, false
+		}
+
+		prevEnd = spans[i].end
+	}
+
+	first, last := spans[0], spans[len(spans)-1]
+
+	if strings.TrimSpace(formatted[:first.start]) != "" || strings.TrimSpace(formatted[last.end:]) != "" {
+		return nil, false
+	}
+
+	return spans, true
+}
+
+// spliceSpans fills in spans for every splice fragment from the sentinels
+// found in formatted, reporting whether each splice's sentinel appears
+// exactly once and all of them appear in fragment order.
+func spliceSpans(fragments []Fragment, formatted string, spans []span) bool {
+	matches := spliceSentinelRe.FindAllStringSubmatchIndex(formatted, -1)
+	next := 0
+
+	for i, frag := range fragments {
+		if !frag.Splice {
+			continue
+		}
+
+		if next == len(matches) {
+			return false
+		}
+
+		m := matches[next]
+		if idx, err := strconv.Atoi(formatted[m[2]:m[3]]); err != nil || idx != i {
+			return false
+		}
+
+		spans[i] = span{start: m[0], end: m[1]}
+		next++
+	}
+
+	return next == len(matches)
+}
+
+// clauseBoundary finds where the fragment boundary at byte offset boundary
+// of joined belongs in formatted. The boundary must fall between two tokens
+// (not inside one), and the token right after it — the nth of its kind in
+// joined — must be the first thing on its line as the nth token of the same
+// kind in formatted. The returned offset is the start of that line, so the
+// preceding fragment keeps its trailing newline and the next one starts
+// with the line's indentation.
+func clauseBoundary(joined string, boundary int, formatted string, mode parser.SQLMode) (int, bool) {
+	before, ok := lexAll(joined[:boundary], mode)
+	if !ok {
+		return 0, false
+	}
+
+	all, ok := lexAll(joined, mode)
+	if !ok || len(all) <= len(before) {
+		return 0, false
+	}
+
+	for i, tok := range before {
+		if !sameToken(tok, all[i]) {
+			return 0, false
+		}
+	}
+
+	next := all[len(before)]
+	if next.Pos.Offset < boundary {
+		return 0, false
+	}
+
+	nth := countTokens(before, next)
+
+	out, ok := lexAll(formatted, mode)
+	if !ok || countTokens(out, next) != countTokens(all, next) {
+		return 0, false
+	}
+
+	for _, tok := range out {
+		if !sameToken(tok, next) {
+			continue
+		}
+
+		if nth > 0 {
+			nth--
+
+			continue
+		}
+
+		lineStart := strings.LastIndexByte(formatted[:tok.Pos.Offset], '\n') + 1
+		if strings.TrimSpace(formatted[lineStart:tok.Pos.Offset]) != "" {
+			return 0, false
+		}
+
+		return lineStart, true
+	}
+
+	return 0, false
+}
+
+// lexAll returns every token of s before EOF, or ok=false if s fails to lex.
+func lexAll(s string, mode parser.SQLMode) ([]parser.Token, bool) {
+	lx := parser.NewWithMode(s, mode)
+
+	var tokens []parser.Token
+
+	for {
+		tok, err := lx.Next()
+		if err != nil {
+			return nil, false
+		}
+
+		if tok.Type == parser.EOF {
+			return tokens, true
+		}
+
+		tokens = append(tokens, tok)
+	}
+}
+
+func sameToken(a, b parser.Token) bool {
+	return a.Type == b.Type && strings.EqualFold(a.Literal, b.Literal)
+}
+
+func countTokens(tokens []parser.Token, want parser.Token) int {
+	n := 0
+
+	for _, tok := range tokens {
+		if sameToken(tok, want) {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,134 @@
+package sqlfmt_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
+)
+
+func text(s string) sqlfmt.Fragment { return sqlfmt.Fragment{Text: s} }
+
+var splice = sqlfmt.Fragment{Splice: true}
+
+func TestJoinFragments(t *testing.T) {
+	got := sqlfmt.JoinFragments([]sqlfmt.Fragment{text("SELECT * FROM"), splice, text("WHERE id = ?")})
+	want := "SELECT * FROM _sqla_splice_1 WHERE id = ?"
+
+	if got != want {
+		t.Errorf("JoinFragments() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatSQLFragments(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []sqlfmt.Fragment
+		want []sqlfmt.Fragment
+		ok   bool
+	}{
+		{
+			name: "split at clause keywords",
+			in:   []sqlfmt.Fragment{text("select id, name "), text("from users "), text("where id = ?")},
+			want: []sqlfmt.Fragment{
+				text(join("SELECT", "  id,", "  name") + "\n"),
+				text(join("FROM", "  users") + "\n"),
+				text(join("WHERE", "  id = ?") + "\n"),
+			},
+			ok: true,
+		},
+		{
+			name: "split inside a subquery keeps indentation with the next fragment",
+			in:   []sqlfmt.Fragment{text("select a from t where x in (select b "), text("from u)")},
+			want: []sqlfmt.Fragment{
+				text(join("SELECT", "  a", "FROM", "  t", "WHERE", "  x IN (", "    SELECT", "      b") + "\n"),
+				text(join("    FROM", "      u", "  )") + "\n"),
+			},
+			ok: true,
+		},
+		{
+			name: "splice as table name",
+			in:   []sqlfmt.Fragment{text("select * from "), splice, text(" where id = ?")},
+			want: []sqlfmt.Fragment{
+				text(join("SELECT", "  *", "FROM") + "\n  "),
+				splice,
+				text("\n" + join("WHERE", "  id = ?") + "\n"),
+			},
+			ok: true,
+		},
+		{
+			name: "trailing splice",
+			in:   []sqlfmt.Fragment{text("select id from users where id = "), splice},
+			want: []sqlfmt.Fragment{
+				text(join("SELECT", "  id", "FROM", "  users", "WHERE", "  id = ")),
+				splice,
+			},
+			ok: true,
+		},
+		{
+			name: "boundary inside a token",
+			in:   []sqlfmt.Fragment{text("select id, na"), text("me from users")},
+			ok:   false,
+		},
+		{
+			name: "boundary mid-line",
+			in:   []sqlfmt.Fragment{text("select a + "), text("b from t")},
+			ok:   false,
+		},
+		{
+			name: "adjacent splices",
+			in:   []sqlfmt.Fragment{text("select * from "), splice, splice},
+			ok:   false,
+		},
+		{
+			name: "splice glued to a name",
+			in:   []sqlfmt.Fragment{text("select id from users_"), splice},
+			ok:   false,
+		},
+		{
+			name: "splice glued to a qualified name",
+			in:   []sqlfmt.Fragment{text("select id from "), splice, text(".users")},
+			ok:   false,
+		},
+		{
+			name: "splice inside a string",
+			in:   []sqlfmt.Fragment{text("select id from users where name = '"), splice, text("'")},
+			ok:   false,
+		},
+		{
+			name: "splice inside a comment",
+			in:   []sqlfmt.Fragment{text("select id from users /* "), splice, text(" */ where id = ?")},
+			ok:   false,
+		},
+		{
+			name: "only splices",
+			in:   []sqlfmt.Fragment{splice},
+			ok:   false,
+		},
+		{
+			name: "not a statement",
+			in:   []sqlfmt.Fragment{text("select * from "), text("where")},
+			ok:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLFragments(tt.in, sqlfmt.Options{Indent: 2})
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v (got %+v)", ok, tt.ok, got)
+			}
+
+			if !ok {
+				if !reflect.DeepEqual(got, tt.in) {
+					t.Errorf("on failure got %+v, want input unchanged", got)
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
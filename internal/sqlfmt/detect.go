@@ -1,27 +1,316 @@
 package sqlfmt
 
 import (
-	"regexp"
+	"errors"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
 )
 
-var (
-	sqlPrefixRe = regexp.MustCompile(`(?i)^\s*(SELECT|INSERT|UPDATE|DELETE|` +
-		`CREATE|ALTER|DROP|TRUNCATE|` +
-		`START|BEGIN|COMMIT|ROLLBACK|SAVEPOINT|RELEASE|SET|` +
-		`SHOW|DESCRIBE|EXPLAIN|USE)\b`)
-	fmtVerbRe = regexp.MustCompile(`%[+\-# 0]*[*]?[0-9]*[.*]?[0-9]*[vTtbcdoOqxXUeEfFgGsp]`)
+// DefaultDetectThreshold is the minimum DetectionScore MightBeSQL requires.
+// A recognized leading statement keyword on its own scores exactly
+// leadingKeywordWeight, which clears this threshold, so the default keeps
+// every string the old keyword-prefix check accepted unless some of its
+// tokens fail to lex.
+const DefaultDetectThreshold = 0.5
+
+const (
+	// leadingKeywordWeight is the share of the score earned by starting
+	// with a statement keyword, after any comments and opening parentheses.
+	leadingKeywordWeight = 0.6
+
+	// clauseStructureWeight is the share earned by the rest of the token
+	// stream looking like that statement kind (see statementClauses).
+	clauseStructureWeight = 0.4
+
+	// maxBareStatementTokens bounds how many tokens may follow the leading
+	// keyword of a statement kind with no characteristic clause keyword
+	// (BEGIN, USE, DESCRIBE, ...) for it to still earn the clause-structure
+	// share: these statements are short, while prose that happens to start
+	// with the same word ("Begin by reading ...") usually isn't.
+	maxBareStatementTokens = 3
+
+	// lexFailureWeight is how many lexed tokens a single lex error counts
+	// as when scaling the score. An error usually swallows a bit of context
+	// (the rest of a word after an unbalanced quote, a run of stray
+	// punctuation), so it's weighed above one clean token.
+	lexFailureWeight = 2
 )
 
+// statementClauses maps each statement keyword MightBeSQL accepts as a
+// leading token to the keywords whose presence later in the stream is
+// evidence of that statement's clause structure (SELECT ... FROM,
+// UPDATE ... SET, CREATE TABLE, ...). A nil entry marks a statement kind
+// with no such keyword; it earns the clause-structure share by being short
+// instead (see maxBareStatementTokens).
+//
+// Every key has a corresponding statement parser in
+// internal/sqlfmt/parser: detecting a statement kind the formatter cannot
+// format would only waste a parse attempt. TABLE and DO are in, but only
+// count with their clause structure (see clauseRequired).
+//
+// The word TABLE opens far more prose strings ("Table users") than
+// statements, so a TABLE statement is only detected with an ORDER BY or
+// LIMIT clause.
+var statementClauses = map[parser.TokenType][]parser.TokenType{
+	parser.SELECT: {
+		parser.FROM, parser.WHERE, parser.GROUP, parser.HAVING, parser.ORDER, parser.LIMIT,
+		parser.UNION, parser.INTERSECT, parser.EXCEPT,
+	},
+	parser.WITH:      {parser.AS},
+	parser.VALUES:    {parser.ROW},
+	parser.INSERT:    {parser.INTO, parser.VALUES, parser.SET, parser.SELECT},
+	parser.REPLACE:   {parser.INTO, parser.VALUES, parser.SET, parser.SELECT},
+	parser.UPDATE:    {parser.SET},
+	parser.DELETE:    {parser.FROM},
+	parser.CREATE:    {parser.TABLE, parser.INDEX, parser.VIEW},
+	parser.ALTER:     {parser.TABLE, parser.VIEW},
+	parser.DROP:      {parser.TABLE, parser.INDEX, parser.VIEW, parser.PREPARE},
+	parser.TRUNCATE:  {parser.TABLE},
+	parser.START:     {parser.TRANSACTION},
+	parser.BEGIN:     nil,
+	parser.COMMIT:    nil,
+	parser.ROLLBACK:  nil,
+	parser.SAVEPOINT: nil,
+	parser.RELEASE:   {parser.SAVEPOINT},
+	parser.SET:       {parser.EQ, parser.NAMES},
+	parser.SHOW: {
+		parser.TABLES, parser.CREATE, parser.COLUMNS, parser.INDEX,
+		parser.DATABASES, parser.VARIABLES, parser.STATUS,
+	},
+	parser.DESCRIBE:   nil,
+	parser.EXPLAIN:    {parser.SELECT, parser.WITH, parser.FORMAT},
+	parser.USE:        nil,
+	parser.CALL:       {parser.LPAREN},
+	parser.PREPARE:    {parser.FROM},
+	parser.EXECUTE:    nil,
+	parser.DEALLOCATE: {parser.PREPARE},
+	parser.DO:         {parser.LPAREN},
+	parser.TABLE:      {parser.ORDER, parser.LIMIT},
+}
+
+// clauseRequired marks the keywords in statementClauses that also open a lot
+// of prose ("Do not retry", "Table users"): a string starting with one
+// scores 0 unless the rest has the statement's clause structure, rather than
+// leadingKeywordWeight.
+var clauseRequired = map[parser.TokenType]bool{
+	parser.DO:    true,
+	parser.TABLE: true,
+}
+
+// DetectOptions controls how MightBeSQLWithOptions scores a string.
+type DetectOptions struct {
+	// Threshold is the minimum DetectionScore, in (0, 1], a string must
+	// reach to be treated as SQL. Zero selects DefaultDetectThreshold.
+	Threshold float64
+
+	// SQLMode selects the sql_mode the string is lexed under, with the same
+	// values (and the same default) as Options.SQLMode. It matters for
+	// strings like 'C:\' that only lex as a complete string literal under
+	// SQLModeNoBackslashEscapes.
+	SQLMode string
+
+	// PrintfTemplate marks s as the format string of a fmt.Sprintf-style
+	// call, as for Options.PrintfTemplate. Unless it is set, a fmt verb
+	// outside quotes disqualifies s (see hasBareVerb).
+	PrintfTemplate bool
+
+	// TextTemplate marks s as a text/template template, as for
+	// Options.TextTemplate: its actions are masked before it is scored, so
+	// their contents, which are template syntax rather than SQL, are not
+	// scored as SQL tokens.
+	TextTemplate bool
+}
+
+// MightBeSQL reports whether s looks enough like SQL to be worth handing to
+// the formatter, using DefaultDetectThreshold and SQLModeDefault. See
+// MightBeSQLWithOptions for full control.
 func MightBeSQL(s string) bool {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return false
+	return MightBeSQLWithOptions(s, DetectOptions{})
+}
+
+// MightBeSQLWithOptions reports whether DetectionScore(s, opts) reaches
+// opts.Threshold.
+func MightBeSQLWithOptions(s string, opts DetectOptions) bool {
+	threshold := opts.Threshold
+	if threshold == 0 {
+		threshold = DefaultDetectThreshold
+	}
+
+	return DetectionScore(s, opts) >= threshold
+}
+
+// DetectionScore rates how confident sanat is that s is a SQL statement, from
+// 0 (not SQL) to 1. s is run through the real parser.Lexer rather than
+// matched against a keyword regex, so comments, string literals, and
+// quoted identifiers are recognized exactly as the parser will see them:
+//
+//   - s scores 0 unless its first token, after any comments and opening
+//     parentheses, is a statement keyword in statementClauses.
+//   - That keyword earns leadingKeywordWeight, and the rest of the stream
+//     matching the statement's clause structure earns clauseStructureWeight.
+//   - The sum is scaled down by the share of s's tokens that fail to lex
+//     (each failure weighted by lexFailureWeight), so prose that merely
+//     starts with a keyword ("Update: don't ...") loses confidence to its
+//     unbalanced quotes and stray punctuation.
+//
+// A fmt verb outside quotes and comments scores 0 unless opts.PrintfTemplate
+// is set (see hasBareVerb); one inside a string literal, as in a LIKE
+// pattern such as '%s%', doesn't count. A known fmt.Sprintf template scores
+// like the statement it builds, since "%s" lexes as an ordinary operator
+// followed by an identifier (see Options.PrintfTemplate).
+func DetectionScore(s string, opts DetectOptions) float64 {
+	mode, ok := parserSQLMode(opts.SQLMode)
+	if !ok {
+		return 0
+	}
+
+	if opts.TextTemplate {
+		if tmpl, ok := maskTemplate(s, mode); ok {
+			s = tmpl.masked
+		}
+	}
+
+	if !opts.PrintfTemplate && hasBareVerb(s, mode) {
+		return 0
+	}
+
+	tokens, failed := lexForDetection(s, mode)
+
+	score := statementScore(tokens)
+	if score == 0 {
+		return 0
+	}
+
+	return score * float64(len(tokens)) / float64(len(tokens)+lexFailureWeight*failed)
+}
+
+// statementScore scores tokens, before any scaling for lex failures: 0
+// unless the first token after any opening parentheses is a statement
+// keyword in statementClauses, leadingKeywordWeight for that keyword, and
+// clauseStructureWeight more if the rest has its clause structure. A
+// keyword in clauseRequired scores 0 without it.
+func statementScore(tokens []parser.TokenType) float64 {
+	start := 0
+	for start < len(tokens) && tokens[start] == parser.LPAREN {
+		start++
 	}
 
-	if fmtVerbRe.MatchString(s) {
-		return false
+	if start == len(tokens) {
+		return 0
 	}
 
-	return sqlPrefixRe.MatchString(s)
+	clauses, ok := statementClauses[tokens[start]]
+	if !ok {
+		return 0
+	}
+
+	if !hasClauseStructure(tokens[start+1:], clauses) {
+		if clauseRequired[tokens[start]] {
+			return 0
+		}
+
+		return leadingKeywordWeight
+	}
+
+	return leadingKeywordWeight + clauseStructureWeight
+}
+
+// fmtVerbLetters are the verb letters fmt defines. hasBareVerb only counts
+// a % followed by one of them, so a modulo such as "a%2" isn't mistaken
+// for a verb. One such as "a%b" is, since nothing tells the two apart.
+const fmtVerbLetters = "vTtbcdoOqxXUeEfFgGsp"
+
+// hasBareVerb reports whether s has a fmt verb outside quotes and comments.
+// A string like that is most likely the format string of a fmt call sanat
+// can't see (a const passed to fmt.Sprintf elsewhere), and formatting it
+// would change what the call produces: "amount_%s" would become
+// "amount_ % s". A verb with the space flag ("a % d") doesn't count, since
+// that is how a modulo is usually written and formatting keeps it as is;
+// neither does %%.
+func hasBareVerb(s string, mode parser.SQLMode) bool {
+	for _, i := range unquotedPercents(s, mode) {
+		verb := verbRe.FindString(s[i:])
+		if verb == "" || strings.Contains(verb, " ") {
+			continue
+		}
+
+		if strings.IndexByte(fmtVerbLetters, verb[len(verb)-1]) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasClauseStructure reports whether rest, the tokens following a leading
+// statement keyword, look like that statement's clause structure: for a
+// statement kind with characteristic clause keywords, at least one of them
+// appears; for one without (a nil clauses), rest is short enough to be the
+// whole statement, ignoring a trailing semicolon.
+func hasClauseStructure(rest, clauses []parser.TokenType) bool {
+	if clauses == nil {
+		if len(rest) > 0 && rest[len(rest)-1] == parser.SEMICOLON {
+			rest = rest[:len(rest)-1]
+		}
+
+		return len(rest) <= maxBareStatementTokens
+	}
+
+	for _, tt := range rest {
+		for _, clause := range clauses {
+			if tt == clause {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// lexForDetection lexes s under mode, returning the type of every token that
+// lexed (excluding the final EOF) and how many lex errors were encountered.
+// A lex error doesn't stop the scan: lexing restarts one rune past the
+// error's position (every *parser.LexError reports the start of the
+// malformed token), so a single stray quote or character costs one failure
+// instead of discarding the rest of the stream.
+func lexForDetection(s string, mode parser.SQLMode) ([]parser.TokenType, int) {
+	var (
+		tokens []parser.TokenType
+		failed int
+	)
+
+	base := 0
+	lx := parser.NewWithMode(s, mode)
+
+	for {
+		tok, err := lx.Next()
+		if err != nil {
+			var lexErr *parser.LexError
+			if !errors.As(err, &lexErr) {
+				return tokens, failed + 1
+			}
+
+			failed++
+
+			base += lexErr.Pos.Offset
+			_, width := utf8.DecodeRuneInString(s[base:])
+			base += max(width, 1)
+
+			if base >= len(s) {
+				return tokens, failed
+			}
+
+			lx = parser.NewWithMode(s[base:], mode)
+
+			continue
+		}
+
+		if tok.Type == parser.EOF {
+			return tokens, failed
+		}
+
+		tokens = append(tokens, tok.Type)
+	}
 }
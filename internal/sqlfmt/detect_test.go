@@ -1,6 +1,7 @@
 package sqlfmt_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
@@ -25,12 +26,32 @@ func TestMightBeSQL(t *testing.T) {
 		{"fmt sprintf %s", "SELECT %s FROM %s", false},
 		{"fmt sprintf %d", "SELECT * FROM users LIMIT %d", false},
 		{"fmt sprintf %v", "SELECT %v FROM users", false},
+		{"fmt verb inside identifier", "SELECT amount_%s FROM t WHERE id = ?", false},
+		{"like pattern with percent verb", "SELECT * FROM users WHERE name LIKE '%s%'", true},
+		{"verb in comment", "SELECT id FROM users -- 100%sure", true},
+		{"modulo", "SELECT a % 2, b%3, c % d FROM t", true},
+		{"double percent", "SELECT a %% b FROM t", true},
+		{"with cte", "WITH c AS (SELECT 1) SELECT * FROM c", true},
+		{"replace", "REPLACE INTO users (id) VALUES (?)", true},
+		{"values statement", "VALUES ROW(1, 'a'), ROW(2, 'b')", true},
+		{"table prose", "Table of contents", false},
+		{"parenthesized select", "(SELECT 1) UNION (SELECT 2)", true},
+		{"leading line comment", "-- fetch users\nSELECT id FROM users", true},
+		{"leading hash comment", "# fetch users\nSELECT id FROM users", true},
+		{"leading block comment", "/* fetch users */ SELECT id FROM users", true},
+		{"only a comment", "-- SELECT id FROM users", false},
+		{"only parentheses", "(()", false},
+		{"keyword prose with unbalanced quote", "Update: don't forget", false},
+		{"keyword prose with stray characters", "Set [HOME] and restart", false},
 		{"select in sentence", "SELECT is a SQL keyword", true},
 		{"log message", "failed to execute query", false},
 		{"contains select not prefix", "the SELECT statement", false},
 		{"create table", "CREATE TABLE users (id INT)", true},
 		{"alter table", "ALTER TABLE users ADD COLUMN name VARCHAR(255)", true},
 		{"drop table", "DROP TABLE users", true},
+		{"create view", "CREATE OR REPLACE VIEW active_users AS SELECT id FROM users", true},
+		{"alter view", "ALTER VIEW active_users AS SELECT id FROM users", true},
+		{"drop view", "DROP VIEW active_users", true},
 		{"truncate table", "TRUNCATE TABLE users", true},
 		{"start transaction", "START TRANSACTION", true},
 		{"begin", "BEGIN", true},
@@ -43,10 +64,17 @@ func TestMightBeSQL(t *testing.T) {
 		{"describe", "DESCRIBE users", true},
 		{"explain", "EXPLAIN SELECT * FROM users", true},
 		{"use", "USE mydb", true},
-		{"call not detected", "CALL my_proc()", false},
-		{"prepare not detected", "PREPARE stmt FROM 'SELECT 1'", false},
-		{"execute not detected", "EXECUTE stmt", false},
-		{"deallocate not detected", "DEALLOCATE PREPARE stmt", false},
+		{"call", "CALL my_proc()", true},
+		{"prepare", "PREPARE stmt FROM 'SELECT 1'", true},
+		{"execute", "EXECUTE stmt", true},
+		{"deallocate", "DEALLOCATE PREPARE stmt", true},
+		{"drop prepare", "DROP PREPARE stmt", true},
+		{"do", "DO SLEEP(1)", true},
+		{"do prose", "Do not retry", false},
+		{"table order by", "TABLE users ORDER BY id", true},
+		{"table limit", "TABLE users LIMIT 10", true},
+		{"bare table", "TABLE users", false},
+		{"table prose", "Table users are listed below", false},
 		{"desc not detected", "DESC users", false},
 	}
 	for _, tt := range tests {
@@ -58,3 +86,166 @@ func TestMightBeSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestMightBeSQLWithOptions_Threshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		threshold float64
+		want      bool
+	}{
+		{"zero selects default", "SELECT is a SQL keyword", 0, true},
+		{"keyword only below raised threshold", "SELECT is a SQL keyword", 0.7, false},
+		{"clause structure meets raised threshold", "SELECT id FROM users", 0.7, true},
+		{"bare statement meets raised threshold", "COMMIT", 0.7, true},
+		{"long bare statement below raised threshold", "Begin by reading the docs", 0.7, false},
+		{"maximum threshold", "SELECT id FROM users", 1, true},
+		{"lex failure below maximum threshold", "SELECT id FROM [users]", 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sqlfmt.MightBeSQLWithOptions(tt.in, sqlfmt.DetectOptions{Threshold: tt.threshold})
+			if got != tt.want {
+				t.Errorf("MightBeSQLWithOptions(%q, %v) = %v, want %v", tt.in, tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMightBeSQLWithOptions_SQLMode(t *testing.T) {
+	in := `SELECT 'C:\' FROM dual WHERE a = 'x'`
+
+	if sqlfmt.MightBeSQLWithOptions(in, sqlfmt.DetectOptions{Threshold: 1}) {
+		t.Errorf("default mode: %q should not lex cleanly", in)
+	}
+
+	opts := sqlfmt.DetectOptions{Threshold: 1, SQLMode: sqlfmt.SQLModeNoBackslashEscapes}
+	if !sqlfmt.MightBeSQLWithOptions(in, opts) {
+		t.Errorf("no_backslash_escapes mode: %q should lex cleanly", in)
+	}
+
+	if sqlfmt.MightBeSQLWithOptions("SELECT 1", sqlfmt.DetectOptions{SQLMode: "sideways"}) {
+		t.Error("an unrecognized SQL mode should never detect SQL")
+	}
+}
+
+func TestMightBeSQLWithOptions_TextTemplate(t *testing.T) {
+	in := "SELECT * FROM {{.Table}} {{if .ID}}WHERE id = {{.ID}}{{end}}"
+
+	// Braces lex as the punctuation of ODBC escapes ({d '...'}), so the
+	// unmasked template lexes cleanly too.
+	if !sqlfmt.MightBeSQLWithOptions(in, sqlfmt.DetectOptions{Threshold: 1}) {
+		t.Errorf("plain: %q should lex cleanly", in)
+	}
+
+	if !sqlfmt.MightBeSQLWithOptions(in, sqlfmt.DetectOptions{Threshold: 1, TextTemplate: true}) {
+		t.Errorf("text template: %q should lex cleanly once its actions are masked", in)
+	}
+}
+
+func TestMightBeSQLWithOptions_PrintfTemplate(t *testing.T) {
+	for _, in := range []string{
+		"SELECT %s FROM %s WHERE id = %d",
+		"SELECT * FROM users LIMIT %d",
+		"SELECT amount_%s FROM t WHERE id = ?",
+	} {
+		if sqlfmt.MightBeSQL(in) {
+			t.Errorf("plain: %q has a bare fmt verb and should not be detected", in)
+		}
+
+		if !sqlfmt.MightBeSQLWithOptions(in, sqlfmt.DetectOptions{PrintfTemplate: true}) {
+			t.Errorf("printf template: %q should be detected", in)
+		}
+	}
+}
+
+func TestDetectionScore(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want float64
+	}{
+		{"no leading keyword", "hello world", 0},
+		{"keyword only", "SELECT is a SQL keyword", 0.6},
+		{"keyword and clause", "select id from users where id = ?", 1},
+		{"bare statement", "RELEASE SAVEPOINT sp1", 1},
+		{"call with argument list", "CALL refresh_stats(?, ?)", 1},
+		{"call without argument list", "CALL refresh_stats", 0.6},
+		{"set operator as clause", "SELECT 1 INTERSECT SELECT 2", 1},
+		{"one lex failure among six tokens", "SELECT a, b FROM t]", 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sqlfmt.DetectionScore(tt.in, sqlfmt.DetectOptions{})
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("DetectionScore(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMightBeSQL_SpecExamples mirrors the worked examples and the
+// Detection Result Summary table in docs/detect-spec.md, so the spec can't
+// silently drift from the implementation.
+func TestMightBeSQL_SpecExamples(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		// Worked examples.
+		{"select id from users where id = ?", true},
+		{"SELECT %s FROM %s WHERE id = %d", false},
+		{"SELECT * FROM users WHERE name LIKE '%s%'", true},
+		{"https://example.com/select/users", false},
+		{"failed to execute query", false},
+		{"  SELECT id FROM users", true},
+		{"-- list active users\n(SELECT id FROM users)", true},
+		{"Update: don't forget to restart", false},
+
+		// Detection Result Summary.
+		{"select id from users", true},
+		{"INSERT INTO users (name) VALUES (?)", true},
+		{"update users set name = ?", true},
+		{"delete from users where id = ?", true},
+		{"REPLACE INTO users (id, name) VALUES (?, ?)", true},
+		{"WITH c AS (SELECT 1) SELECT * FROM c", true},
+		{"SELECT %s FROM %s", false},
+		{"SELECT * FROM users LIMIT %d", false},
+		{"hello world", false},
+		{"the SELECT statement", false},
+		{"", false},
+		{"CREATE TABLE users (id INT)", true},
+		{"ALTER TABLE users ADD COLUMN name VARCHAR(255)", true},
+		{"DROP TABLE users", true},
+		{"TRUNCATE TABLE users", true},
+		{"START TRANSACTION", true},
+		{"BEGIN", true},
+		{"COMMIT", true},
+		{"ROLLBACK", true},
+		{"SAVEPOINT sp1", true},
+		{"RELEASE SAVEPOINT sp1", true},
+		{"SET @x = 1", true},
+		{"SHOW TABLES", true},
+		{"DESCRIBE users", true},
+		{"EXPLAIN SELECT * FROM users", true},
+		{"USE mydb", true},
+		{"CALL my_proc()", true},
+		{"PREPARE stmt FROM 'SELECT 1'", true},
+		{"EXECUTE stmt", true},
+		{"DEALLOCATE PREPARE stmt", true},
+		{"DROP PREPARE stmt", true},
+		{"DO SLEEP(1)", true},
+		{"Do not retry", false},
+		{"TABLE users ORDER BY id", true},
+		{"Table users", false},
+		{"DESC users", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := sqlfmt.MightBeSQL(tt.in)
+			if got != tt.want {
+				t.Errorf("MightBeSQL(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
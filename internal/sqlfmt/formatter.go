@@ -3,6 +3,7 @@ package sqlfmt
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
@@ -19,6 +20,11 @@ const (
 
 	SQLModeDefault            = "default"
 	SQLModeNoBackslashEscapes = "no_backslash_escapes"
+	SQLModeANSIQuotes         = "ansi_quotes"
+	SQLModePipesAsConcat      = "pipes_as_concat"
+
+	IdentifierQuoteBacktick = "backtick"
+	IdentifierQuoteANSI     = "ansi"
 )
 
 var (
@@ -30,8 +36,17 @@ var (
 	// literal-substring replacer) keep adjacent keywords like "IS NULL" or
 	// "NOT BETWEEN" independently addressable — a substring replacer would
 	// consume the shared space between them and miss the second keyword.
+	// The MOD and BINARY operators are matched with their trailing space so
+	// that a MOD(...) function call or a BINARY(n) cast type, whose names are
+	// left as written, is not touched; CAST and CONVERT are matched with
+	// their opening parenthesis, and a CAST's ARRAY and the SIGNED/UNSIGNED
+	// [INTEGER|INT] cast types with their closing one, for the same reason. MATCH's search modifiers are matched as whole
+	// phrases, since BOOLEAN, QUERY and the rest are only keywords there.
 	keywordCaseRe = regexp.MustCompile(
-		`\b(AS|ASC|DESC|AND|OR|NOT|IN|IS|LIKE|BETWEEN|EXISTS|NULL|TRUE|FALSE|ON|USING)\b`,
+		`\b(AS|ASC|DESC|AND|OR|XOR|NOT|IN|IS|LIKE|BETWEEN|EXISTS|NULL|TRUE|FALSE|ON|USING|DIV|COLLATE|MATCH|AGAINST)\b` +
+			`|\b(?:MOD|BINARY) |\b(?:CAST|CONVERT)\(` +
+			`|\b(?:UN)?SIGNED(?: INTEGER| INT)?(?: ARRAY)?\)|\bARRAY\)` +
+			`|\b(?:NATURAL LANGUAGE MODE|BOOLEAN MODE|WITH QUERY EXPANSION)\b`,
 	)
 )
 
@@ -53,15 +68,54 @@ type Options struct {
 	// CommaStyleTrailing.
 	CommaStyle string
 
-	// SQLMode selects MySQL sql_mode-dependent string-literal parsing
-	// behavior. Defaults to SQLModeDefault, which matches MySQL without
-	// NO_BACKSLASH_ESCAPES set: backslash is a string-literal escape
-	// character. FormatSQL and FormatSQLWithOptions format one statement at
-	// a time with no session or connection state, so callers whose
-	// connection has NO_BACKSLASH_ESCAPES enabled must set SQLMode to
-	// SQLModeNoBackslashEscapes explicitly — sanat cannot infer it from the
-	// input SQL.
+	// SQLMode selects MySQL sql_mode-dependent quoting and operator
+	// behavior: one of SQLModeDefault, SQLModeNoBackslashEscapes,
+	// SQLModeANSIQuotes, and SQLModePipesAsConcat, or several joined with
+	// commas as in MySQL's sql_mode ("ansi_quotes,no_backslash_escapes").
+	// Defaults to SQLModeDefault, which matches MySQL with none of these
+	// modes set: backslash is a string-literal escape character, "..." is a
+	// string, and || is OR. FormatSQL and FormatSQLWithOptions format one
+	// statement at a time with no session or connection state, so callers
+	// whose connection has any of these modes enabled must set SQLMode
+	// explicitly — sanat cannot infer it from the input SQL.
 	SQLMode string
+
+	// PrintfTemplate marks sql as the format string of a fmt.Sprintf-style
+	// call. Its verbs (%s, %d, %[1]v, ...) are masked with sentinels while
+	// it is parsed and formatted, then restored exactly (see maskVerbs), and
+	// a %% is formatted as the % it prints.
+	PrintfTemplate bool
+
+	// TextTemplate marks sql as a text/template template. Its actions are
+	// masked while it is parsed and formatted (see maskTemplate): a value
+	// action ({{.Table}}) is restored where its sentinel ends up, and a
+	// control action ({{if .X}}, {{range .Y}}, {{end}}, ...) is put back on
+	// a line of its own.
+	TextTemplate bool
+
+	// IdentifierQuote selects how identifiers quoted in the input are quoted
+	// in the output: IdentifierQuoteBacktick (the default) keeps MySQL's
+	// backticks, and IdentifierQuoteANSI emits standard double quotes, for
+	// callers that can't hold a backtick (a Go raw string literal) and run
+	// their SQL with ANSI_QUOTES enabled. Identifiers that weren't quoted in
+	// the input are never quoted.
+	IdentifierQuote string
+
+	// ValuesRowAlias, when non-empty, migrates an INSERT off the deprecated
+	// VALUES(col) form in its ON DUPLICATE KEY UPDATE clause: each
+	// reference becomes ValuesRowAlias.col, and the INSERT gains the row
+	// alias `AS ValuesRowAlias` (see
+	// parser.ParseStatementWithValuesRowAlias for when the rewrite is
+	// skipped). It must be a plain, unquoted, non-keyword identifier, or
+	// formatting fails.
+	ValuesRowAlias string
+}
+
+// ValidValuesRowAlias reports whether alias is usable as
+// Options.ValuesRowAlias: empty (the rewrite is disabled), or a plain,
+// unquoted, non-keyword identifier.
+func ValidValuesRowAlias(alias string) bool {
+	return alias == "" || parser.IsPlainIdent(alias)
 }
 
 // formatter holds the resolved rendering options for a single FormatSQL call.
@@ -69,6 +123,15 @@ type formatter struct {
 	indent      int
 	keywordCase string
 	commaStyle  string
+
+	// opts and mode are kept whole for formatting a PREPARE's statement
+	// text, which goes through formatSQLText like a statement of its own.
+	opts Options
+	mode parser.SQLMode
+
+	// lineCommentEnds holds every line written so far that ends with a line
+	// comment, so appendComments never puts anything after one.
+	lineCommentEnds map[string]bool
 }
 
 func newFormatter(opts Options) *formatter {
@@ -76,8 +139,11 @@ func newFormatter(opts Options) *formatter {
 		indent:      opts.Indent,
 		keywordCase: opts.KeywordCase,
 		commaStyle:  opts.CommaStyle,
+		opts:        opts,
 	}
 
+	f.mode, _ = parserSQLMode(opts.SQLMode)
+
 	if f.keywordCase == "" {
 		f.keywordCase = KeywordCaseUpper
 	}
@@ -101,9 +167,26 @@ func FormatSQLWithOptions(sql string, opts Options) (string, bool) {
 		return sql, false
 	}
 
+	if _, ok := identifierQuoteChar(opts.IdentifierQuote, mode); !ok {
+		return sql, false
+	}
+
+	switch {
+	case opts.TextTemplate:
+		return formatTextTemplate(sql, opts, mode)
+	case opts.PrintfTemplate:
+		return formatPrintfTemplate(sql, opts, mode)
+	default:
+		return formatSQLText(sql, opts, mode)
+	}
+}
+
+// formatSQLText parses and formats sql, a single statement whose only
+// non-SQL syntax is ? placeholders.
+func formatSQLText(sql string, opts Options, mode parser.SQLMode) (string, bool) {
 	replaced, count := replacePlaceholders(sql)
 
-	stmt, err := parser.ParseStatementWithMode(replaced, mode)
+	stmt, err := parser.ParseStatementWithValuesRowAlias(replaced, mode, opts.ValuesRowAlias)
 	if err != nil {
 		return sql, false
 	}
@@ -113,23 +196,41 @@ func FormatSQLWithOptions(sql string, opts Options) (string, bool) {
 		return sql, false
 	}
 
+	quote, _ := identifierQuoteChar(opts.IdentifierQuote, mode)
+
+	result, ok = requoteIdentifiers(replaced, result, mode, quote)
+	if !ok || !keepsComments(replaced, result, mode) {
+		return sql, false
+	}
+
 	return restorePlaceholders(result, count), true
 }
 
-// parserSQLMode translates an Options.SQLMode value into the parser
-// package's SQLMode, reporting whether sqlMode was a recognized value.
-// The empty string and SQLModeDefault both map to parser.ModeDefault;
-// SQLModeNoBackslashEscapes maps to parser.ModeNoBackslashEscapes; any other
-// value is rejected with ok == false.
+// sqlModeFlags maps each Options.SQLMode value to the parser flag it adds.
+var sqlModeFlags = map[string]parser.SQLMode{
+	"":                        parser.ModeDefault,
+	SQLModeDefault:            parser.ModeDefault,
+	SQLModeNoBackslashEscapes: parser.ModeNoBackslashEscapes,
+	SQLModeANSIQuotes:         parser.ModeANSIQuotes,
+	SQLModePipesAsConcat:      parser.ModePipesAsConcat,
+}
+
+// parserSQLMode translates an Options.SQLMode value, a comma-separated list
+// of modes, into the parser package's SQLMode, the union of each mode's
+// flag in sqlModeFlags. ok is false when a mode isn't a recognized value.
 func parserSQLMode(sqlMode string) (parser.SQLMode, bool) {
-	switch sqlMode {
-	case "", SQLModeDefault:
-		return parser.ModeDefault, true
-	case SQLModeNoBackslashEscapes:
-		return parser.ModeNoBackslashEscapes, true
-	default:
-		return parser.ModeDefault, false
+	mode := parser.ModeDefault
+
+	for name := range strings.SplitSeq(sqlMode, ",") {
+		flag, ok := sqlModeFlags[strings.TrimSpace(name)]
+		if !ok {
+			return parser.ModeDefault, false
+		}
+
+		mode |= flag
 	}
+
+	return mode, true
 }
 
 // formatParsedStatement renders stmt, recovering a panic from an AST node
@@ -170,7 +271,26 @@ func restorePlaceholders(sql string, _ int) string {
 	return sentinelRe.ReplaceAllString(sql, "?")
 }
 
+// formatStatement formats stmt at depth, with its leading comments on lines
+// of their own before it and its trailing ones after its last line.
 func (f *formatter) formatStatement(b *strings.Builder, stmt sqlast.Statement, depth int) {
+	c := stmt.NodeComments()
+	if c.IsEmpty() {
+		f.formatStatementBody(b, stmt, depth)
+
+		return
+	}
+
+	var body strings.Builder
+
+	f.formatStatementBody(&body, stmt, depth)
+	f.appendTrailing(&body, c.Trailing, f.pad(depth))
+
+	writeComments(b, c.Leading, f.pad(depth))
+	b.WriteString(body.String())
+}
+
+func (f *formatter) formatStatementBody(b *strings.Builder, stmt sqlast.Statement, depth int) {
 	switch s := stmt.(type) {
 	case *sqlast.Select:
 		f.formatSelect(b, s, depth)
@@ -183,6 +303,10 @@ func (f *formatter) formatStatement(b *strings.Builder, stmt sqlast.Statement, d
 	case *sqlast.Union:
 		f.formatUnion(b, s, depth)
 	default:
+		if f.formatQueryStatement(b, stmt, depth) {
+			return
+		}
+
 		if f.formatDDLStatement(b, stmt, depth) {
 			return
 		}
@@ -197,8 +321,27 @@ func (f *formatter) formatStatement(b *strings.Builder, stmt sqlast.Statement, d
 	}
 }
 
+// formatQueryStatement handles the remaining query statement types (a
+// parenthesized set operation branch, VALUES, and TABLE), split out of
+// formatStatement to keep that switch's cyclomatic complexity down. It
+// reports whether stmt was one of them.
+func (f *formatter) formatQueryStatement(b *strings.Builder, stmt sqlast.Statement, depth int) bool {
+	switch s := stmt.(type) {
+	case *sqlast.ParenSelect:
+		f.formatParenSelect(b, s, depth)
+	case *sqlast.ValuesStatement:
+		f.formatValuesStatement(b, s, depth)
+	case *sqlast.TableStatement:
+		f.formatTableStatement(b, s, depth)
+	default:
+		return false
+	}
+
+	return true
+}
+
 // formatDDLStatement handles the DDL statement types (CREATE/ALTER/DROP
-// TABLE, CREATE/DROP INDEX, TRUNCATE TABLE), split out of formatStatement to
+// TABLE, CREATE/DROP INDEX, TRUNCATE TABLE, CREATE/ALTER/DROP VIEW), split out of formatStatement to
 // keep that switch's cyclomatic complexity down. It reports whether stmt was
 // a recognized DDL statement.
 func (f *formatter) formatDDLStatement(b *strings.Builder, stmt sqlast.Statement, depth int) bool {
@@ -215,6 +358,12 @@ func (f *formatter) formatDDLStatement(b *strings.Builder, stmt sqlast.Statement
 		f.formatDropTable(b, s, depth)
 	case *sqlast.TruncateTable:
 		f.formatTruncateTable(b, s, depth)
+	case *sqlast.CreateView:
+		f.formatCreateView(b, s, depth)
+	case *sqlast.AlterView:
+		f.formatViewDefinition(b, "ALTER ", &s.ViewDefinition, depth)
+	case *sqlast.DropView:
+		f.formatDropView(b, s, depth)
 	default:
 		return false
 	}
@@ -327,15 +476,40 @@ func (f *formatter) formatSetNames(b *strings.Builder, s *sqlast.SetNames, depth
 // formatAdminStatement handles the admin/utility statement types (SHOW
 // TABLES/CREATE TABLE/COLUMNS/INDEX/DATABASES/VARIABLES/STATUS, DESCRIBE,
 // EXPLAIN, USE), split out of formatStatement to keep that switch's
-// cyclomatic complexity down. It reports whether stmt was a recognized
-// admin/utility statement.
+// cyclomatic complexity down. Any other statement type is passed on to
+// formatProgramStatement. It reports whether stmt was recognized by either.
 func (f *formatter) formatAdminStatement(b *strings.Builder, stmt sqlast.Statement, depth int) bool {
 	switch s := stmt.(type) {
 	case *sqlast.ShowTables, *sqlast.ShowCreateTable, *sqlast.ShowColumns, *sqlast.ShowIndex,
-		*sqlast.ShowDatabases, *sqlast.ShowVariables, *sqlast.ShowStatus, *sqlast.Describe, *sqlast.Use:
+		*sqlast.ShowDatabases, *sqlast.ShowVariables, *sqlast.ShowStatus, *sqlast.Describe, *sqlast.Use,
+		*sqlast.ExecutableCommentStatement:
 		f.formatSingleLineStatement(b, s, depth)
 	case *sqlast.Explain:
 		f.formatExplain(b, s, depth)
+	default:
+		return f.formatProgramStatement(b, stmt, depth)
+	}
+
+	return true
+}
+
+// formatProgramStatement handles the stored-program utility statement types
+// (CALL, PREPARE, EXECUTE, DEALLOCATE PREPARE, DO), split out of
+// formatAdminStatement to keep that switch's cyclomatic complexity down. It
+// reports whether stmt was a recognized stored-program utility statement.
+func (f *formatter) formatProgramStatement(b *strings.Builder, stmt sqlast.Statement, depth int) bool {
+	switch s := stmt.(type) {
+	case *sqlast.Call:
+		f.formatCall(b, s, depth)
+	case *sqlast.Prepare:
+		f.formatPrepare(b, s, depth)
+	case *sqlast.Execute, *sqlast.DeallocatePrepare:
+		f.formatSingleLineStatement(b, s, depth)
+	case *sqlast.Do:
+		b.WriteString(f.pad(depth))
+		b.WriteString("DO ")
+		b.WriteString(f.formatExprList(s.Exprs, depth))
+		b.WriteString("\n")
 	default:
 		return false
 	}
@@ -343,6 +517,80 @@ func (f *formatter) formatAdminStatement(b *strings.Builder, stmt sqlast.Stateme
 	return true
 }
 
+// formatCall writes a CALL statement on one line, its arguments formatted
+// like a function call's.
+func (f *formatter) formatCall(b *strings.Builder, s *sqlast.Call, depth int) {
+	b.WriteString(f.pad(depth))
+	b.WriteString("CALL ")
+	b.WriteString(s.Procedure.String())
+
+	if s.Parens {
+		b.WriteString("(")
+		b.WriteString(f.formatExprList(s.Args, depth))
+		b.WriteString(")")
+	}
+
+	b.WriteString("\n")
+}
+
+// formatPrepare writes "PREPARE name FROM text". When text is a string
+// literal holding a statement formatSQLText can format, the statement is
+// formatted under the same options and quoted again, starting right after
+// FROM and continuing at the start of each following line, so the only
+// whitespace it gains is the formatting's own. Otherwise the text is written
+// unchanged.
+func (f *formatter) formatPrepare(b *strings.Builder, s *sqlast.Prepare, depth int) {
+	b.WriteString(f.pad(depth))
+	b.WriteString("PREPARE ")
+	b.WriteString(s.Name.String())
+	b.WriteString(" FROM ")
+	b.WriteString(f.formatPreparedText(s.From, depth))
+	b.WriteString("\n")
+}
+
+func (f *formatter) formatPreparedText(from sqlast.Expr, depth int) string {
+	lit, ok := from.(*sqlast.Literal)
+	if !ok {
+		return f.formatExpr(from, depth)
+	}
+
+	tok, err := parser.NewWithMode(lit.Val, f.mode).Next()
+	if err != nil || tok.Type != parser.STRING {
+		return lit.Val
+	}
+
+	formatted, ok := formatSQLText(tok.Literal, f.opts, f.mode)
+	if !ok {
+		return lit.Val
+	}
+
+	return quoteString(strings.TrimRight(formatted, "\n"), tok.Quote, f.mode)
+}
+
+// quoteString quotes s as a string literal between quote characters (' or
+// ") that decodes back to s under mode. Unlike the parser's own re-encoding,
+// line breaks are kept as they are rather than escaped, so a formatted
+// statement stays readable.
+func quoteString(s string, quote byte, mode parser.SQLMode) string {
+	if !mode.Has(parser.ModeNoBackslashEscapes) {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+
+	q := string(quote)
+
+	return q + strings.ReplaceAll(s, q, q+q) + q
+}
+
+// formatExprList formats exprs at depth, separated by ", ".
+func (f *formatter) formatExprList(exprs []sqlast.Expr, depth int) string {
+	strs := make([]string, len(exprs))
+	for i, e := range exprs {
+		strs[i] = f.formatExpr(e, depth)
+	}
+
+	return strings.Join(strs, ", ")
+}
+
 // formatSingleLineStatement writes a statement whose sqlast node's String()
 // is already the exact rendered output: none of the SHOW/DESCRIBE/USE
 // statement kinds have an Expr-valued field that needs keyword-case or
@@ -389,8 +637,9 @@ func (f *formatter) keyword(word string) string {
 
 // applyKeywordCase lowercases operator/predicate keywords embedded in text
 // produced by an sqlast node's String() method (which always renders them
-// uppercase), skipping quoted regions so literal contents are left intact.
-// It is a no-op unless keywordCase is KeywordCaseLower.
+// uppercase), skipping quoted regions so literal contents are left intact,
+// and /*! ... */ executable comments, which are kept as written. It is a
+// no-op unless keywordCase is KeywordCaseLower.
 func (f *formatter) applyKeywordCase(s string) string {
 	if f.keywordCase != KeywordCaseLower {
 		return s
@@ -403,14 +652,12 @@ func (f *formatter) applyKeywordCase(s string) string {
 	segStart := 0
 
 	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c != '\'' && c != '"' && c != '`' {
+		end, ok := opaqueRegionEnd(s, i)
+		if !ok {
 			continue
 		}
 
 		b.WriteString(keywordCaseRe.ReplaceAllStringFunc(s[segStart:i], strings.ToLower))
-
-		end := quotedRegionEnd(s, i, c)
 		b.WriteString(s[i:end])
 		segStart = end
 		i = end - 1
@@ -421,6 +668,23 @@ func (f *formatter) applyKeywordCase(s string) string {
 	return b.String()
 }
 
+// opaqueRegionEnd returns the index just past the quoted region or /*! ... */
+// executable comment starting at s[i], and whether one starts there.
+func opaqueRegionEnd(s string, i int) (int, bool) {
+	switch c := s[i]; {
+	case c == '\'' || c == '"' || c == '`':
+		return quotedRegionEnd(s, i, c), true
+	case strings.HasPrefix(s[i:], "/*!"):
+		if n := strings.Index(s[i+len("/*!"):], "*/"); n >= 0 {
+			return i + len("/*!") + n + len("*/"), true
+		}
+
+		return len(s), true
+	default:
+		return 0, false
+	}
+}
+
 // quotedRegionEnd returns the index just past the closing quote of the
 // quoted region starting at s[start] (a c-quote character), treating
 // backslash as an escape for the following character.
@@ -468,8 +732,22 @@ func (f *formatter) itemSuffix(i, n int) string {
 // writeList writes pre-rendered single-line items, applying the configured
 // comma style.
 func (f *formatter) writeList(b *strings.Builder, pi string, lines []string) {
+	f.writeCommentedList(b, pi, lines, nil)
+}
+
+// writeCommentedList is writeList for items that carry comments, comments[i]
+// being item i's: its leading comments go on lines of their own before it,
+// and its trailing ones after it and its comma. A nil comments is none.
+func (f *formatter) writeCommentedList(b *strings.Builder, pi string, lines []string, comments []*sqlast.Comments) {
 	for i, line := range lines {
-		b.WriteString(f.itemPrefix(pi, i) + line + f.itemSuffix(i, len(lines)) + "\n")
+		line = f.itemPrefix(pi, i) + line + f.itemSuffix(i, len(lines))
+
+		if comments != nil {
+			writeComments(b, comments[i].Leading, pi)
+			line = f.appendComments(line, comments[i].Trailing, pi)
+		}
+
+		b.WriteString(line + "\n")
 	}
 }
 
@@ -524,52 +802,136 @@ func (f *formatter) formatSelect(b *strings.Builder, s *sqlast.Select, depth int
 
 	f.formatWith(b, s.With, depth)
 
-	b.WriteString(p)
-	b.WriteString("SELECT")
+	action := "SELECT" + hintSuffix(s.Hints)
 
 	if s.Distinct {
-		b.WriteString(" DISTINCT")
+		action += " DISTINCT"
 	}
 
-	b.WriteString("\n")
+	if s.Modifiers != 0 {
+		action += " " + s.Modifiers.String()
+	}
+
+	f.writeClause(b, s.Clauses, "SELECT", action, p)
 	f.formatSelectExprs(b, s.SelectExprs, pi, depth)
+	f.formatSelectInto(b, s.Into, sqlast.IntoBeforeFrom, p, pi)
 
 	if len(s.From) > 0 {
-		b.WriteString(p)
-		b.WriteString("FROM\n")
+		f.writeClause(b, s.Clauses, "FROM", "FROM", p)
 		f.formatTableExprs(b, s.From, pi, depth)
 	}
 
-	if s.Where != nil {
-		b.WriteString(p)
-		b.WriteString("WHERE\n")
-		f.formatWhere(b, s.Where.Expr, pi, depth)
+	f.formatWhereClause(b, s.Clauses, "WHERE", s.Where, p, depth)
+	f.formatGroupBy(b, s.Clauses, s.GroupBy, p, pi, depth)
+	f.formatWhereClause(b, s.Clauses, "HAVING", s.Having, p, depth)
+	f.formatWindowClause(b, s.Clauses, s.Window, p, pi, depth)
+	f.formatOrderBy(b, s.Clauses, s.OrderBy, p, pi, depth)
+	f.formatLimit(b, s.Clauses, s.Limit, p, depth)
+
+	f.formatSelectInto(b, s.Into, sqlast.IntoBeforeLock, p, pi)
+	formatLock(b, s.Lock, s.LockWait, p)
+	f.formatSelectInto(b, s.Into, sqlast.IntoAfterLock, p, pi)
+}
+
+// hintSuffix returns a statement's optimizer hint and executable comments,
+// preceded by a space, to follow its leading keyword on the same line, or ""
+// if it has none. They're written as the parser read them; an executable
+// comment in particular is opaque, so even the keyword case is left alone.
+func hintSuffix(hints sqlast.HintComments) string {
+	if len(hints) == 0 {
+		return ""
 	}
 
-	f.formatGroupBy(b, s.GroupBy, p, pi, depth)
+	return " " + hints.String()
+}
 
-	if s.Having != nil {
-		b.WriteString(p)
-		b.WriteString("HAVING\n")
-		f.formatWhere(b, s.Having.Expr, pi, depth)
+// formatSelectInto formats a SELECT's INTO clause as its own section if it
+// was written at position pos. A variable list goes one variable per list
+// item; an OUTFILE/DUMPFILE target stays on the INTO line, with OUTFILE's
+// CHARACTER SET, FIELDS, and LINES options each on their own indented line.
+func (f *formatter) formatSelectInto(
+	b *strings.Builder, into *sqlast.SelectInto, pos sqlast.IntoPosition, p, pi string,
+) {
+	if into == nil || into.Position != pos {
+		return
 	}
 
-	f.formatOrderBy(b, s.OrderBy, p, pi, depth)
+	b.WriteString(p)
+
+	if into.Type == sqlast.IntoVariables {
+		b.WriteString("INTO\n")
+
+		lines := make([]string, len(into.Variables))
+		for i, v := range into.Variables {
+			lines[i] = v.String()
+		}
+
+		f.writeList(b, pi, lines)
 
-	if s.Limit != nil {
-		f.formatLimit(b, s.Limit, p, depth)
+		return
 	}
 
-	formatLock(b, s.Lock, s.LockWait, p)
+	b.WriteString("INTO " + into.TargetString() + "\n")
+
+	for _, line := range exportOptionLines(into) {
+		b.WriteString(pi + line + "\n")
+	}
+}
+
+// exportOptionLines returns the CHARACTER SET, FIELDS, and LINES options of
+// an INTO OUTFILE clause that are present, one entry per option.
+func exportOptionLines(into *sqlast.SelectInto) []string {
+	var lines []string
+
+	if into.Charset != "" {
+		lines = append(lines, "CHARACTER SET "+into.Charset)
+	}
+
+	if into.Export == nil {
+		return lines
+	}
+
+	if fields := into.Export.FieldsString(); fields != "" {
+		lines = append(lines, fields)
+	}
+
+	if linesOpt := into.Export.LinesString(); linesOpt != "" {
+		lines = append(lines, linesOpt)
+	}
+
+	return lines
+}
+
+// formatWindowClause formats a SELECT's WINDOW clause as its own section,
+// one `name AS (spec)` definition per list item with the specification
+// broken out like an OVER clause's.
+func (f *formatter) formatWindowClause(
+	b *strings.Builder, clauses sqlast.ClauseComments, windows sqlast.NamedWindows, p, pi string, depth int,
+) {
+	if len(windows) == 0 {
+		return
+	}
+
+	f.writeClause(b, clauses, "WINDOW", "WINDOW", p)
+
+	lines := make([]string, len(windows))
+	for i, w := range windows {
+		lines[i] = w.Name.String() + " " + f.keyword("AS") + " " + f.formatWindowSpec(w.Spec, depth+1)
+	}
+
+	f.writeList(b, pi, lines)
 }
 
 func (f *formatter) formatSelectExprs(b *strings.Builder, exprs []sqlast.SelectExpr, pi string, depth int) {
 	lines := make([]string, len(exprs))
+	comments := make([]*sqlast.Comments, len(exprs))
+
 	for i, expr := range exprs {
 		lines[i] = f.formatSelectExpr(expr, depth)
+		comments[i] = expr.NodeComments()
 	}
 
-	f.writeList(b, pi, lines)
+	f.writeCommentedList(b, pi, lines, comments)
 }
 
 func (f *formatter) formatSelectExpr(expr sqlast.SelectExpr, depth int) string {
@@ -593,7 +955,8 @@ func (f *formatter) formatSelectExpr(expr sqlast.SelectExpr, depth int) string {
 // element is rendered into its own builder first so the configured comma
 // style's prefix/suffix can be spliced onto its first/last line, without
 // threading "is this the first/last element" through formatTableExpr's
-// JOIN/paren recursion.
+// JOIN/paren recursion. The element's comments go around it as a list
+// item's do (see writeCommentedList).
 func (f *formatter) formatTableExprs(b *strings.Builder, exprs []sqlast.TableExpr, pi string, depth int) {
 	n := len(exprs)
 
@@ -604,7 +967,9 @@ func (f *formatter) formatTableExprs(b *strings.Builder, exprs []sqlast.TableExp
 
 		lines := strings.Split(strings.TrimSuffix(item.String(), "\n"), "\n")
 		lines[0] = f.itemPrefix(pi, i) + strings.TrimPrefix(lines[0], pi)
-		lines[len(lines)-1] += f.itemSuffix(i, n)
+		lines[len(lines)-1] = f.appendComments(lines[len(lines)-1]+f.itemSuffix(i, n), expr.NodeComments().Trailing, pi)
+
+		writeComments(b, expr.NodeComments().Leading, pi)
 
 		for _, line := range lines {
 			b.WriteString(line + "\n")
@@ -624,14 +989,67 @@ func (f *formatter) formatTableExpr(b *strings.Builder, expr sqlast.TableExpr, p
 		f.formatTableExprs(b, e.Exprs, f.pad(depth+2), depth+1)
 		b.WriteString(pi)
 		b.WriteString(")\n")
+	case *sqlast.JSONTableExpr:
+		f.formatJSONTableExpr(b, e, pi, depth)
 	default:
 		panic(fmt.Sprintf("sqlfmt: unhandled table expr type %T", expr))
 	}
 }
 
+// formatJSONTableExpr renders JSON_TABLE with its COLUMNS list broken out
+// one column per line, like CREATE TABLE's column list. Column text isn't
+// passed through applyKeywordCase, for the same reason as CREATE TABLE's:
+// it's dominated by clause keywords (PATH, ON EMPTY, ...) that stay
+// uppercase.
+func (f *formatter) formatJSONTableExpr(b *strings.Builder, e *sqlast.JSONTableExpr, pi string, depth int) {
+	b.WriteString(pi)
+	b.WriteString("JSON_TABLE(")
+	b.WriteString(f.formatExpr(e.Expr, depth+1))
+	b.WriteString(", ")
+	b.WriteString(e.Path.String())
+	b.WriteString(" COLUMNS (\n")
+	f.formatJSONTableColumns(b, e.Columns, depth+2)
+	b.WriteString(pi)
+	b.WriteString("))")
+
+	if !e.As.IsEmpty() {
+		b.WriteString(" ")
+		b.WriteString(e.As.String())
+	}
+
+	b.WriteString("\n")
+}
+
+// formatJSONTableColumns writes a JSON_TABLE COLUMNS list one column per
+// line at depth, recursing into each NESTED PATH's own list.
+func (f *formatter) formatJSONTableColumns(b *strings.Builder, cols []sqlast.JSONTableColumn, depth int) {
+	pi := f.pad(depth)
+
+	for i, c := range cols {
+		prefix := f.itemPrefix(pi, i)
+		suffix := f.itemSuffix(i, len(cols))
+
+		nested, ok := c.(*sqlast.JSONTableNestedPath)
+		if !ok {
+			b.WriteString(prefix + c.String() + suffix + "\n")
+
+			continue
+		}
+
+		b.WriteString(prefix + "NESTED PATH " + nested.Path.String() + " COLUMNS (\n")
+		f.formatJSONTableColumns(b, nested.Columns, depth+1)
+		b.WriteString(pi + ")" + suffix + "\n")
+	}
+}
+
 func (f *formatter) formatAliasedTableExpr(b *strings.Builder, e *sqlast.AliasedTableExpr, pi string, depth int) {
 	if sub, ok := e.Expr.(*sqlast.DerivedTable); ok {
 		b.WriteString(pi)
+
+		if sub.Lateral {
+			b.WriteString("LATERAL ")
+		}
+
 		b.WriteString("(\n")
 		f.formatStatement(b, sub.Select, depth+1)
 		b.WriteString(pi)
@@ -646,6 +1064,10 @@ func (f *formatter) formatAliasedTableExpr(b *strings.Builder, e *sqlast.Aliased
 		b.WriteString(e.As.String())
 	}
 
+	if len(e.Columns) > 0 {
+		b.WriteString(" (" + e.Columns.String() + ")")
+	}
+
 	b.WriteString(formatIndexHints(e.Hints))
 	b.WriteString("\n")
 }
@@ -663,14 +1085,28 @@ func formatIndexHints(hints sqlast.IndexHints) string {
 	return " " + strings.Join(parts, " ")
 }
 
+// formatJoinTableExpr formats a join, with the comments each operand
+// carries around it: the left one's up to the join keyword, the right one's
+// from after the keyword up to ON or USING. Those from there on belong to
+// whatever encloses the join.
 func (f *formatter) formatJoinTableExpr(b *strings.Builder, e *sqlast.JoinTableExpr, pi string, depth int) {
+	left := e.LeftExpr.NodeComments()
+
+	writeComments(b, left.Leading, pi)
 	f.formatTableExpr(b, e.LeftExpr, pi, depth)
+	f.appendTrailing(b, left.Trailing, pi)
+
 	joinStr := strings.ToUpper(e.Join.ToString())
 
 	b.WriteString(pi)
 	b.WriteString(joinStr)
 	b.WriteString("\n")
+
+	right := e.RightExpr.NodeComments()
+
+	writeComments(b, right.Leading, pi)
 	f.formatTableExpr(b, e.RightExpr, pi, depth)
+	f.appendTrailing(b, right.Trailing, pi)
 
 	if e.Condition != nil && e.Condition.On != nil {
 		b.WriteString(f.pad(depth + 2))
@@ -681,19 +1117,36 @@ func (f *formatter) formatJoinTableExpr(b *strings.Builder, e *sqlast.JoinTableE
 	}
 }
 
-func (f *formatter) formatWhere(b *strings.Builder, expr sqlast.Expr, pi string, depth int) {
-	f.formatWhereExpr(b, expr, pi, depth, true)
+// formatWhereClause formats a WHERE or HAVING clause (per kw), if where
+// isn't nil, with its comments around its conditions.
+func (f *formatter) formatWhereClause(
+	b *strings.Builder, clauses sqlast.ClauseComments, kw string, where *sqlast.Where, p string, depth int,
+) {
+	if where == nil {
+		return
+	}
+
+	pi := f.pad(depth + 1)
+
+	f.writeClause(b, clauses, kw, kw, p)
+	writeComments(b, where.Leading, pi)
+	f.formatWhereExpr(b, where.Expr, pi, depth, true)
+	f.appendTrailing(b, where.Trailing, pi)
 }
 
+// formatWhereExpr formats a WHERE or HAVING condition one AND/OR operand
+// per line, each operator's comments ending the line before it.
 func (f *formatter) formatWhereExpr(b *strings.Builder, expr sqlast.Expr, pi string, depth int, first bool) {
 	exprDepth := depth + 1
 
 	switch e := expr.(type) {
 	case *sqlast.AndExpr:
 		f.formatWhereExpr(b, e.Left, pi, depth, first)
+		f.appendTrailing(b, e.Leading, pi)
 		f.formatWhereExpr(b, e.Right, pi, depth, false)
 	case *sqlast.OrExpr:
 		f.formatWhereExpr(b, e.Left, pi, depth, first)
+		f.appendTrailing(b, e.Leading, pi)
 		b.WriteString(pi)
 		b.WriteString(f.keyword("OR"))
 		b.WriteString(" ")
@@ -739,10 +1192,15 @@ func (f *formatter) formatExpr(expr sqlast.Expr, depth int) string {
 		right := f.formatExpr(e.Right, depth)
 
 		return f.formatExpr(e.Left, depth) + " " + f.keyword(e.Operator.ToString()) + " " + right
+	case *sqlast.QuantifiedComparisonExpr:
+		return f.formatExpr(e.Left, depth) + " " + f.keyword(e.Operator.ToString()) + " " +
+			f.keyword(e.Quantifier.ToString()) + " " + f.formatExpr(e.Subquery, depth)
 	case *sqlast.NotExpr:
 		return f.keyword("NOT") + " " + f.formatExpr(e.Expr, depth)
 	case *sqlast.CaseExpr:
 		return f.formatCaseExpr(e, depth)
+	case *sqlast.ExecutableCommentExpr:
+		return f.formatExpr(e.Expr, depth) + " " + e.Comment.String()
 	default:
 		if accessor := getOverAccessor(expr); accessor != nil {
 			if oc := accessor.getOverClause(); oc != nil {
@@ -760,32 +1218,25 @@ func (f *formatter) formatCaseExpr(e *sqlast.CaseExpr, depth int) string {
 	pi := f.pad(depth + 1)
 	p := f.pad(depth)
 
-	b.WriteString("CASE")
+	line := "CASE"
 
 	if e.Expr != nil {
-		b.WriteString(" ")
-		b.WriteString(f.formatExpr(e.Expr, depth))
+		line += " " + f.formatExpr(e.Expr, depth)
 	}
 
-	b.WriteString("\n")
+	b.WriteString(f.appendComments(line, slices.Concat(e.Leading, e.Trailing), pi) + "\n")
 
 	for _, when := range e.Whens {
 		cond := f.formatExpr(when.Cond, depth+1)
 		val := f.formatExpr(when.Val, depth+1)
 
-		b.WriteString(pi)
-		b.WriteString("WHEN ")
-		b.WriteString(cond)
-		b.WriteString(" THEN ")
-		b.WriteString(val)
-		b.WriteString("\n")
+		writeComments(&b, when.Leading, pi)
+		b.WriteString(f.appendComments(pi+"WHEN "+cond+" THEN "+val, when.Trailing, pi) + "\n")
 	}
 
 	if e.Else != nil {
-		b.WriteString(pi)
-		b.WriteString("ELSE ")
-		b.WriteString(f.formatExpr(e.Else, depth+1))
-		b.WriteString("\n")
+		writeComments(&b, e.ElseComments.Leading, pi)
+		b.WriteString(f.appendComments(pi+"ELSE "+f.formatExpr(e.Else, depth+1), e.ElseComments.Trailing, pi) + "\n")
 	}
 
 	b.WriteString(p)
@@ -821,6 +1272,8 @@ func getOverAccessor(expr sqlast.Expr) overClauseAccessor { //nolint:cyclop,funl
 		return overClauseField{&e.OverClause}
 	case *sqlast.Max:
 		return overClauseField{&e.OverClause}
+	case *sqlast.GroupConcat:
+		return overClauseField{&e.OverClause}
 	case *sqlast.BitAnd:
 		return overClauseField{&e.OverClause}
 	case *sqlast.BitOr:
@@ -878,9 +1331,16 @@ func (f *formatter) formatOverClause(oc *sqlast.OverClause, depth int) string {
 		return "OVER " + oc.WindowName.String()
 	}
 
-	parts := f.formatWindowSpecParts(oc.WindowSpec, depth)
+	return "OVER " + f.formatWindowSpec(oc.WindowSpec, depth)
+}
+
+// formatWindowSpec renders a parenthesized window specification, shared by
+// OVER clauses and WINDOW clause definitions: each part on its own line one
+// level deeper than depth, or just "()" for an empty specification.
+func (f *formatter) formatWindowSpec(spec *sqlast.WindowSpecification, depth int) string {
+	parts := f.formatWindowSpecParts(spec, depth)
 	if len(parts) == 0 {
-		return "OVER ()"
+		return "()"
 	}
 
 	pi := f.pad(depth + 1)
@@ -888,7 +1348,7 @@ func (f *formatter) formatOverClause(oc *sqlast.OverClause, depth int) string {
 
 	var b strings.Builder
 
-	b.WriteString("OVER (\n")
+	b.WriteString("(\n")
 
 	for _, part := range parts {
 		b.WriteString(pi)
@@ -905,6 +1365,10 @@ func (f *formatter) formatOverClause(oc *sqlast.OverClause, depth int) string {
 func (f *formatter) formatWindowSpecParts(spec *sqlast.WindowSpecification, depth int) []string {
 	var parts []string
 
+	if !spec.Name.IsEmpty() {
+		parts = append(parts, spec.Name.String())
+	}
+
 	if len(spec.PartitionClause) > 0 {
 		exprs := make([]string, len(spec.PartitionClause))
 		for i, e := range spec.PartitionClause {
@@ -956,6 +1420,8 @@ func (f *formatter) formatInsert(b *strings.Builder, s *sqlast.Insert, depth int
 		action = "REPLACE"
 	}
 
+	action += hintSuffix(s.Hints)
+
 	if s.Ignore {
 		action += " IGNORE"
 	}
@@ -967,12 +1433,34 @@ func (f *formatter) formatInsert(b *strings.Builder, s *sqlast.Insert, depth int
 	b.WriteString(s.Table.String())
 	b.WriteString("\n")
 
-	f.formatInsertColumns(b, s.Columns, p, pi)
-	f.formatInsertRows(b, s.Rows, p, pi, depth)
-	f.formatOnDupUpdate(b, s.OnDup, p, pi)
+	f.formatInsertColumns(b, s.Columns, s.ColumnComments, p, pi)
+	f.formatInsertRows(b, s.Clauses, s.Rows, p, pi, depth)
+	f.formatRowAlias(b, s.RowAlias, p)
+	f.formatSetExprs(b, s.Clauses, "ON DUPLICATE KEY UPDATE", s.OnDup, p, pi)
 }
 
-func (f *formatter) formatInsertColumns(b *strings.Builder, cols sqlast.Columns, p, pi string) {
+// formatRowAlias writes an INSERT's row alias on its own line after the row
+// source, with any column aliases inline.
+func (f *formatter) formatRowAlias(b *strings.Builder, alias *sqlast.RowAlias, p string) {
+	if alias == nil {
+		return
+	}
+
+	b.WriteString(p)
+	b.WriteString(f.keyword("AS") + " " + alias.Name.String())
+
+	if len(alias.Columns) > 0 {
+		b.WriteString(" (" + alias.Columns.String() + ")")
+	}
+
+	b.WriteString("\n")
+}
+
+// formatInsertColumns writes an INSERT's column list one column per line,
+// each with its comments from comments, which is nil if none has any.
+func (f *formatter) formatInsertColumns(
+	b *strings.Builder, cols sqlast.Columns, comments []sqlast.Comments, p, pi string,
+) {
 	if len(cols) == 0 {
 		return
 	}
@@ -985,30 +1473,48 @@ func (f *formatter) formatInsertColumns(b *strings.Builder, cols sqlast.Columns,
 		lines[i] = col.String()
 	}
 
-	f.writeList(b, pi, lines)
+	var itemComments []*sqlast.Comments
+
+	for i := range comments {
+		itemComments = append(itemComments, &comments[i])
+	}
+
+	f.writeCommentedList(b, pi, lines, itemComments)
 
 	b.WriteString(p)
 	b.WriteString(")\n")
 }
 
-func (f *formatter) formatInsertRows(b *strings.Builder, rows sqlast.InsertRows, p, pi string, depth int) {
+// formatInsertRows formats an INSERT's row source, with the comments around
+// a VALUES or SET keyword from clauses, the INSERT's.
+func (f *formatter) formatInsertRows(
+	b *strings.Builder, clauses sqlast.ClauseComments, rows sqlast.InsertRows, p, pi string, depth int,
+) {
 	switch r := rows.(type) {
 	case sqlast.Values:
-		f.formatValuesRows(b, r, p, pi, depth)
+		f.formatValuesRows(b, clauses, r, "", p, pi, depth)
+	case *sqlast.ValuesStatement:
+		f.formatValuesStatement(b, r, depth)
+	case *sqlast.TableStatement:
+		f.formatTableStatement(b, r, depth)
 	case *sqlast.Select:
 		f.formatSelect(b, r, depth)
 	case *sqlast.Union:
 		f.formatUnion(b, r, depth)
 	case sqlast.SetExprs:
-		f.formatSetExprs(b, r, p, pi)
+		f.formatSetExprs(b, clauses, "SET", r, p, pi)
 	default:
 		panic(fmt.Sprintf("sqlfmt: unhandled insert rows type %T", rows))
 	}
 }
 
-func (f *formatter) formatValuesRows(b *strings.Builder, rows sqlast.Values, p, pi string, depth int) {
-	b.WriteString(p)
-	b.WriteString("VALUES\n")
+// formatValuesRows writes a VALUES clause one row per line, each row
+// preceded by rowPrefix (ROW for a table value constructor), with the
+// comments around the VALUES keyword from clauses.
+func (f *formatter) formatValuesRows(
+	b *strings.Builder, clauses sqlast.ClauseComments, rows sqlast.Values, rowPrefix, p, pi string, depth int,
+) {
+	f.writeClause(b, clauses, "VALUES", "VALUES", p)
 
 	lines := make([]string, len(rows))
 
@@ -1018,38 +1524,58 @@ func (f *formatter) formatValuesRows(b *strings.Builder, rows sqlast.Values, p,
 			vals[j] = f.formatExpr(v, depth)
 		}
 
-		lines[i] = "(" + strings.Join(vals, ", ") + ")"
+		lines[i] = rowPrefix + "(" + strings.Join(vals, ", ") + ")"
 	}
 
 	f.writeList(b, pi, lines)
 }
 
-func (f *formatter) formatSetExprs(b *strings.Builder, exprs sqlast.SetExprs, p, pi string) {
-	b.WriteString(p)
-	b.WriteString("SET\n")
+// formatValuesStatement formats a VALUES table value constructor with each
+// ROW on its own line, followed by its ORDER BY/LIMIT clauses.
+func (f *formatter) formatValuesStatement(b *strings.Builder, s *sqlast.ValuesStatement, depth int) {
+	p := f.pad(depth)
+	pi := f.pad(depth + 1)
 
-	lines := make([]string, len(exprs))
-	for i, expr := range exprs {
-		lines[i] = f.applyKeywordCase(expr.String())
-	}
+	f.formatValuesRows(b, s.Clauses, s.Rows, f.keyword("ROW"), p, pi, depth)
+	f.formatOrderBy(b, s.Clauses, s.OrderBy, p, pi, depth)
+	f.formatLimit(b, s.Clauses, s.Limit, p, depth)
+}
 
-	f.writeList(b, pi, lines)
+// formatTableStatement formats TABLE t with the table on its own indented
+// line, followed by its ORDER BY/LIMIT clauses.
+func (f *formatter) formatTableStatement(b *strings.Builder, s *sqlast.TableStatement, depth int) {
+	p := f.pad(depth)
+	pi := f.pad(depth + 1)
+
+	b.WriteString(p)
+	b.WriteString("TABLE\n")
+	b.WriteString(pi)
+	b.WriteString(s.Table.String())
+	b.WriteString("\n")
+	f.formatOrderBy(b, s.Clauses, s.OrderBy, p, pi, depth)
+	f.formatLimit(b, s.Clauses, s.Limit, p, depth)
 }
 
-func (f *formatter) formatOnDupUpdate(b *strings.Builder, onDup sqlast.OnDup, p, pi string) {
-	if len(onDup) == 0 {
+// formatSetExprs formats a list of assignments (UPDATE's or INSERT's SET,
+// or ON DUPLICATE KEY UPDATE, per kw), if there are any, one per line.
+func (f *formatter) formatSetExprs(
+	b *strings.Builder, clauses sqlast.ClauseComments, kw string, exprs []*sqlast.UpdateExpr, p, pi string,
+) {
+	if len(exprs) == 0 {
 		return
 	}
 
-	b.WriteString(p)
-	b.WriteString("ON DUPLICATE KEY UPDATE\n")
+	f.writeClause(b, clauses, kw, kw, p)
+
+	lines := make([]string, len(exprs))
+	comments := make([]*sqlast.Comments, len(exprs))
 
-	lines := make([]string, len(onDup))
-	for i, expr := range onDup {
+	for i, expr := range exprs {
 		lines[i] = f.applyKeywordCase(expr.String())
+		comments[i] = &expr.Comments
 	}
 
-	f.writeList(b, pi, lines)
+	f.writeCommentedList(b, pi, lines, comments)
 }
 
 func (f *formatter) formatUpdate(b *strings.Builder, s *sqlast.Update, depth int) {
@@ -1058,37 +1584,19 @@ func (f *formatter) formatUpdate(b *strings.Builder, s *sqlast.Update, depth int
 
 	f.formatWith(b, s.With, depth)
 
-	action := "UPDATE"
+	action := "UPDATE" + hintSuffix(s.Hints)
 	if s.Ignore {
-		action = "UPDATE IGNORE"
+		action += " IGNORE"
 	}
 
 	b.WriteString(p)
 	b.WriteString(action)
 	b.WriteString("\n")
 	f.formatTableExprs(b, s.TableExprs, pi, depth)
-
-	b.WriteString(p)
-	b.WriteString("SET\n")
-
-	lines := make([]string, len(s.Exprs))
-	for i, expr := range s.Exprs {
-		lines[i] = f.applyKeywordCase(expr.String())
-	}
-
-	f.writeList(b, pi, lines)
-
-	if s.Where != nil {
-		b.WriteString(p)
-		b.WriteString("WHERE\n")
-		f.formatWhere(b, s.Where.Expr, pi, depth)
-	}
-
-	f.formatOrderBy(b, s.OrderBy, p, pi, depth)
-
-	if s.Limit != nil {
-		f.formatLimit(b, s.Limit, p, depth)
-	}
+	f.formatSetExprs(b, s.Clauses, "SET", s.Exprs, p, pi)
+	f.formatWhereClause(b, s.Clauses, "WHERE", s.Where, p, depth)
+	f.formatOrderBy(b, s.Clauses, s.OrderBy, p, pi, depth)
+	f.formatLimit(b, s.Clauses, s.Limit, p, depth)
 }
 
 func (f *formatter) formatDelete(b *strings.Builder, s *sqlast.Delete, depth int) {
@@ -1097,9 +1605,9 @@ func (f *formatter) formatDelete(b *strings.Builder, s *sqlast.Delete, depth int
 
 	f.formatWith(b, s.With, depth)
 
-	action := "DELETE"
+	action := "DELETE" + hintSuffix(s.Hints)
 	if s.Ignore {
-		action = "DELETE IGNORE"
+		action += " IGNORE"
 	}
 
 	if len(s.Targets) > 0 {
@@ -1113,9 +1621,7 @@ func (f *formatter) formatDelete(b *strings.Builder, s *sqlast.Delete, depth int
 		}
 
 		f.writeList(b, pi, lines)
-
-		b.WriteString(p)
-		b.WriteString("FROM\n")
+		f.writeClause(b, s.Clauses, "FROM", "FROM", p)
 	} else {
 		b.WriteString(p)
 		b.WriteString(action)
@@ -1123,18 +1629,9 @@ func (f *formatter) formatDelete(b *strings.Builder, s *sqlast.Delete, depth int
 	}
 
 	f.formatTableExprs(b, s.TableExprs, pi, depth)
-
-	if s.Where != nil {
-		b.WriteString(p)
-		b.WriteString("WHERE\n")
-		f.formatWhere(b, s.Where.Expr, pi, depth)
-	}
-
-	f.formatOrderBy(b, s.OrderBy, p, pi, depth)
-
-	if s.Limit != nil {
-		f.formatLimit(b, s.Limit, p, depth)
-	}
+	f.formatWhereClause(b, s.Clauses, "WHERE", s.Where, p, depth)
+	f.formatOrderBy(b, s.Clauses, s.OrderBy, p, pi, depth)
+	f.formatLimit(b, s.Clauses, s.Limit, p, depth)
 }
 
 func (f *formatter) formatUnion(b *strings.Builder, s *sqlast.Union, depth int) {
@@ -1144,26 +1641,33 @@ func (f *formatter) formatUnion(b *strings.Builder, s *sqlast.Union, depth int)
 
 	f.formatStatement(b, s.Left, depth)
 
-	op := "UNION"
+	op := s.Operator.ToString()
 	if !s.Distinct {
-		op = "UNION ALL"
+		op += " ALL"
 	}
 
-	b.WriteString(p)
-	b.WriteString(op)
-	b.WriteString("\n")
+	f.writeClause(b, s.Clauses, s.Operator.ToString(), op, p)
 	f.formatStatement(b, s.Right, depth)
 
 	pi := f.pad(depth + 1)
-	f.formatOrderBy(b, s.OrderBy, p, pi, depth)
-
-	if s.Limit != nil {
-		f.formatLimit(b, s.Limit, p, depth)
-	}
+	f.formatOrderBy(b, s.Clauses, s.OrderBy, p, pi, depth)
+	f.formatLimit(b, s.Clauses, s.Limit, p, depth)
 
 	formatLock(b, s.Lock, s.LockWait, p)
 }
 
+// formatParenSelect formats a parenthesized set operation branch, indenting
+// the query inside the parentheses one level deeper.
+func (f *formatter) formatParenSelect(b *strings.Builder, s *sqlast.ParenSelect, depth int) {
+	p := f.pad(depth)
+
+	b.WriteString(p)
+	b.WriteString("(\n")
+	f.formatStatement(b, s.Select, depth+1)
+	b.WriteString(p)
+	b.WriteString(")\n")
+}
+
 func formatLock(b *strings.Builder, lock sqlast.Lock, wait sqlast.LockWaitType, p string) {
 	if lock == sqlast.NoLock {
 		return
@@ -1179,13 +1683,15 @@ func formatLock(b *strings.Builder, lock sqlast.Lock, wait sqlast.LockWaitType,
 	b.WriteString("\n")
 }
 
-func (f *formatter) formatGroupBy(b *strings.Builder, groupBy *sqlast.GroupBy, p, pi string, depth int) {
+func (f *formatter) formatGroupBy(
+	b *strings.Builder, clauses sqlast.ClauseComments, groupBy *sqlast.GroupBy, p, pi string, depth int,
+) {
 	if groupBy == nil || len(groupBy.Exprs) == 0 {
 		return
 	}
 
-	b.WriteString(p)
-	b.WriteString("GROUP BY\n")
+	f.writeClause(b, clauses, "GROUP BY", "GROUP BY", p)
+	writeComments(b, groupBy.Leading, pi)
 
 	lines := make([]string, len(groupBy.Exprs))
 	for i, expr := range groupBy.Exprs {
@@ -1198,17 +1704,21 @@ func (f *formatter) formatGroupBy(b *strings.Builder, groupBy *sqlast.GroupBy, p
 		b.WriteString(p)
 		b.WriteString("WITH ROLLUP\n")
 	}
+
+	f.appendTrailing(b, groupBy.Trailing, pi)
 }
 
-func (f *formatter) formatOrderBy(b *strings.Builder, orders sqlast.OrderBy, p, pi string, depth int) {
+func (f *formatter) formatOrderBy(
+	b *strings.Builder, clauses sqlast.ClauseComments, orders sqlast.OrderBy, p, pi string, depth int,
+) {
 	if len(orders) == 0 {
 		return
 	}
 
-	b.WriteString(p)
-	b.WriteString("ORDER BY\n")
+	f.writeClause(b, clauses, "ORDER BY", "ORDER BY", p)
 
 	lines := make([]string, len(orders))
+	comments := make([]*sqlast.Comments, len(orders))
 
 	for i, order := range orders {
 		dir := ""
@@ -1217,32 +1727,33 @@ func (f *formatter) formatOrderBy(b *strings.Builder, orders sqlast.OrderBy, p,
 		}
 
 		lines[i] = f.formatExpr(order.Expr, depth) + dir
+		comments[i] = &order.Comments
 	}
 
-	f.writeList(b, pi, lines)
+	f.writeCommentedList(b, pi, lines, comments)
 }
 
-func (f *formatter) formatLimit(b *strings.Builder, limit *sqlast.Limit, p string, depth int) {
+// formatLimit formats a LIMIT clause, if limit isn't nil, with its comments
+// around its values.
+func (f *formatter) formatLimit(
+	b *strings.Builder, clauses sqlast.ClauseComments, limit *sqlast.Limit, p string, depth int,
+) {
+	if limit == nil {
+		return
+	}
+
 	pi := f.pad(depth + 1)
 
+	f.writeClause(b, clauses, "LIMIT", "LIMIT", p)
+	writeComments(b, limit.Leading, pi)
+	b.WriteString(pi + f.formatExpr(limit.Rowcount, depth) + "\n")
+
 	if limit.Offset != nil {
-		b.WriteString(p)
-		b.WriteString("LIMIT\n")
-		b.WriteString(pi)
-		b.WriteString(f.formatExpr(limit.Rowcount, depth))
-		b.WriteString("\n")
-		b.WriteString(p)
-		b.WriteString("OFFSET\n")
-		b.WriteString(pi)
-		b.WriteString(f.formatExpr(limit.Offset, depth))
-		b.WriteString("\n")
-	} else {
-		b.WriteString(p)
-		b.WriteString("LIMIT\n")
-		b.WriteString(pi)
-		b.WriteString(f.formatExpr(limit.Rowcount, depth))
-		b.WriteString("\n")
+		b.WriteString(p + "OFFSET\n")
+		b.WriteString(pi + f.formatExpr(limit.Offset, depth) + "\n")
 	}
+
+	f.appendTrailing(b, limit.Trailing, pi)
 }
 
 // formatCreateTable renders a CREATE TABLE statement, exploding its column
@@ -1269,11 +1780,14 @@ func (f *formatter) formatCreateTable(b *strings.Builder, s *sqlast.CreateTable,
 	// and the regex-based lowering can't tell those apart from a genuine
 	// operator/predicate keyword occurring at the same position.
 	lines := make([]string, len(s.Elements))
+	comments := make([]*sqlast.Comments, len(s.Elements))
+
 	for i, e := range s.Elements {
 		lines[i] = e.String()
+		comments[i] = e.NodeComments()
 	}
 
-	f.writeList(b, pi, lines)
+	f.writeCommentedList(b, pi, lines, comments)
 
 	b.WriteString(p)
 	b.WriteString(")")
@@ -1318,8 +1832,11 @@ func (f *formatter) formatCreateIndex(b *strings.Builder, s *sqlast.CreateIndex,
 	b.WriteString(p)
 	b.WriteString("CREATE ")
 
-	if s.Unique {
+	switch {
+	case s.Unique:
 		b.WriteString("UNIQUE ")
+	case s.Fulltext:
+		b.WriteString("FULLTEXT ")
 	}
 
 	b.WriteString("INDEX ")
@@ -1341,7 +1858,14 @@ func (f *formatter) formatCreateIndex(b *strings.Builder, s *sqlast.CreateIndex,
 	f.writeList(b, pi, lines)
 
 	b.WriteString(p)
-	b.WriteString(")\n")
+	b.WriteString(")")
+
+	if s.Parser != "" {
+		b.WriteString(" WITH PARSER ")
+		b.WriteString(s.Parser)
+	}
+
+	b.WriteString("\n")
 }
 
 func (f *formatter) formatDropIndex(b *strings.Builder, s *sqlast.DropIndex, depth int) {
@@ -1376,3 +1900,62 @@ func (f *formatter) formatTruncateTable(b *strings.Builder, s *sqlast.TruncateTa
 	b.WriteString(s.Table.String())
 	b.WriteString("\n")
 }
+
+func (f *formatter) formatCreateView(b *strings.Builder, s *sqlast.CreateView, depth int) {
+	prefix := "CREATE "
+	if s.OrReplace {
+		prefix = "CREATE OR REPLACE "
+	}
+
+	f.formatViewDefinition(b, prefix, &s.ViewDefinition, depth)
+}
+
+// formatViewDefinition renders a CREATE VIEW or ALTER VIEW statement: the
+// header, keyword prefix included, up to AS on one line, then the view body
+// formatted like any other query one level deeper, then the check option
+// back at depth. Like the other DDL headers, the header isn't passed through
+// applyKeywordCase; the body is, as a regular query.
+func (f *formatter) formatViewDefinition(b *strings.Builder, prefix string, d *sqlast.ViewDefinition, depth int) {
+	p := f.pad(depth)
+
+	b.WriteString(p)
+	b.WriteString(prefix)
+	b.WriteString(d.Options())
+	b.WriteString("VIEW ")
+	b.WriteString(d.View.String())
+
+	if len(d.Columns) > 0 {
+		b.WriteString(" (" + d.Columns.String() + ")")
+	}
+
+	b.WriteString(" AS\n")
+	f.formatStatement(b, d.Select, depth+1)
+
+	if check := d.CheckOption.String(); check != "" {
+		b.WriteString(p)
+		b.WriteString(check)
+		b.WriteString("\n")
+	}
+}
+
+func (f *formatter) formatDropView(b *strings.Builder, s *sqlast.DropView, depth int) {
+	b.WriteString(f.pad(depth))
+	b.WriteString("DROP VIEW ")
+
+	if s.IfExists {
+		b.WriteString("IF EXISTS ")
+	}
+
+	names := make([]string, len(s.Views))
+	for i, v := range s.Views {
+		names[i] = v.String()
+	}
+
+	b.WriteString(strings.Join(names, ", "))
+
+	if s.Behavior != sqlast.NoDropBehavior {
+		b.WriteString(" " + s.Behavior.String())
+	}
+
+	b.WriteString("\n")
+}
@@ -116,6 +116,18 @@ func TestFormatSQL_CreateIndex(t *testing.T) {
 	)
 }
 
+func TestFormatSQL_CreateFulltextIndex(t *testing.T) {
+	assertFormatSQL(t,
+		"create fulltext index ft_body on posts (title, body) with parser ngram",
+		join(
+			"CREATE FULLTEXT INDEX ft_body ON posts (",
+			"  title,",
+			"  body",
+			") WITH PARSER ngram",
+		),
+	)
+}
+
 func TestFormatSQL_DropIndex(t *testing.T) {
 	assertFormatSQL(t, "drop index idx_email on users", "DROP INDEX idx_email ON users")
 }
@@ -129,6 +141,77 @@ func TestFormatSQL_TruncateTable(t *testing.T) {
 	assertFormatSQL(t, "truncate users", "TRUNCATE TABLE users")
 }
 
+func TestFormatSQL_CreateView(t *testing.T) {
+	assertFormatSQL(t,
+		"create or replace algorithm=merge definer='app'@'%' sql security invoker view active_users (id, name) "+
+			"as select id, name from users where active = 1 with cascaded check option",
+		join(
+			"CREATE OR REPLACE ALGORITHM = MERGE DEFINER = 'app'@'%' SQL SECURITY INVOKER VIEW active_users (id, name) AS",
+			"  SELECT",
+			"    id,",
+			"    name",
+			"  FROM",
+			"    users",
+			"  WHERE",
+			"    active = 1",
+			"WITH CASCADED CHECK OPTION",
+		),
+	)
+
+	assertFormatSQL(t, "create view v as select a from t union all select b from u",
+		join(
+			"CREATE VIEW v AS",
+			"  SELECT",
+			"    a",
+			"  FROM",
+			"    t",
+			"  UNION ALL",
+			"  SELECT",
+			"    b",
+			"  FROM",
+			"    u",
+		),
+	)
+
+	assertFormatSQL(t, "create view v as select a, count(*) from t group by a with rollup",
+		join(
+			"CREATE VIEW v AS",
+			"  SELECT",
+			"    a,",
+			"    COUNT(*)",
+			"  FROM",
+			"    t",
+			"  GROUP BY",
+			"    a",
+			"  WITH ROLLUP",
+		),
+	)
+}
+
+func TestFormatSQL_AlterView(t *testing.T) {
+	assertFormatSQL(t, "alter definer = current_user view v as with c as (select 1 as n) select n from c with check option",
+		join(
+			"ALTER DEFINER = CURRENT_USER VIEW v AS",
+			"  WITH",
+			"    c AS (",
+			"      SELECT",
+			"        1 AS n",
+			"    )",
+			"  SELECT",
+			"    n",
+			"  FROM",
+			"    c",
+			"WITH CHECK OPTION",
+		),
+	)
+}
+
+func TestFormatSQL_DropView(t *testing.T) {
+	assertFormatSQL(t, "drop view if exists v, db.w", "DROP VIEW IF EXISTS v, db.w")
+	assertFormatSQL(t, "drop view v1 cascade", "DROP VIEW v1 CASCADE")
+	assertFormatSQL(t, "drop view if exists v1, v2 restrict", "DROP VIEW IF EXISTS v1, v2 RESTRICT")
+}
+
 func TestFormatSQL_CreateTable_ParseFailureFallsThrough(t *testing.T) {
 	in := "create table users (id int, primary key)"
 
@@ -0,0 +1,93 @@
+package sqlfmt_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
+)
+
+func TestFormatSQL_Call(t *testing.T) {
+	assertFormatSQL(t, "call refresh_stats(?, ?)", "CALL refresh_stats(?, ?)")
+	assertFormatSQL(t, "call app.refresh", "CALL app.refresh")
+	assertFormatSQL(t, "call refresh()", "CALL refresh()")
+	assertFormatSQL(t, "call p((select max(id) from t), 'x')",
+		join(
+			"CALL p((",
+			"  SELECT",
+			"    MAX(id)",
+			"  FROM",
+			"    t",
+			"), 'x')",
+		),
+	)
+}
+
+func TestFormatSQL_Prepare(t *testing.T) {
+	assertFormatSQL(t, "prepare stmt from 'select id, name from users where id = ? and name = ''bob'''",
+		join(
+			"PREPARE stmt FROM 'SELECT",
+			"  id,",
+			"  name",
+			"FROM",
+			"  users",
+			"WHERE",
+			"  id = ?",
+			"  AND name = ''bob'''",
+		),
+	)
+
+	assertFormatSQL(t, "prepare stmt from \"select `order` from t -- all\"",
+		join(
+			"PREPARE stmt FROM \"SELECT",
+			"  `order`",
+			"FROM",
+			"  t -- all\"",
+		),
+	)
+
+	assertFormatSQL(t, `prepare stmt from 'select a from t where b like ''x\\%'''`,
+		join(
+			"PREPARE stmt FROM 'SELECT",
+			"  a",
+			"FROM",
+			"  t",
+			"WHERE",
+			`  b LIKE ''x\\%'''`,
+		),
+	)
+}
+
+func TestFormatSQL_Prepare_unparsedText(t *testing.T) {
+	assertFormatSQL(t, "prepare stmt from 'select 1; select 2'", "PREPARE stmt FROM 'select 1; select 2'")
+	assertFormatSQL(t, "prepare stmt from @sql", "PREPARE stmt FROM @sql")
+}
+
+func TestFormatSQL_Prepare_noBackslashEscapes(t *testing.T) {
+	in := `prepare stmt from 'select ''C:\'' from t'`
+
+	got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, SQLMode: sqlfmt.SQLModeNoBackslashEscapes})
+	if !ok {
+		t.Fatalf("FormatSQLWithOptions(%q) ok = false, want true", in)
+	}
+
+	assertSQL(t, got, join(
+		"PREPARE stmt FROM 'SELECT",
+		`  ''C:\''`,
+		"FROM",
+		"  t'",
+	))
+}
+
+func TestFormatSQL_Execute(t *testing.T) {
+	assertFormatSQL(t, "execute stmt", "EXECUTE stmt")
+	assertFormatSQL(t, "execute stmt using @a, @b", "EXECUTE stmt USING @a, @b")
+}
+
+func TestFormatSQL_DeallocatePrepare(t *testing.T) {
+	assertFormatSQL(t, "deallocate prepare stmt", "DEALLOCATE PREPARE stmt")
+	assertFormatSQL(t, "drop prepare stmt", "DROP PREPARE stmt")
+}
+
+func TestFormatSQL_Do(t *testing.T) {
+	assertFormatSQL(t, "do sleep(1), release_lock('x')", "DO sleep(1), release_lock('x')")
+}
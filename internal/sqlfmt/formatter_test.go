@@ -220,6 +220,41 @@ func TestFormatSQL_Subquery(t *testing.T) {
 	}
 }
 
+func TestFormatSQL_QuantifiedSubquery(t *testing.T) {
+	in := "select id from products where price > all (select price from sale_items where sale_id = ?) and id = some (select 1)"
+
+	want := join(
+		"SELECT",
+		"  id",
+		"FROM",
+		"  products",
+		"WHERE",
+		"  price > ALL (",
+		"    SELECT",
+		"      price",
+		"    FROM",
+		"      sale_items",
+		"    WHERE",
+		"      sale_id = ?",
+		"  )",
+		"  AND id = SOME (",
+		"    SELECT",
+		"      1",
+		"  )",
+	)
+
+	assertFormatSQL(t, in, want)
+
+	got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, KeywordCase: sqlfmt.KeywordCaseLower})
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	if !strings.Contains(got, "price > all (") || !strings.Contains(got, "and id = some (") {
+		t.Errorf("expected lowercase quantifiers, got:\n%s", got)
+	}
+}
+
 func TestFormatSQL_Union(t *testing.T) {
 	got, ok := sqlfmt.FormatSQL("select id from users union all select id from admins", 2)
 	if !ok {
@@ -272,6 +307,77 @@ func TestFormatSQL_InsertOnDuplicateKey(t *testing.T) {
 	}
 }
 
+func TestFormatSQL_InsertRowAlias(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("insert into users (name, email) values (?, ?) as new (n, e) "+
+		"on duplicate key update name = new.n, email = new.e", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"INSERT INTO",
+		"  users",
+		"(",
+		"  name,",
+		"  email",
+		")",
+		"VALUES",
+		"  (?, ?)",
+		"AS new (n, e)",
+		"ON DUPLICATE KEY UPDATE",
+		"  name = new.n,",
+		"  email = new.e",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_InsertValuesRowAliasRewrite(t *testing.T) {
+	in := "insert into users (name, email) values (?, ?) on duplicate key update name = values(name), email = values(email)"
+
+	got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, ValuesRowAlias: "new"})
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"INSERT INTO",
+		"  users",
+		"(",
+		"  name,",
+		"  email",
+		")",
+		"VALUES",
+		"  (?, ?)",
+		"AS new",
+		"ON DUPLICATE KEY UPDATE",
+		"  name = new.name,",
+		"  email = new.email",
+	)
+
+	assertSQL(t, got, want)
+
+	if _, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, ValuesRowAlias: "values"}); ok {
+		t.Error("expected a keyword row alias to fail formatting")
+	}
+}
+
+func TestFormatSQL_InsertTable(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("INSERT INTO archive TABLE users", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"INSERT INTO",
+		"  archive",
+		"TABLE",
+		"  users",
+	)
+
+	assertSQL(t, got, want)
+}
+
 func TestFormatSQL_DerivedTable(t *testing.T) {
 	got, ok := sqlfmt.FormatSQL("select t.id from (select id from users) t", 2)
 	if !ok {
@@ -805,6 +911,69 @@ func TestFormatSQL_WindowFunction(t *testing.T) {
 	}
 }
 
+func TestFormatSQL_WindowClause(t *testing.T) {
+	const in = "SELECT SUM(amount) OVER w, RANK() OVER (w2 ROWS UNBOUNDED PRECEDING) FROM orders " +
+		"WINDOW w AS (PARTITION BY user_id ORDER BY created_at DESC), w2 AS (w), w3 AS () ORDER BY user_id"
+
+	t.Run("trailing commas", func(t *testing.T) {
+		got, ok := sqlfmt.FormatSQL(in, 2)
+		if !ok {
+			t.Fatal("expected ok")
+		}
+
+		assertSQL(t, got, join(
+			"SELECT",
+			"  SUM(amount) OVER w,",
+			"  RANK() OVER (",
+			"    w2",
+			"    ROWS UNBOUNDED PRECEDING",
+			"  )",
+			"FROM",
+			"  orders",
+			"WINDOW",
+			"  w AS (",
+			"    PARTITION BY user_id",
+			"    ORDER BY created_at DESC",
+			"  ),",
+			"  w2 AS (",
+			"    w",
+			"  ),",
+			"  w3 AS ()",
+			"ORDER BY",
+			"  user_id",
+		))
+	})
+
+	t.Run("leading commas", func(t *testing.T) {
+		got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, CommaStyle: sqlfmt.CommaStyleLeading})
+		if !ok {
+			t.Fatal("expected ok")
+		}
+
+		assertSQL(t, got, join(
+			"SELECT",
+			"  SUM(amount) OVER w",
+			", RANK() OVER (",
+			"    w2",
+			"    ROWS UNBOUNDED PRECEDING",
+			"  )",
+			"FROM",
+			"  orders",
+			"WINDOW",
+			"  w AS (",
+			"    PARTITION BY user_id",
+			"    ORDER BY created_at DESC",
+			"  )",
+			", w2 AS (",
+			"    w",
+			"  )",
+			", w3 AS ()",
+			"ORDER BY",
+			"  user_id",
+		))
+	})
+}
+
 func TestFormatSQL_WindowFunction_AllTypes(t *testing.T) {
 	// Tests that all aggregate/window function types with OVER clause are formatted correctly.
 	// Each entry: SQL function call -> expected uppercase output in formatted result.
@@ -837,6 +1006,7 @@ func TestFormatSQL_WindowFunction_AllTypes(t *testing.T) {
 		{"NTH_VALUE", "SELECT NTH_VALUE(x, 2) OVER (ORDER BY id) FROM t", "NTH_VALUE(x, 2)"},
 		{"LAG", "SELECT LAG(x) OVER (ORDER BY id) FROM t", "LAG(x)"},
 		{"LEAD", "SELECT LEAD(x) OVER (ORDER BY id) FROM t", "LEAD(x)"},
+		{"GROUP_CONCAT", "SELECT GROUP_CONCAT(x SEPARATOR ';') OVER (ORDER BY id) FROM t", "GROUP_CONCAT(x SEPARATOR ';')"},
 		{"JSON_ARRAYAGG", "SELECT JSON_ARRAYAGG(x) OVER (ORDER BY id) FROM t", "JSON_ARRAYAGG(x)"},
 		{"JSON_OBJECTAGG", "SELECT JSON_OBJECTAGG(k, v) OVER (ORDER BY id) FROM t", "JSON_OBJECTAGG(k, v)"},
 	}
@@ -861,6 +1031,36 @@ func TestFormatSQL_WindowFunction_AllTypes(t *testing.T) {
 	}
 }
 
+func TestFormatSQL_GroupConcat(t *testing.T) {
+	assertFormatSQL(t,
+		"select dept, group_concat(distinct name order by name desc separator ', ') from emp group by dept",
+		join(
+			"SELECT",
+			"  dept,",
+			"  GROUP_CONCAT(DISTINCT name ORDER BY name DESC SEPARATOR ', ')",
+			"FROM",
+			"  emp",
+			"GROUP BY",
+			"  dept",
+		),
+	)
+}
+
+func TestFormatSQL_JSONArrayAggOrderBy(t *testing.T) {
+	assertFormatSQL(t,
+		"select dept, json_arrayagg(name order by name) from emp group by dept",
+		join(
+			"SELECT",
+			"  dept,",
+			"  JSON_ARRAYAGG(name ORDER BY name)",
+			"FROM",
+			"  emp",
+			"GROUP BY",
+			"  dept",
+		),
+	)
+}
+
 func TestFormatSQL_IndexHintForType(t *testing.T) {
 	got, ok := sqlfmt.FormatSQL("SELECT id FROM users USE INDEX FOR ORDER BY (idx_name) WHERE id = ?", 2)
 	if !ok {
@@ -1131,128 +1331,635 @@ func TestFormatSQL_SelectDistinct(t *testing.T) {
 	assertSQL(t, got, want)
 }
 
-func TestFormatSQL_MultiColumnGroupBy(t *testing.T) {
-	got, ok := sqlfmt.FormatSQL("SELECT status, dept FROM users GROUP BY status, dept", 2)
+func TestFormatSQL_SelectModifiers(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("select sql_calc_found_rows distinct high_priority id from users limit 10", 2)
 	if !ok {
 		t.Fatal("expected ok")
 	}
 
 	want := join(
-		"SELECT",
-		"  status,",
-		"  dept",
+		"SELECT DISTINCT HIGH_PRIORITY SQL_CALC_FOUND_ROWS",
+		"  id",
 		"FROM",
 		"  users",
-		"GROUP BY",
-		"  status,",
-		"  dept",
+		"LIMIT",
+		"  10",
 	)
 
 	assertSQL(t, got, want)
 }
 
-func TestFormatSQL_MultiColumnOrderBy(t *testing.T) {
-	got, ok := sqlfmt.FormatSQL("SELECT id FROM users ORDER BY status, id DESC", 2)
-	if !ok {
-		t.Fatal("expected ok")
+func TestFormatSQL_OptimizerHints(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "select",
+			in:   "select /*+ max_execution_time(1000) index(u idx_name) */ distinct id from users u",
+			want: join(
+				"SELECT /*+ MAX_EXECUTION_TIME(1000) INDEX(u idx_name) */ DISTINCT",
+				"  id",
+				"FROM",
+				"  users u",
+			),
+		},
+		{
+			name: "subquery keeps its own hints",
+			in:   "SELECT id FROM users WHERE id IN (SELECT /*+ QB_NAME(sub) */ user_id FROM orders)",
+			want: join(
+				"SELECT",
+				"  id",
+				"FROM",
+				"  users",
+				"WHERE",
+				"  id IN (",
+				"    SELECT /*+ QB_NAME(sub) */",
+				"      user_id",
+				"    FROM",
+				"      orders",
+				"  )",
+			),
+		},
+		{
+			name: "insert",
+			in:   "insert /*+ SET_VAR(foreign_key_checks=OFF) */ ignore into users (id) values (?)",
+			want: join(
+				"INSERT /*+ SET_VAR(foreign_key_checks = OFF) */ IGNORE INTO",
+				"  users",
+				"(",
+				"  id",
+				")",
+				"VALUES",
+				"  (?)",
+			),
+		},
+		{
+			name: "update",
+			in:   "UPDATE /*+ NO_MERGE(u) */ users u SET name = ?",
+			want: join(
+				"UPDATE /*+ NO_MERGE(u) */",
+				"  users u",
+				"SET",
+				"  name = ?",
+			),
+		},
+		{
+			name: "delete",
+			in:   "DELETE /*+ BKA(users) */ FROM users WHERE id = ?",
+			want: join(
+				"DELETE /*+ BKA(users) */ FROM",
+				"  users",
+				"WHERE",
+				"  id = ?",
+			),
+		},
+		{
+			name: "executable comment is kept verbatim",
+			in:   "select /*!40001 sql_no_cache */ id from users",
+			want: join(
+				"SELECT /*!40001 sql_no_cache */",
+				"  id",
+				"FROM",
+				"  users",
+			),
+		},
 	}
 
-	want := join(
-		"SELECT",
-		"  id",
-		"FROM",
-		"  users",
-		"ORDER BY",
-		"  status,",
-		"  id DESC",
-	)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQL(tt.in, 2)
+			if !ok {
+				t.Fatal("expected ok")
+			}
 
-	assertSQL(t, got, want)
+			assertSQL(t, got, tt.want)
+		})
+	}
 }
 
-func TestFormatSQL_InsertMultiRowValues(t *testing.T) {
-	got, ok := sqlfmt.FormatSQL("INSERT INTO t (a) VALUES (1), (2)", 2)
-	if !ok {
-		t.Fatal("expected ok")
+func TestFormatSQL_ExecutableCommentsKeepTheirPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "whole statement",
+			in:   "/*!40101 SET NAMES utf8 */;",
+			want: "/*!40101 SET NAMES utf8 */",
+		},
+		{
+			name: "after an operand",
+			in:   "select 1 /*! + 1 */",
+			want: join("SELECT", "  1 /*! + 1 */"),
+		},
+		{
+			name: "after a select list item and a condition operand",
+			in:   "select a /*!40001 , b */ from t where x = 1 and y /*!50700 OR z */ = 2",
+			want: join(
+				"SELECT",
+				"  a /*!40001 , b */",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  x = 1",
+				"  and y /*!50700 OR z */ = 2",
+			),
+		},
+		{
+			name: "after a subquery",
+			in:   "SELECT (SELECT 1) /*!1*/ FROM t",
+			want: join(
+				"SELECT",
+				"  (",
+				"    SELECT",
+				"      1",
+				"  ) /*!1*/",
+				"FROM",
+				"  t",
+			),
+		},
 	}
 
-	want := join(
-		"INSERT INTO",
-		"  t",
-		"(",
-		"  a",
-		")",
-		"VALUES",
-		"  (1),",
-		"  (2)",
-	)
-
-	assertSQL(t, got, want)
-}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLWithOptions(tt.in, sqlfmt.Options{Indent: 2, KeywordCase: sqlfmt.KeywordCaseLower})
+			if !ok {
+				t.Fatal("expected ok")
+			}
 
-func TestFormatSQL_UpdateOrderByLimit(t *testing.T) {
-	got, ok := sqlfmt.FormatSQL("UPDATE users SET name = ? WHERE id = ? ORDER BY id LIMIT 1", 2)
-	if !ok {
-		t.Fatal("expected ok")
+			assertSQL(t, got, tt.want)
+		})
 	}
+}
 
-	want := join(
-		"UPDATE",
-		"  users",
-		"SET",
-		"  name = ?",
-		"WHERE",
-		"  id = ?",
-		"ORDER BY",
-		"  id",
-		"LIMIT",
-		"  1",
+func TestFormatSQL_UnknownOptimizerHintIsKept(t *testing.T) {
+	assertFormatSQL(t,
+		"select /*+ UNKNOWN_HINT(x y z) bka(t) */ id from t",
+		join(
+			"SELECT /*+ UNKNOWN_HINT(x y z) BKA(t) */",
+			"  id",
+			"FROM",
+			"  t",
+		),
 	)
-
-	assertSQL(t, got, want)
 }
 
-func TestFormatSQL_DeleteOrderByLimit(t *testing.T) {
-	got, ok := sqlfmt.FormatSQL("DELETE FROM users WHERE id > ? ORDER BY id LIMIT 1", 2)
-	if !ok {
-		t.Fatal("expected ok")
-	}
-
-	want := join(
-		"DELETE FROM",
-		"  users",
-		"WHERE",
-		"  id > ?",
-		"ORDER BY",
-		"  id",
-		"LIMIT",
-		"  1",
-	)
+func TestFormatSQL_MisplacedHintIsUnchanged(t *testing.T) {
+	in := "SELECT id /*+ BKA(t) */ FROM t"
 
-	assertSQL(t, got, want)
+	got, ok := sqlfmt.FormatSQL(in, 2)
+	if ok || got != in {
+		t.Errorf("FormatSQL(%q) = %q, %v; want input unchanged, false", in, got, ok)
+	}
 }
 
-func TestFormatSQL_UnionLimit(t *testing.T) {
-	got, ok := sqlfmt.FormatSQL("SELECT id FROM a UNION SELECT id FROM b LIMIT 5", 2)
+func TestFormatSQL_SelectInto(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "variables before FROM",
+			in:   "SELECT id, name INTO @id, @name FROM users WHERE id = ?",
+			want: join(
+				"SELECT",
+				"  id,",
+				"  name",
+				"INTO",
+				"  @id,",
+				"  @name",
+				"FROM",
+				"  users",
+				"WHERE",
+				"  id = ?",
+			),
+		},
+		{
+			name: "before lock",
+			in:   "SELECT id FROM users LIMIT 1 INTO @id FOR UPDATE",
+			want: join(
+				"SELECT",
+				"  id",
+				"FROM",
+				"  users",
+				"LIMIT",
+				"  1",
+				"INTO",
+				"  @id",
+				"FOR UPDATE",
+			),
+		},
+		{
+			name: "after lock",
+			in:   "SELECT id FROM users FOR UPDATE INTO @id",
+			want: join(
+				"SELECT",
+				"  id",
+				"FROM",
+				"  users",
+				"FOR UPDATE",
+				"INTO",
+				"  @id",
+			),
+		},
+		{
+			name: "outfile with options",
+			in: "SELECT * FROM users INTO OUTFILE '/tmp/users.csv' CHARACTER SET utf8mb4 " +
+				"FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES TERMINATED BY '\\n'",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  users",
+				"INTO OUTFILE '/tmp/users.csv'",
+				"  CHARACTER SET utf8mb4",
+				"  FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"'",
+				"  LINES TERMINATED BY '\\n'",
+			),
+		},
+		{
+			name: "dumpfile",
+			in:   "SELECT doc INTO DUMPFILE '/tmp/doc' FROM docs",
+			want: join(
+				"SELECT",
+				"  doc",
+				"INTO DUMPFILE '/tmp/doc'",
+				"FROM",
+				"  docs",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQL(tt.in, 2)
+			if !ok {
+				t.Fatal("expected ok")
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
+
+func TestFormatSQL_MultiColumnGroupBy(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("SELECT status, dept FROM users GROUP BY status, dept", 2)
 	if !ok {
 		t.Fatal("expected ok")
 	}
 
-	want := join(
-		"SELECT",
-		"  id",
-		"FROM",
-		"  a",
-		"UNION",
-		"SELECT",
-		"  id",
-		"FROM",
-		"  b",
-		"LIMIT",
-		"  5",
-	)
+	want := join(
+		"SELECT",
+		"  status,",
+		"  dept",
+		"FROM",
+		"  users",
+		"GROUP BY",
+		"  status,",
+		"  dept",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_MultiColumnOrderBy(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("SELECT id FROM users ORDER BY status, id DESC", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"SELECT",
+		"  id",
+		"FROM",
+		"  users",
+		"ORDER BY",
+		"  status,",
+		"  id DESC",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_InsertMultiRowValues(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("INSERT INTO t (a) VALUES (1), (2)", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"INSERT INTO",
+		"  t",
+		"(",
+		"  a",
+		")",
+		"VALUES",
+		"  (1),",
+		"  (2)",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_UpdateOrderByLimit(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("UPDATE users SET name = ? WHERE id = ? ORDER BY id LIMIT 1", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"UPDATE",
+		"  users",
+		"SET",
+		"  name = ?",
+		"WHERE",
+		"  id = ?",
+		"ORDER BY",
+		"  id",
+		"LIMIT",
+		"  1",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_DeleteOrderByLimit(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("DELETE FROM users WHERE id > ? ORDER BY id LIMIT 1", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"DELETE FROM",
+		"  users",
+		"WHERE",
+		"  id > ?",
+		"ORDER BY",
+		"  id",
+		"LIMIT",
+		"  1",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_UnionLimit(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("SELECT id FROM a UNION SELECT id FROM b LIMIT 5", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"SELECT",
+		"  id",
+		"FROM",
+		"  a",
+		"UNION",
+		"SELECT",
+		"  id",
+		"FROM",
+		"  b",
+		"LIMIT",
+		"  5",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_IntersectExcept(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL("select id from a intersect select id from b except all select id from c", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"SELECT",
+		"  id",
+		"FROM",
+		"  a",
+		"INTERSECT",
+		"SELECT",
+		"  id",
+		"FROM",
+		"  b",
+		"EXCEPT ALL",
+		"SELECT",
+		"  id",
+		"FROM",
+		"  c",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_ParenthesizedUnionBranches(t *testing.T) {
+	got, ok := sqlfmt.FormatSQL(
+		"(select id from a order by id limit 1) union all ((select id from b) intersect (select id from c)) limit 5", 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"(",
+		"  SELECT",
+		"    id",
+		"  FROM",
+		"    a",
+		"  ORDER BY",
+		"    id",
+		"  LIMIT",
+		"    1",
+		")",
+		"UNION ALL",
+		"(",
+		"  (",
+		"    SELECT",
+		"      id",
+		"    FROM",
+		"      b",
+		"  )",
+		"  INTERSECT",
+		"  (",
+		"    SELECT",
+		"      id",
+		"    FROM",
+		"      c",
+		"  )",
+		")",
+		"LIMIT",
+		"  5",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_ParenthesizedSetOperationSubqueries(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "derived table",
+			in:   "select * from ((select 1) union (select 2)) as d",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  (",
+				"  (",
+				"    SELECT",
+				"      1",
+				"  )",
+				"  UNION",
+				"  (",
+				"    SELECT",
+				"      2",
+				"  )",
+				"  ) d",
+			),
+		},
+		{
+			name: "in subquery",
+			in:   "select a from t where a in ((select 1) union (select 2))",
+			want: join(
+				"SELECT",
+				"  a",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  a IN (",
+				"    (",
+				"      SELECT",
+				"        1",
+				"    )",
+				"    UNION",
+				"    (",
+				"      SELECT",
+				"        2",
+				"    )",
+				"  )",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQL(tt.in, 2)
+			if !ok {
+				t.Fatal("expected ok")
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
+
+func TestFormatSQL_TableValueConstructors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "values statement",
+			in:   "values row(1, 'a'), row(2, 'b') order by column_0 limit 1",
+			want: join(
+				"VALUES",
+				"  ROW(1, 'a'),",
+				"  ROW(2, 'b')",
+				"ORDER BY",
+				"  column_0",
+				"LIMIT",
+				"  1",
+			),
+		},
+		{
+			name: "insert values row",
+			in:   "insert into t (a, b) values row(1, 2), row(3, 4)",
+			want: join(
+				"INSERT INTO",
+				"  t",
+				"(",
+				"  a,",
+				"  b",
+				")",
+				"VALUES",
+				"  ROW(1, 2),",
+				"  ROW(3, 4)",
+			),
+		},
+		{
+			name: "values derived table",
+			in:   "select * from (values row(1), row(2)) v",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  (",
+				"  VALUES",
+				"    ROW(1),",
+				"    ROW(2)",
+				"  ) v",
+			),
+		},
+		{
+			name: "values derived table column list",
+			in:   "select a, b from (values row(1, 2), row(3, 4)) as v (a, b)",
+			want: join(
+				"SELECT",
+				"  a,",
+				"  b",
+				"FROM",
+				"  (",
+				"  VALUES",
+				"    ROW(1, 2),",
+				"    ROW(3, 4)",
+				"  ) v (a, b)",
+			),
+		},
+		{
+			name: "table statement",
+			in:   "table users order by id limit 10",
+			want: join(
+				"TABLE",
+				"  users",
+				"ORDER BY",
+				"  id",
+				"LIMIT",
+				"  10",
+			),
+		},
+		{
+			name: "lateral derived table",
+			in:   "select * from t1, lateral (select t1.a) d",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  t1,",
+				"  LATERAL (",
+				"  SELECT",
+				"    t1.a",
+				"  ) d",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQL(tt.in, 2)
+			if !ok {
+				t.Fatal("expected ok")
+			}
 
-	assertSQL(t, got, want)
+			assertSQL(t, got, tt.want)
+		})
+	}
 }
 
 func TestFormatSQL_Replace(t *testing.T) {
@@ -1350,21 +2057,230 @@ func TestFormatSQL_WindowEmptyOver(t *testing.T) {
 	assertSQL(t, got, want)
 }
 
-// JSON_TABLE is outside the in-house parser's scope (see docs/parser-spec.md);
-// FormatSQL falls back to returning the input unchanged.
+func TestFormatSQL_IntervalAndTemporalLiterals(t *testing.T) {
+	in := "select sum(n) over (order by d range between interval 1 day preceding and current row) from t " +
+		"where created_at > now() - interval 7 day and d >= date '2024-01-02' and t < {ts '2024-01-02 00:00:00'}"
+
+	got, ok := sqlfmt.FormatSQL(in, 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"SELECT",
+		"  SUM(n) OVER (",
+		"    ORDER BY d",
+		"    RANGE BETWEEN INTERVAL 1 DAY PRECEDING AND CURRENT ROW",
+		"  )",
+		"FROM",
+		"  t",
+		"WHERE",
+		"  created_at > now() - INTERVAL 7 DAY",
+		"  AND d >= DATE '2024-01-02'",
+		"  AND t < {ts '2024-01-02 00:00:00'}",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQL_CollateAndIntroducers(t *testing.T) {
+	in := "select _utf8mb4'abc', n'def' from users where name collate utf8mb4_bin = ? and code = _latin1 'x' collate latin1_bin"
+
+	tests := []struct {
+		name        string
+		keywordCase string
+		want        string
+	}{
+		{
+			name:        "upper",
+			keywordCase: sqlfmt.KeywordCaseUpper,
+			want: join(
+				"SELECT",
+				"  _utf8mb4'abc',",
+				"  n'def'",
+				"FROM",
+				"  users",
+				"WHERE",
+				"  name COLLATE utf8mb4_bin = ?",
+				"  AND code = _latin1'x' COLLATE latin1_bin",
+			),
+		},
+		{
+			name:        "lower",
+			keywordCase: sqlfmt.KeywordCaseLower,
+			want: join(
+				"SELECT",
+				"  _utf8mb4'abc',",
+				"  n'def'",
+				"FROM",
+				"  users",
+				"WHERE",
+				"  name collate utf8mb4_bin = ?",
+				"  and code = _latin1'x' collate latin1_bin",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, KeywordCase: tt.keywordCase})
+			if !ok {
+				t.Fatal("expected ok")
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
+
+func TestFormatSQL_MatchAgainst(t *testing.T) {
+	in := "select id from posts where match (title, body) against (? in natural language mode with query expansion)" +
+		" or match (body) against ('+a' in boolean mode)"
+
+	tests := []struct {
+		name        string
+		keywordCase string
+		want        string
+	}{
+		{
+			name:        "upper",
+			keywordCase: sqlfmt.KeywordCaseUpper,
+			want: join(
+				"SELECT",
+				"  id",
+				"FROM",
+				"  posts",
+				"WHERE",
+				"  MATCH (title, body) AGAINST (? IN NATURAL LANGUAGE MODE WITH QUERY EXPANSION)",
+				"  OR MATCH (body) AGAINST ('+a' IN BOOLEAN MODE)",
+			),
+		},
+		{
+			name:        "lower",
+			keywordCase: sqlfmt.KeywordCaseLower,
+			want: join(
+				"SELECT",
+				"  id",
+				"FROM",
+				"  posts",
+				"WHERE",
+				"  match (title, body) against (? in natural language mode with query expansion)",
+				"  or match (body) against ('+a' in boolean mode)",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, KeywordCase: tt.keywordCase})
+			if !ok {
+				t.Fatal("expected ok")
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
+
+func TestFormatSQL_BitwiseAndAssignment(t *testing.T) {
+	in := "select @rank := @rank + 1 as r, a div 2, mod(b, 3) from t where flags & ? <> 0 xor c << 1 > d"
+
+	got, ok := sqlfmt.FormatSQL(in, 2)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"SELECT",
+		"  @rank := @rank + 1 AS r,",
+		"  a DIV 2,",
+		"  mod(b, 3)",
+		"FROM",
+		"  t",
+		"WHERE",
+		"  flags & ? != 0 XOR c << 1 > d",
+	)
+
+	assertSQL(t, got, want)
+}
+
 func TestFormatSQL_JSONTableExpr(t *testing.T) {
-	query := "select id from JSON_TABLE(data, '$[*]' COLUMNS(id INT PATH '$.id')) AS jt"
+	query := "select jt.id, jt.tag from orders o join JSON_TABLE(o.data, '$.items[*]' COLUMNS(rn for ordinality, " +
+		"id INT PATH '$.id' default '0' on empty, nested path '$.tags[*]' columns (tag varchar(20) path '$'))) AS jt on true"
 
-	got, ok := sqlfmt.FormatSQL(query, 2)
-	if ok {
-		t.Fatal("expected parse failure")
+	tests := []struct {
+		name       string
+		commaStyle string
+		want       string
+	}{
+		{
+			name:       "trailing",
+			commaStyle: sqlfmt.CommaStyleTrailing,
+			want: join(
+				"SELECT",
+				"  jt.id,",
+				"  jt.tag",
+				"FROM",
+				"  orders o",
+				"  JOIN",
+				"  JSON_TABLE(o.data, '$.items[*]' COLUMNS (",
+				"    rn FOR ORDINALITY,",
+				"    id INT PATH '$.id' DEFAULT '0' ON EMPTY,",
+				"    NESTED PATH '$.tags[*]' COLUMNS (",
+				"      tag varchar(20) PATH '$'",
+				"    )",
+				"  )) jt",
+				"    ON TRUE",
+			),
+		},
+		{
+			name:       "leading",
+			commaStyle: sqlfmt.CommaStyleLeading,
+			want: join(
+				"SELECT",
+				"  jt.id",
+				", jt.tag",
+				"FROM",
+				"  orders o",
+				"  JOIN",
+				"  JSON_TABLE(o.data, '$.items[*]' COLUMNS (",
+				"    rn FOR ORDINALITY",
+				"  , id INT PATH '$.id' DEFAULT '0' ON EMPTY",
+				"  , NESTED PATH '$.tags[*]' COLUMNS (",
+				"      tag varchar(20) PATH '$'",
+				"    )",
+				"  )) jt",
+				"    ON TRUE",
+			),
+		},
 	}
 
-	if got != query {
-		t.Errorf("got:\n%s\n\nwant original input unchanged:\n%s", got, query)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLWithOptions(query, sqlfmt.Options{Indent: 2, CommaStyle: tt.commaStyle})
+			if !ok {
+				t.Fatal("expected ok")
+			}
+
+			assertSQL(t, got, tt.want)
+		})
 	}
 }
 
+func TestFormatSQL_JSONPathOperators(t *testing.T) {
+	assertFormatSQL(t,
+		"select data->>'$.user.id' as uid from events where data->'$.type' = ?",
+		join(
+			"SELECT",
+			"  data->>'$.user.id' AS uid",
+			"FROM",
+			"  events",
+			"WHERE",
+			"  data->'$.type' = ?",
+		),
+	)
+}
+
 func TestFormatSQLWithOptions_KeywordCase(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1472,6 +2388,86 @@ func TestFormatSQLWithOptions_KeywordCase_ASAndON(t *testing.T) {
 	assertSQL(t, got, want)
 }
 
+func TestFormatSQLWithOptions_KeywordCase_Operators(t *testing.T) {
+	in := "select a DIV 2, b MOD 3, MOD(c, 4) from t where x XOR y"
+
+	got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, KeywordCase: sqlfmt.KeywordCaseLower})
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	want := join(
+		"SELECT",
+		"  a div 2,",
+		"  b mod 3,",
+		"  MOD(c, 4)",
+		"FROM",
+		"  t",
+		"WHERE",
+		"  x xor y",
+	)
+
+	assertSQL(t, got, want)
+}
+
+func TestFormatSQLWithOptions_KeywordCase_Cast(t *testing.T) {
+	in := "SELECT CAST(a AS CHAR(10)), CAST(b AS Unsigned ARRAY), CAST(e AS unsigned integer), " +
+		"CONVERT(f, Signed INT), CONVERT(c USING utf8mb4) FROM t WHERE BINARY d = ?"
+
+	tests := []struct {
+		name        string
+		keywordCase string
+		want        string
+	}{
+		{
+			name:        "upper",
+			keywordCase: sqlfmt.KeywordCaseUpper,
+			want: join(
+				"SELECT",
+				"  CAST(a AS CHAR(10)),",
+				"  CAST(b AS UNSIGNED ARRAY),",
+				"  CAST(e AS UNSIGNED INTEGER),",
+				"  CONVERT(f, SIGNED INT),",
+				"  CONVERT(c USING utf8mb4)",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  BINARY d = ?",
+			),
+		},
+		{
+			// A target type's name is kept as written, like a DDL column
+			// type (see TestFormatSQL_CreateTable_TypeNamePreservesSourceCase),
+			// but SIGNED/UNSIGNED [INTEGER|INT] are keywords.
+			name:        "lower",
+			keywordCase: sqlfmt.KeywordCaseLower,
+			want: join(
+				"SELECT",
+				"  cast(a as CHAR(10)),",
+				"  cast(b as unsigned array),",
+				"  cast(e as unsigned integer),",
+				"  convert(f, signed int),",
+				"  convert(c using utf8mb4)",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  binary d = ?",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLWithOptions(in, sqlfmt.Options{Indent: 2, KeywordCase: tt.keywordCase})
+			if !ok {
+				t.Fatal("expected ok")
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
+
 func TestFormatSQLWithOptions_CommaStyle(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1566,7 +2562,9 @@ func TestFormatSQLWithOptions_CommaStyle_With(t *testing.T) {
 // mode re-encodes with a backslash, mangling it once NO_BACKSLASH_ESCAPES is
 // set) and a literal newline (which the default mode re-encodes as
 // backslash-n, changing a multi-line value into a single-line one under
-// NO_BACKSLASH_ESCAPES).
+// NO_BACKSLASH_ESCAPES), plus the double-quoted token ANSI_QUOTES turns from
+// a string into an identifier, the || PIPES_AS_CONCAT turns from OR into
+// concatenation, and modes combined as a comma-separated list.
 func TestFormatSQLWithOptions_SQLMode(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1624,6 +2622,55 @@ func TestFormatSQLWithOptions_SQLMode(t *testing.T) {
 			want:    `select 'it\'s' from t`,
 			wantOK:  false,
 		},
+		{
+			name:    "default keeps a double-quoted string double-quoted",
+			sqlMode: sqlfmt.SQLModeDefault,
+			in:      `select "order", "it's", "say ""hi""" from t`,
+			want:    join("SELECT", `  "order",`, `  "it's",`, `  "say \"hi\""`, "FROM", "  t"),
+			wantOK:  true,
+		},
+		{
+			name:    "NoBackslashEscapes doubles a double quote in a double-quoted string",
+			sqlMode: sqlfmt.SQLModeNoBackslashEscapes,
+			in:      `select "C:\ ""x""" from t`,
+			want:    join("SELECT", `  "C:\ ""x"""`, "FROM", "  t"),
+			wantOK:  true,
+		},
+		{
+			name:    "ANSIQuotes keeps a double-quoted identifier double-quoted",
+			sqlMode: sqlfmt.SQLModeANSIQuotes,
+			in:      "select \"order\", `t`.id, 'a\\'' from t",
+			want:    join("SELECT", `  "order",`, `  "t".id,`, `  'a\''`, "FROM", "  t"),
+			wantOK:  true,
+		},
+		{
+			name:    "combined modes",
+			sqlMode: "ansi_quotes,no_backslash_escapes",
+			in:      `select "a""b" from t where c = 'it''s'`,
+			want:    join("SELECT", `  "a""b"`, "FROM", "  t", "WHERE", `  c = 'it''s'`),
+			wantOK:  true,
+		},
+		{
+			name:    "default reads || and && as OR and AND",
+			sqlMode: sqlfmt.SQLModeDefault,
+			in:      "select a from t where b = 1 || c = 2 && d = 3",
+			want:    join("SELECT", "  a", "FROM", "  t", "WHERE", "  b = 1", "  OR c = 2 AND d = 3"),
+			wantOK:  true,
+		},
+		{
+			name:    "PipesAsConcat reads || as concatenation",
+			sqlMode: sqlfmt.SQLModePipesAsConcat,
+			in:      "select fname || ' ' || lname from t",
+			want:    join("SELECT", "  fname || ' ' || lname", "FROM", "  t"),
+			wantOK:  true,
+		},
+		{
+			name:    "unknown mode in a list",
+			sqlMode: "ansi_quotes,sideways",
+			in:      "select 1",
+			want:    "select 1",
+			wantOK:  false,
+		},
 	}
 
 	for _, tt := range tests {
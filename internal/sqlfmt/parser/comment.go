@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+// commentMark records where a comment-carrying node starts: the comments
+// leading its first token, and how many unclaimed comments there were
+// before it.
+type commentMark struct {
+	leading []sqlast.Comment
+	pending int
+}
+
+// markComments marks the start of a node at the current token, taking the
+// token's leading comments as the node's own. Every comment of the tokens
+// consumed from here until claimComments is the node's too, except those a
+// node nested inside it claims first.
+func (p *Parser) markComments() commentMark {
+	m := commentMark{leading: p.tok.Leading, pending: len(p.comments)}
+	p.tok.Leading = nil
+
+	return m
+}
+
+// claimComments ends the node marked by m, returning its comments: those
+// leading its first token, and those consumed since that no nested node
+// claimed, as trailing ones.
+func (p *Parser) claimComments(m commentMark) sqlast.Comments {
+	c := sqlast.Comments{Leading: m.leading}
+
+	if len(p.comments) > m.pending {
+		c.Trailing = slices.Clone(p.comments[m.pending:])
+		p.comments = p.comments[:m.pending]
+	}
+
+	return c
+}
+
+// attachComments ends the node marked by m like claimComments, adding its
+// comments to node's: the node may already carry some, claimed by a nested
+// mark on the same node (a table factor that is also a FROM list item).
+func (p *Parser) attachComments(node sqlast.Commented, m commentMark) {
+	claimed := p.claimComments(m)
+	c := node.NodeComments()
+
+	if len(claimed.Leading) > 0 {
+		c.Leading = append(claimed.Leading, c.Leading...)
+	}
+
+	if len(claimed.Trailing) > 0 {
+		c.Trailing = append(c.Trailing, claimed.Trailing...)
+	}
+}
+
+// consumeClause consumes a clause's keywords if the current token is the
+// first of them, recording the comments before and after them in clauses
+// under name. It reports whether it did so.
+func (p *Parser) consumeClause(clauses *sqlast.ClauseComments, name string, keywords ...TokenType) bool {
+	if !p.at(keywords[0]) {
+		return false
+	}
+
+	m := p.markComments()
+
+	for _, kw := range keywords {
+		p.expect(kw)
+	}
+
+	p.claimClause(clauses, name, m)
+
+	return true
+}
+
+// claimClause ends a clause keyword marked by m, recording its comments in
+// clauses under name, if it has any.
+func (p *Parser) claimClause(clauses *sqlast.ClauseComments, name string, m commentMark) {
+	c := p.claimComments(m)
+	if c.IsEmpty() {
+		return
+	}
+
+	if *clauses == nil {
+		*clauses = sqlast.ClauseComments{}
+	}
+
+	(*clauses)[name] = c
+}
+
+// finishStatement ends the top-level statement marked by m: it consumes an
+// optional trailing semicolon, fails unless the input ends there, and gives
+// stmt every comment no nested node claimed, including those after the last
+// token.
+func (p *Parser) finishStatement(stmt sqlast.Statement, m commentMark) {
+	p.consume(SEMICOLON)
+
+	if !p.at(EOF) {
+		p.failf("unexpected token %s after statement", p.tok.Type)
+	}
+
+	p.comments = append(p.comments, p.tok.Leading...)
+	p.attachComments(stmt, m)
+}
@@ -0,0 +1,245 @@
+package parser_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+func lineComment(text string) sqlast.Comment { return sqlast.Comment{Text: text} }
+
+func ownLineComment(text string) sqlast.Comment { return sqlast.Comment{Text: text, OwnLine: true} }
+
+func TestParseSelect_Comments(t *testing.T) {
+	sel, err := parser.ParseSelect("-- head\nSELECT a, -- first\n  b\nFROM t -- table\n" +
+		"WHERE x = 1 -- one\n  AND y = 2 -- two\nORDER BY a -- ord\nLIMIT 1;\n-- tail")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	checks := []struct {
+		name string
+		got  sqlast.Comments
+		want sqlast.Comments
+	}{
+		{
+			"statement",
+			sel.Comments,
+			sqlast.Comments{
+				Leading:  []sqlast.Comment{ownLineComment("-- head")},
+				Trailing: []sqlast.Comment{ownLineComment("-- tail")},
+			},
+		},
+		{"first select item", *sel.SelectExprs[0].NodeComments(), sqlast.Comments{Trailing: []sqlast.Comment{lineComment("-- first")}}},
+		{"second select item", *sel.SelectExprs[1].NodeComments(), sqlast.Comments{}},
+		{"table", *sel.From[0].NodeComments(), sqlast.Comments{Trailing: []sqlast.Comment{lineComment("-- table")}}},
+		{"where", sel.Where.Comments, sqlast.Comments{Trailing: []sqlast.Comment{lineComment("-- two")}}},
+		{"and", sel.Where.Expr.(*sqlast.AndExpr).Comments, sqlast.Comments{Leading: []sqlast.Comment{lineComment("-- one")}}},
+		{"order item", sel.OrderBy[0].Comments, sqlast.Comments{Trailing: []sqlast.Comment{lineComment("-- ord")}}},
+		{"limit", sel.Limit.Comments, sqlast.Comments{}},
+	}
+
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s comments = %+v, want %+v", c.name, c.got, c.want)
+		}
+	}
+
+	if sel.Clauses != nil {
+		t.Errorf("Clauses = %+v, want nil", sel.Clauses)
+	}
+}
+
+func TestParseSelect_ClauseComments(t *testing.T) {
+	sel, err := parser.ParseSelect("SELECT /* cols */ a\n-- source\nFROM t GROUP BY -- grp\na")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	want := sqlast.ClauseComments{
+		"SELECT":   {Trailing: []sqlast.Comment{lineComment("/* cols */")}},
+		"FROM":     {Leading: []sqlast.Comment{ownLineComment("-- source")}},
+		"GROUP BY": {Trailing: []sqlast.Comment{lineComment("-- grp")}},
+	}
+
+	if !reflect.DeepEqual(sel.Clauses, want) {
+		t.Errorf("Clauses = %+v, want %+v", sel.Clauses, want)
+	}
+}
+
+func TestParseSelect_JoinComments(t *testing.T) {
+	sel, err := parser.ParseSelect("SELECT a FROM t1 -- left\nJOIN t2 ON t1.id = t2.id -- on")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	join, ok := sel.From[0].(*sqlast.JoinTableExpr)
+	if !ok {
+		t.Fatalf("From[0] = %T, want *sqlast.JoinTableExpr", sel.From[0])
+	}
+
+	if want := (sqlast.Comments{Trailing: []sqlast.Comment{lineComment("-- left")}}); !reflect.DeepEqual(
+		*join.LeftExpr.NodeComments(), want) {
+		t.Errorf("left operand comments = %+v, want %+v", *join.LeftExpr.NodeComments(), want)
+	}
+
+	if want := (sqlast.Comments{Trailing: []sqlast.Comment{lineComment("-- on")}}); !reflect.DeepEqual(join.Comments, want) {
+		t.Errorf("join comments = %+v, want %+v", join.Comments, want)
+	}
+
+	if !join.RightExpr.NodeComments().IsEmpty() {
+		t.Errorf("right operand comments = %+v, want none", *join.RightExpr.NodeComments())
+	}
+}
+
+func TestParseSelect_JoinRightOperandComments(t *testing.T) {
+	sel, err := parser.ParseSelect("SELECT a FROM t1 JOIN t2 -- right\nON t1.id = t2.id")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	join, ok := sel.From[0].(*sqlast.JoinTableExpr)
+	if !ok {
+		t.Fatalf("From[0] = %T, want *sqlast.JoinTableExpr", sel.From[0])
+	}
+
+	if want := (sqlast.Comments{Trailing: []sqlast.Comment{lineComment("-- right")}}); !reflect.DeepEqual(
+		*join.RightExpr.NodeComments(), want) {
+		t.Errorf("right operand comments = %+v, want %+v", *join.RightExpr.NodeComments(), want)
+	}
+
+	if !join.Comments.IsEmpty() {
+		t.Errorf("join comments = %+v, want none", join.Comments)
+	}
+}
+
+func TestParseSelect_OperatorComments(t *testing.T) {
+	sel, err := parser.ParseSelect("SELECT a FROM t WHERE b = 1 AND -- and\n c = 2 OR /* or */ d = 3")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	or, ok := sel.Where.Expr.(*sqlast.OrExpr)
+	if !ok {
+		t.Fatalf("Where.Expr = %T, want *sqlast.OrExpr", sel.Where.Expr)
+	}
+
+	and, ok := or.Left.(*sqlast.AndExpr)
+	if !ok {
+		t.Fatalf("Left = %T, want *sqlast.AndExpr", or.Left)
+	}
+
+	// The AND ends its line, so its comment follows the left operand; the
+	// OR doesn't, so its comment leads the right one, in the WHERE body.
+	if want := (sqlast.Comments{Leading: []sqlast.Comment{lineComment("-- and")}}); !reflect.DeepEqual(and.Comments, want) {
+		t.Errorf("and comments = %+v, want %+v", and.Comments, want)
+	}
+
+	if !or.Comments.IsEmpty() {
+		t.Errorf("or comments = %+v, want none", or.Comments)
+	}
+
+	if want := (sqlast.Comments{Trailing: []sqlast.Comment{lineComment("/* or */")}}); !reflect.DeepEqual(
+		sel.Where.Comments, want) {
+		t.Errorf("where comments = %+v, want %+v", sel.Where.Comments, want)
+	}
+}
+
+func TestParseSelect_CaseComments(t *testing.T) {
+	sel, err := parser.ParseSelect("SELECT CASE -- c\nWHEN a THEN 1 -- w\nELSE 2 -- e\nEND FROM t")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	expr, ok := sel.SelectExprs[0].(*sqlast.AliasedExpr)
+	if !ok {
+		t.Fatalf("SelectExprs[0] = %T, want *sqlast.AliasedExpr", sel.SelectExprs[0])
+	}
+
+	c, ok := expr.Expr.(*sqlast.CaseExpr)
+	if !ok {
+		t.Fatalf("Expr = %T, want *sqlast.CaseExpr", expr.Expr)
+	}
+
+	checks := []struct {
+		name string
+		got  sqlast.Comments
+		want string
+	}{
+		{"case", c.Comments, "-- c"},
+		{"when", c.Whens[0].Comments, "-- w"},
+		{"else", c.ElseComments, "-- e"},
+	}
+
+	for _, check := range checks {
+		if want := (sqlast.Comments{Trailing: []sqlast.Comment{lineComment(check.want)}}); !reflect.DeepEqual(check.got, want) {
+			t.Errorf("%s comments = %+v, want %+v", check.name, check.got, want)
+		}
+	}
+
+	if !expr.Comments.IsEmpty() {
+		t.Errorf("select item comments = %+v, want none", expr.Comments)
+	}
+}
+
+func TestParseCreateTable_ElementComments(t *testing.T) {
+	ct, err := parser.ParseCreateTable("CREATE TABLE t (id INT, -- pk\n-- about name\nname TEXT, -- name\nPRIMARY KEY (id) -- key\n)")
+	if err != nil {
+		t.Fatalf("ParseCreateTable error = %v", err)
+	}
+
+	want := []sqlast.Comments{
+		{Trailing: []sqlast.Comment{lineComment("-- pk")}},
+		{Leading: []sqlast.Comment{ownLineComment("-- about name")}, Trailing: []sqlast.Comment{lineComment("-- name")}},
+		{Trailing: []sqlast.Comment{lineComment("-- key")}},
+	}
+
+	for i, e := range ct.Elements {
+		if got := *e.NodeComments(); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Elements[%d] comments = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	if !ct.Comments.IsEmpty() {
+		t.Errorf("statement comments = %+v, want none", ct.Comments)
+	}
+}
+
+func TestParseInsert_ColumnComments(t *testing.T) {
+	ins, err := parser.ParseInsert("INSERT INTO t (a, -- first\nb) VALUES (1, 2)")
+	if err != nil {
+		t.Fatalf("ParseInsert error = %v", err)
+	}
+
+	want := []sqlast.Comments{{Trailing: []sqlast.Comment{lineComment("-- first")}}, {}}
+	if !reflect.DeepEqual(ins.ColumnComments, want) {
+		t.Errorf("ColumnComments = %+v, want %+v", ins.ColumnComments, want)
+	}
+
+	if !ins.Comments.IsEmpty() {
+		t.Errorf("statement comments = %+v, want none", ins.Comments)
+	}
+
+	ins, err = parser.ParseInsert("INSERT INTO t (a, b) VALUES (1, 2)")
+	if err != nil {
+		t.Fatalf("ParseInsert error = %v", err)
+	}
+
+	if ins.ColumnComments != nil {
+		t.Errorf("ColumnComments = %+v, want nil", ins.ColumnComments)
+	}
+}
+
+func TestParseStatement_CommentsGoToNearestCarrier(t *testing.T) {
+	stmt, err := parser.ParseStatement("INSERT INTO t (a, b) VALUES (1, -- col\n2)")
+	if err != nil {
+		t.Fatalf("ParseStatement error = %v", err)
+	}
+
+	want := sqlast.Comments{Trailing: []sqlast.Comment{lineComment("-- col")}}
+	if got := *stmt.NodeComments(); !reflect.DeepEqual(got, want) {
+		t.Errorf("statement comments = %+v, want %+v", got, want)
+	}
+}
@@ -16,13 +16,9 @@ func parseDDLEntry[T sqlast.Statement](input string, mode SQLMode, parse func(*P
 	defer recoverParseError(&err)
 
 	p := NewParserWithMode(input, mode)
+	m := p.markComments()
 	parsed := parse(p)
-
-	p.consume(SEMICOLON)
-
-	if !p.at(EOF) {
-		p.failf("unexpected token %s after statement", p.tok.Type)
-	}
+	p.finishStatement(parsed, m)
 
 	return parsed, nil
 }
@@ -99,35 +95,58 @@ func ParseTruncateTableWithMode(input string, mode SQLMode) (*sqlast.TruncateTab
 	return parseDDLEntry(input, mode, (*Parser).parseTruncateTableStatement)
 }
 
-// parseCreateStatement dispatches a leading CREATE to CREATE TABLE or
-// CREATE [UNIQUE] INDEX based on the following token. The current token
-// must be CREATE.
+// parseCreateStatement dispatches a leading CREATE to CREATE TABLE, CREATE
+// [UNIQUE] INDEX, or CREATE [OR REPLACE] VIEW based on the following token.
+// The current token must be CREATE.
 func (p *Parser) parseCreateStatement() sqlast.Statement {
 	switch {
 	case p.peekAt(TABLE):
 		return p.parseCreateTableStatement()
-	case p.peekAt(INDEX) || p.peekAt(UNIQUE):
+	case p.peekAt(INDEX) || p.peekAt(UNIQUE) || p.peekAt(FULLTEXT):
 		return p.parseCreateIndexStatement()
+	case p.peekAt(VIEW) || p.peekAt(OR) || p.peekAtViewOption():
+		return p.parseCreateViewStatement()
+	default:
+		return failReturn[sqlast.Statement](
+			p, "expected TABLE, INDEX, UNIQUE, FULLTEXT, or VIEW after CREATE, got %s", p.peekTok.Type,
+		)
+	}
+}
+
+// parseAlterStatement dispatches a leading ALTER to ALTER TABLE or ALTER
+// VIEW based on the following token. The current token must be ALTER.
+func (p *Parser) parseAlterStatement() sqlast.Statement {
+	switch {
+	case p.peekAt(TABLE):
+		return p.parseAlterTableStatement()
+	case p.peekAt(VIEW) || p.peekAtViewOption():
+		return p.parseAlterViewStatement()
 	default:
-		return failReturn[sqlast.Statement](p, "expected TABLE, INDEX, or UNIQUE after CREATE, got %s", p.peekTok.Type)
+		return failReturn[sqlast.Statement](p, "expected TABLE or VIEW after ALTER, got %s", p.peekTok.Type)
 	}
 }
 
-// parseDropStatement dispatches a leading DROP to DROP TABLE or DROP INDEX
-// based on the following token. The current token must be DROP.
+// parseDropStatement dispatches a leading DROP to DROP TABLE, DROP INDEX,
+// DROP VIEW, or DROP PREPARE (a DeallocatePrepare) based on the following
+// token. The current token must be DROP.
 func (p *Parser) parseDropStatement() sqlast.Statement {
 	switch {
 	case p.peekAt(TABLE):
 		return p.parseDropTableStatement()
 	case p.peekAt(INDEX):
 		return p.parseDropIndexStatement()
+	case p.peekAt(VIEW):
+		return p.parseDropViewStatement()
+	case p.peekAt(PREPARE):
+		return p.parseDeallocatePrepareStatement()
 	default:
-		return failReturn[sqlast.Statement](p, "expected TABLE or INDEX after DROP, got %s", p.peekTok.Type)
+		return failReturn[sqlast.Statement](p, "expected TABLE, INDEX, VIEW, or PREPARE after DROP, got %s", p.peekTok.Type)
 	}
 }
 
 // parseCreateTableStatement parses a CREATE TABLE statement. The current
-// token must be CREATE.
+// token must be CREATE. Each element of the column list claims its comments
+// through the comma after it.
 func (p *Parser) parseCreateTableStatement() *sqlast.CreateTable {
 	p.expect(CREATE)
 	p.expect(TABLE)
@@ -138,9 +157,14 @@ func (p *Parser) parseCreateTableStatement() *sqlast.CreateTable {
 	p.expect(LPAREN)
 
 	for {
-		ct.Elements = append(ct.Elements, p.parseTableElement())
+		m := p.markComments()
+		elem := p.parseTableElement()
+		more := p.consume(COMMA)
+		p.attachComments(elem, m)
 
-		if !p.consume(COMMA) {
+		ct.Elements = append(ct.Elements, elem)
+
+		if !more {
 			break
 		}
 	}
@@ -165,7 +189,7 @@ func (p *Parser) parseTableElement() sqlast.TableElement {
 
 func (p *Parser) isTableConstraintStart() bool {
 	switch p.tok.Type {
-	case CONSTRAINT, PRIMARY, UNIQUE, INDEX, KEY, FOREIGN:
+	case CONSTRAINT, PRIMARY, UNIQUE, INDEX, KEY, FOREIGN, FULLTEXT:
 		return true
 	default:
 		return false
@@ -271,11 +295,12 @@ func (p *Parser) parseDataType() sqlast.DataType {
 }
 
 // readTypeName reads a data type's name. This is almost always a plain
-// identifier, but MySQL's SET type collides lexically with the SET keyword
-// (used elsewhere for UPDATE/INSERT's SET clause), so it's special-cased
-// here as the one type name that isn't a plain IDENT token.
+// identifier, but MySQL's SET and BINARY types collide lexically with the
+// SET keyword (used elsewhere for UPDATE/INSERT's SET clause) and the BINARY
+// prefix operator, so they're special-cased here as the type names that
+// aren't plain IDENT tokens.
 func (p *Parser) readTypeName() string {
-	if p.at(SET) {
+	if p.at(SET) || p.at(BINARY) {
 		name := p.tok.Literal
 		p.advance()
 
@@ -297,7 +322,7 @@ func (p *Parser) parseTypeParams() []string {
 			params = append(params, p.tok.Literal)
 			p.advance()
 		case STRING:
-			params = append(params, "'"+escapeStringLiteral(p.tok.Literal, p.mode)+"'")
+			params = append(params, quoteStringToken(p.tok, p.mode))
 			p.advance()
 		default:
 			return failReturn[[]string](p, "expected type parameter, got %s", p.tok.Type)
@@ -310,13 +335,14 @@ func (p *Parser) parseTypeParams() []string {
 }
 
 // parseTableConstraint parses a table-level constraint or secondary index:
-// [CONSTRAINT [name]] PRIMARY KEY | UNIQUE | FOREIGN KEY, or a plain
-// INDEX/KEY. The current token must be one that isTableConstraintStart
-// recognizes.
+// [CONSTRAINT [name]] PRIMARY KEY | UNIQUE | FOREIGN KEY, a plain INDEX/KEY,
+// or a FULLTEXT [INDEX|KEY]. The current token must be one that
+// isTableConstraintStart recognizes.
 //
-// A plain INDEX/KEY is checked first and handled without ever consuming a
-// CONSTRAINT keyword, since MySQL only allows a CONSTRAINT symbol on
-// PRIMARY KEY/UNIQUE/FOREIGN KEY — IndexConstraint has no field to hold one.
+// A plain or full-text index is checked first and handled without ever
+// consuming a CONSTRAINT keyword, since MySQL only allows a CONSTRAINT
+// symbol on PRIMARY KEY/UNIQUE/FOREIGN KEY — IndexConstraint has no field to
+// hold one.
 func (p *Parser) parseTableConstraint() sqlast.TableConstraint {
 	if p.at(INDEX) || p.at(KEY) {
 		p.advance()
@@ -326,6 +352,18 @@ func (p *Parser) parseTableConstraint() sqlast.TableConstraint {
 		return &sqlast.IndexConstraint{IndexName: name, Columns: p.parseIndexColumnList()}
 	}
 
+	if p.consume(FULLTEXT) {
+		if !p.consume(INDEX) {
+			p.consume(KEY)
+		}
+
+		ic := &sqlast.IndexConstraint{Fulltext: true, IndexName: p.parseOptionalIndexName()}
+		ic.Columns = p.parseIndexColumnList()
+		ic.Parser = p.parseOptionalWithParser()
+
+		return ic
+	}
+
 	constraintName := p.parseOptionalConstraintName()
 
 	switch {
@@ -550,11 +588,15 @@ func isTableOptionStartToken(tt TokenType) bool {
 	}
 }
 
+// parseTableOption parses one "name [=] value" table option. MySQL only
+// accepts a literal or bare name as the value, so it's parsed as a primary
+// expression: the full grammar would read a following COLLATE option as a
+// postfix COLLATE on the value.
 func (p *Parser) parseTableOption() sqlast.TableOption {
 	name := p.parseTableOptionName()
 	p.consume(EQ)
 
-	return sqlast.TableOption{Name: name, Value: p.parseExpr()}
+	return sqlast.TableOption{Name: name, Value: p.parsePrimaryExpr()}
 }
 
 // parseTableOptionName parses one table option's name. The common MySQL
@@ -671,25 +713,42 @@ func (p *Parser) parseDropAction() sqlast.AlterAction {
 	return &sqlast.DropColumnAction{Name: sqlast.ColIdent(p.readIdent())}
 }
 
-// parseCreateIndexStatement parses a CREATE [UNIQUE] INDEX statement. The
-// current token must be CREATE.
+// parseCreateIndexStatement parses a CREATE [UNIQUE|FULLTEXT] INDEX
+// statement. The current token must be CREATE.
 func (p *Parser) parseCreateIndexStatement() *sqlast.CreateIndex {
 	p.expect(CREATE)
 
 	unique := p.consume(UNIQUE)
+	fulltext := !unique && p.consume(FULLTEXT)
 
 	p.expect(INDEX)
 
-	ci := &sqlast.CreateIndex{Unique: unique, Name: sqlast.TableIdent(p.readIdent())}
+	ci := &sqlast.CreateIndex{Unique: unique, Fulltext: fulltext, Name: sqlast.TableIdent(p.readIdent())}
 
 	p.expect(ON)
 
 	ci.Table = p.parseTableName()
 	ci.Columns = p.parseIndexColumnList()
 
+	if fulltext {
+		ci.Parser = p.parseOptionalWithParser()
+	}
+
 	return ci
 }
 
+// parseOptionalWithParser parses a full-text index's optional WITH PARSER
+// name option (e.g. WITH PARSER ngram), returning "" if absent.
+func (p *Parser) parseOptionalWithParser() string {
+	if !p.consume(WITH) {
+		return ""
+	}
+
+	p.expectWord("PARSER")
+
+	return p.readIdent()
+}
+
 // parseDropIndexStatement parses a DROP INDEX statement. The current token
 // must be DROP.
 func (p *Parser) parseDropIndexStatement() *sqlast.DropIndex {
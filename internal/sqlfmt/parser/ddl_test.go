@@ -66,6 +66,21 @@ func TestParseCreateTable(t *testing.T) {
 			"CREATE TABLE t (status ENUM('a', 'b'), flags SET('x', 'y'))",
 			"CREATE TABLE t (status ENUM('a', 'b'), flags SET('x', 'y'))",
 		},
+		{
+			"binary type",
+			"CREATE TABLE t (id BINARY(16))",
+			"CREATE TABLE t (id BINARY(16))",
+		},
+		{
+			"fulltext key with parser",
+			"CREATE TABLE t (body TEXT, FULLTEXT KEY ft_body (body) WITH PARSER ngram)",
+			"CREATE TABLE t (body TEXT, FULLTEXT INDEX ft_body (body) WITH PARSER ngram)",
+		},
+		{
+			"bare unnamed fulltext",
+			"CREATE TABLE t (title TEXT, body TEXT, FULLTEXT (title, body))",
+			"CREATE TABLE t (title TEXT, body TEXT, FULLTEXT INDEX (title, body))",
+		},
 		{
 			"named unique key with index name",
 			"CREATE TABLE t (id INT, email VARCHAR(255), UNIQUE KEY uq_email (email))",
@@ -110,6 +125,11 @@ func TestParseCreateTable(t *testing.T) {
 			"CREATE TABLE t (id INT) ENGINE InnoDB",
 			"CREATE TABLE t (id INT) ENGINE=InnoDB",
 		},
+		{
+			"charset followed by collate without equals",
+			"CREATE TABLE t (id INT) CHARSET utf8mb4 COLLATE utf8mb4_bin",
+			"CREATE TABLE t (id INT) CHARSET=utf8mb4 COLLATE=utf8mb4_bin",
+		},
 		{
 			"every recognized table option name form",
 			"CREATE TABLE t (id INT) DEFAULT CHARACTER SET=utf8mb4, DEFAULT COLLATE=utf8mb4_bin, " +
@@ -249,6 +269,11 @@ func TestParseAlterTable(t *testing.T) {
 			"ALTER TABLE t ADD INDEX idx_name (name)",
 			"ALTER TABLE t ADD INDEX idx_name (name)",
 		},
+		{
+			"add fulltext index",
+			"ALTER TABLE t ADD FULLTEXT INDEX ft_body (body) WITH PARSER ngram",
+			"ALTER TABLE t ADD FULLTEXT INDEX ft_body (body) WITH PARSER ngram",
+		},
 		{
 			"drop index",
 			"ALTER TABLE t DROP INDEX idx_name",
@@ -343,6 +368,16 @@ func TestParseCreateIndex(t *testing.T) {
 			"CREATE UNIQUE INDEX idx_email ON t (email)",
 			"CREATE UNIQUE INDEX idx_email ON t (email)",
 		},
+		{
+			"fulltext",
+			"CREATE FULLTEXT INDEX ft_body ON t (title, body)",
+			"CREATE FULLTEXT INDEX ft_body ON t (title, body)",
+		},
+		{
+			"fulltext with parser",
+			"CREATE FULLTEXT INDEX ft_body ON t (body) WITH PARSER ngram",
+			"CREATE FULLTEXT INDEX ft_body ON t (body) WITH PARSER ngram",
+		},
 		{
 			"multi column with prefix and direction",
 			"CREATE INDEX idx ON t (a(10) ASC, b DESC)",
@@ -365,12 +400,16 @@ func TestParseCreateTable_anonymousConstraint(t *testing.T) {
 }
 
 func TestParseCreateStatement_unknownKeyword(t *testing.T) {
-	if _, err := parser.ParseStatement("CREATE VIEW v AS SELECT 1"); err == nil {
-		t.Error(`ParseStatement("CREATE VIEW v AS SELECT 1") expected error, got nil`)
+	if _, err := parser.ParseStatement("CREATE TRIGGER trg BEFORE INSERT ON t FOR EACH ROW SET @a = 1"); err == nil {
+		t.Error(`ParseStatement("CREATE TRIGGER ...") expected error, got nil`)
+	}
+
+	if _, err := parser.ParseStatement("DROP TRIGGER trg"); err == nil {
+		t.Error(`ParseStatement("DROP TRIGGER trg") expected error, got nil`)
 	}
 
-	if _, err := parser.ParseStatement("DROP VIEW v"); err == nil {
-		t.Error(`ParseStatement("DROP VIEW v") expected error, got nil`)
+	if _, err := parser.ParseStatement("ALTER DATABASE db CHARACTER SET utf8mb4"); err == nil {
+		t.Error(`ParseStatement("ALTER DATABASE ...") expected error, got nil`)
 	}
 }
 
@@ -386,6 +425,10 @@ func TestParseCreateIndex_errors(t *testing.T) {
 		"CREATE INDEX idx ON t (a) extra",
 		"CREATE INDEX idx ON t (a(x))",
 		"CREATE INDEX idx ON t (a(99999999999999999999))",
+		"CREATE UNIQUE FULLTEXT INDEX idx ON t (a)",
+		"CREATE INDEX idx ON t (a) WITH PARSER ngram",
+		"CREATE FULLTEXT INDEX idx ON t (a) WITH ngram",
+		"CREATE FULLTEXT INDEX idx ON t (a) WITH PARSER",
 	}
 
 	for _, in := range tests {
@@ -18,13 +18,9 @@ func ParseDeleteWithMode(input string, mode SQLMode) (del *sqlast.Delete, err er
 	defer recoverParseError(&err)
 
 	p := NewParserWithMode(input, mode)
+	m := p.markComments()
 	result := p.parseDeleteStatement()
-
-	p.consume(SEMICOLON)
-
-	if !p.at(EOF) {
-		p.failf("unexpected token %s after statement", p.tok.Type)
-	}
+	p.finishStatement(result, m)
 
 	return result, nil
 }
@@ -58,6 +54,7 @@ func (p *Parser) parseDeleteStatementAfterWith(with *sqlast.With) *sqlast.Delete
 
 	p.expect(DELETE)
 
+	del.Hints = p.parseOptionalHintComments()
 	del.Ignore = p.consume(IGNORE)
 
 	var singleTable bool
@@ -66,17 +63,20 @@ func (p *Parser) parseDeleteStatementAfterWith(with *sqlast.With) *sqlast.Delete
 		singleTable = p.parseDeleteFromClause(del)
 	} else {
 		del.Targets = p.parseTableNameList()
-		p.expect(FROM)
+		if !p.consumeClause(&del.Clauses, "FROM", FROM) {
+			p.failf("expected %s, got %s", FROM, p.tok.Type)
+		}
+
 		del.TableExprs = p.parseTableReferenceList()
 	}
 
-	del.Where = p.parseOptionalWhereClause(WHERE)
+	del.Where = p.parseOptionalWhereClause(&del.Clauses, WHERE)
 
 	// ORDER BY and LIMIT are only valid for the single-table form (Form A);
 	// MySQL rejects them on the multi-table forms (Form B and Form C).
 	if singleTable {
-		del.OrderBy = p.parseOptionalOrderBy()
-		del.Limit = p.parseOptionalLimit()
+		del.OrderBy = p.parseOptionalOrderBy(&del.Clauses)
+		del.Limit = p.parseOptionalLimit(&del.Clauses)
 	}
 
 	return del
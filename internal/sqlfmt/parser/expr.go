@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
@@ -9,29 +10,92 @@ import (
 // parseExpr parses a SQL expression, starting from the loosest-binding
 // precedence level (OR) down to the tightest (primary expressions).
 func (p *Parser) parseExpr() sqlast.Expr {
+	if p.at(AtVariable) && p.peekAt(ASSIGN) {
+		return p.parseAssignmentExpr()
+	}
+
 	return p.parseOrExpr()
 }
 
+// parseAssignmentExpr parses @name := expr, the loosest-binding expression
+// form. It is right-associative, so @a := @b := 1 assigns 1 to both.
+func (p *Parser) parseAssignmentExpr() sqlast.Expr {
+	name := p.tok.Literal
+	p.advance() // consume the variable
+	p.advance() // consume :=
+
+	return &sqlast.AssignmentExpr{Name: name, Expr: p.parseExpr()}
+}
+
+// parseOrExpr parses an OR chain. || is OR too, unless the mode has
+// ModePipesAsConcat (see parseConcatExpr).
 func (p *Parser) parseOrExpr() sqlast.Expr {
+	pending := len(p.comments)
+	left := p.parseXorExpr()
+
+	for p.at(OR) || (!p.mode.Has(ModePipesAsConcat) && p.at(PIPES)) {
+		or := &sqlast.OrExpr{Left: left}
+		or.Leading, pending = p.claimOperatorComments(pending)
+		or.Right = p.parseXorExpr()
+		left = or
+	}
+
+	return left
+}
+
+// parseXorExpr parses a logical XOR chain, which binds between OR and AND.
+func (p *Parser) parseXorExpr() sqlast.Expr {
 	left := p.parseAndExpr()
 
-	for p.consume(OR) {
-		left = &sqlast.OrExpr{Left: left, Right: p.parseAndExpr()}
+	for p.consume(XOR) {
+		left = &sqlast.XorExpr{Left: left, Right: p.parseAndExpr()}
 	}
 
 	return left
 }
 
+// parseAndExpr parses an AND chain, where && is AND too.
 func (p *Parser) parseAndExpr() sqlast.Expr {
+	pending := len(p.comments)
 	left := p.parseNotExpr()
 
-	for p.consume(AND) {
-		left = &sqlast.AndExpr{Left: left, Right: p.parseNotExpr()}
+	for p.at(AND) || p.at(AMPS) {
+		and := &sqlast.AndExpr{Left: left}
+		and.Leading, pending = p.claimOperatorComments(pending)
+		and.Right = p.parseNotExpr()
+		left = and
 	}
 
 	return left
 }
 
+// claimOperatorComments consumes an AND or OR operator, returning the
+// comments before it that no operand claimed, from the pending'th
+// unclaimed comment on, and the count to pass for the next operator: the
+// formatter writes them on the line the operator's left operand ends. So
+// are those after an operator that ends its line (b = 1 AND -- note), which
+// follow the left operand rather than lead the right one.
+func (p *Parser) claimOperatorComments(pending int) ([]sqlast.Comment, int) {
+	var comments []sqlast.Comment
+
+	if len(p.comments) > pending {
+		comments = slices.Clone(p.comments[pending:])
+		p.comments = p.comments[:pending]
+	}
+
+	comments = append(comments, p.tok.Leading...)
+	p.tok.Leading = nil
+
+	if p.peekTok.Pos.Line > p.tok.Pos.Line {
+		comments = append(comments, p.tok.Trailing...)
+		p.tok.Trailing = nil
+	}
+
+	p.advance()
+
+	return comments, pending
+}
+
 func (p *Parser) parseNotExpr() sqlast.Expr {
 	if !p.consume(NOT) {
 		return p.parseComparisonExpr()
@@ -53,7 +117,7 @@ var simpleComparisonOps = map[TokenType]sqlast.ComparisonOperator{
 // parseComparisonExpr parses a single predicate: a plain comparison, or one
 // of the [NOT] IN / [NOT] BETWEEN / [NOT] LIKE / IS [NOT] NULL forms.
 func (p *Parser) parseComparisonExpr() sqlast.Expr {
-	return p.parsePredicateSuffix(p.parseAdditiveExpr())
+	return p.parsePredicateSuffix(p.parseBitOrExpr())
 }
 
 func (p *Parser) parsePredicateSuffix(left sqlast.Expr) sqlast.Expr {
@@ -106,7 +170,60 @@ func (p *Parser) parseOptionalSimpleComparison(left sqlast.Expr) sqlast.Expr {
 func (p *Parser) parseSimpleComparison(left sqlast.Expr, op sqlast.ComparisonOperator) sqlast.Expr {
 	p.advance()
 
-	return &sqlast.ComparisonExpr{Left: left, Operator: op, Right: p.parseAdditiveExpr()}
+	if q, ok := p.atQuantifiedSubquery(); ok {
+		return p.parseQuantifiedComparison(left, op, q)
+	}
+
+	return &sqlast.ComparisonExpr{Left: left, Operator: op, Right: p.parseBitOrExpr()}
+}
+
+// quantifierWords maps ANY and SOME, which MySQL doesn't reserve and are
+// matched as ordinary identifiers, to their Quantifier.
+var quantifierWords = map[string]sqlast.Quantifier{
+	"ANY":  sqlast.AnyQuantifier,
+	"SOME": sqlast.SomeQuantifier,
+}
+
+// atQuantifiedSubquery reports whether the current token starts an ANY,
+// SOME, or ALL quantifier followed by a parenthesized subquery, and if so
+// which one. Requiring the subquery keeps a column or function that happens
+// to be named any or some parsing as before.
+func (p *Parser) atQuantifiedSubquery() (sqlast.Quantifier, bool) {
+	if !p.peekAt(LPAREN) || (!p.peek2At(SELECT) && !p.peek2At(WITH)) {
+		return 0, false
+	}
+
+	if p.at(ALL) {
+		return sqlast.AllQuantifier, true
+	}
+
+	if !p.at(IDENT) {
+		return 0, false
+	}
+
+	q, ok := quantifierWords[strings.ToUpper(p.tok.Literal)]
+
+	return q, ok
+}
+
+// parseQuantifiedComparison parses the ANY/SOME/ALL (subquery) right-hand
+// side of a comparison. The current token must be the quantifier.
+func (p *Parser) parseQuantifiedComparison(
+	left sqlast.Expr, op sqlast.ComparisonOperator, q sqlast.Quantifier,
+) sqlast.Expr {
+	p.advance() // consume the quantifier
+	p.expect(LPAREN)
+
+	sel := p.parseSubqueryStatement()
+
+	p.expect(RPAREN)
+
+	return &sqlast.QuantifiedComparisonExpr{
+		Left:       left,
+		Operator:   op,
+		Quantifier: q,
+		Subquery:   &sqlast.Subquery{Select: sel},
+	}
 }
 
 func (p *Parser) parseInExpr(left sqlast.Expr, not bool) sqlast.Expr {
@@ -118,7 +235,7 @@ func (p *Parser) parseInExpr(left sqlast.Expr, not bool) sqlast.Expr {
 		op = sqlast.NotInOp
 	}
 
-	if p.at(SELECT) || p.at(WITH) {
+	if p.at(SELECT) || p.at(WITH) || p.atParenSetOperation() {
 		sel := p.parseSubqueryStatement()
 		p.expect(RPAREN)
 
@@ -134,10 +251,10 @@ func (p *Parser) parseInExpr(left sqlast.Expr, not bool) sqlast.Expr {
 func (p *Parser) parseBetweenExpr(left sqlast.Expr, not bool) sqlast.Expr {
 	p.advance() // consume BETWEEN
 
-	from := p.parseAdditiveExpr()
+	from := p.parseBitOrExpr()
 	p.expect(AND)
 
-	to := p.parseAdditiveExpr()
+	to := p.parseBitOrExpr()
 
 	return &sqlast.RangeCond{Not: not, Left: left, From: from, To: to}
 }
@@ -145,7 +262,7 @@ func (p *Parser) parseBetweenExpr(left sqlast.Expr, not bool) sqlast.Expr {
 func (p *Parser) parseLikeExpr(left sqlast.Expr, not bool) sqlast.Expr {
 	p.advance() // consume LIKE
 
-	right := p.parseAdditiveExpr()
+	right := p.parseBitOrExpr()
 
 	op := sqlast.LikeOp
 	if not {
@@ -158,7 +275,7 @@ func (p *Parser) parseLikeExpr(left sqlast.Expr, not bool) sqlast.Expr {
 func (p *Parser) parseRegexpExpr(left sqlast.Expr, not bool) sqlast.Expr {
 	p.advance() // consume REGEXP/RLIKE
 
-	right := p.parseAdditiveExpr()
+	right := p.parseBitOrExpr()
 
 	op := sqlast.RegexpOp
 	if not {
@@ -183,29 +300,65 @@ var arithmeticOps = map[TokenType]sqlast.ArithmeticOperator{
 	STAR:    sqlast.MultOp,
 	SLASH:   sqlast.DivOp,
 	PERCENT: sqlast.ModOp,
-}
-
-func (p *Parser) parseAdditiveExpr() sqlast.Expr {
-	left := p.parseMultiplicativeExpr()
-
-	for p.at(PLUS) || p.at(MINUS) {
+	DIV:     sqlast.IntDivOp,
+	MOD:     sqlast.ModKeywordOp,
+	PIPE:    sqlast.BitOrOp,
+	AMP:     sqlast.BitAndOp,
+	CARET:   sqlast.BitXorOp,
+	SHL:     sqlast.ShiftLeftOp,
+	SHR:     sqlast.ShiftRightOp,
+}
+
+// parseArithmeticLevel parses a left-associative chain of the binary
+// operators in ops, with next parsing each operand. The levels below follow
+// MySQL's operator precedence, loosest first: |, &, << and >>, + and -,
+// then *, /, DIV, % and MOD, then ^.
+func (p *Parser) parseArithmeticLevel(next func() sqlast.Expr, ops ...TokenType) sqlast.Expr {
+	left := next()
+
+	for slices.Contains(ops, p.tok.Type) {
 		op := arithmeticOps[p.tok.Type]
 		p.advance()
 
-		left = &sqlast.ArithmeticExpr{Left: left, Operator: op, Right: p.parseMultiplicativeExpr()}
+		left = &sqlast.ArithmeticExpr{Left: left, Operator: op, Right: next()}
 	}
 
 	return left
 }
 
+func (p *Parser) parseBitOrExpr() sqlast.Expr {
+	return p.parseArithmeticLevel(p.parseBitAndExpr, PIPE)
+}
+
+func (p *Parser) parseBitAndExpr() sqlast.Expr {
+	return p.parseArithmeticLevel(p.parseShiftExpr, AMP)
+}
+
+func (p *Parser) parseShiftExpr() sqlast.Expr {
+	return p.parseArithmeticLevel(p.parseAdditiveExpr, SHL, SHR)
+}
+
+func (p *Parser) parseAdditiveExpr() sqlast.Expr {
+	return p.parseArithmeticLevel(p.parseMultiplicativeExpr, PLUS, MINUS)
+}
+
 func (p *Parser) parseMultiplicativeExpr() sqlast.Expr {
-	left := p.parseUnaryExpr()
+	return p.parseArithmeticLevel(p.parseBitXorExpr, STAR, SLASH, DIV, PERCENT, MOD)
+}
 
-	for p.at(STAR) || p.at(SLASH) || p.at(PERCENT) {
-		op := arithmeticOps[p.tok.Type]
-		p.advance()
+func (p *Parser) parseBitXorExpr() sqlast.Expr {
+	return p.parseArithmeticLevel(p.parseConcatExpr, CARET)
+}
+
+// parseConcatExpr parses a || concatenation chain under ModePipesAsConcat.
+// Per MySQL, || then binds tighter than every other binary operator (^
+// included) but looser than the unary ones, so -a || b * c is
+// ((-a) || b) * c.
+func (p *Parser) parseConcatExpr() sqlast.Expr {
+	left := p.parseUnaryExpr()
 
-		left = &sqlast.ArithmeticExpr{Left: left, Operator: op, Right: p.parseUnaryExpr()}
+	for p.mode.Has(ModePipesAsConcat) && p.consume(PIPES) {
+		left = &sqlast.ConcatExpr{Left: left, Right: p.parseUnaryExpr()}
 	}
 
 	return left
@@ -219,8 +372,12 @@ func (p *Parser) parseUnaryExpr() sqlast.Expr {
 		op = sqlast.UPlusOp
 	case p.at(MINUS):
 		op = sqlast.UMinusOp
+	case p.at(TILDE):
+		op = sqlast.BitNotOp
+	case p.at(BINARY):
+		op = sqlast.BinaryOp
 	default:
-		return p.parsePrimaryExpr()
+		return p.parseOptionalExecutableComments(p.parseCollateExpr())
 	}
 
 	p.advance()
@@ -228,6 +385,31 @@ func (p *Parser) parseUnaryExpr() sqlast.Expr {
 	return &sqlast.UnaryExpr{Operator: op, Expr: p.parseUnaryExpr()}
 }
 
+// parseOptionalExecutableComments wraps operand in an ExecutableCommentExpr
+// for each /*! ... */ executable comment following it, so a comment read
+// after an operand is written back right after it.
+func (p *Parser) parseOptionalExecutableComments(operand sqlast.Expr) sqlast.Expr {
+	for p.at(ExecComment) {
+		operand = &sqlast.ExecutableCommentExpr{Expr: operand, Comment: &sqlast.ExecutableComment{Text: p.tok.Literal}}
+		p.advance()
+	}
+
+	return operand
+}
+
+// parseCollateExpr parses a primary expression followed by any number of
+// postfix COLLATE clauses. Per MySQL, COLLATE binds tighter than every
+// other operator, so -a COLLATE x is -(a COLLATE x).
+func (p *Parser) parseCollateExpr() sqlast.Expr {
+	left := p.parsePrimaryExpr()
+
+	for p.consume(COLLATE) {
+		left = &sqlast.CollateExpr{Expr: left, Collation: p.parseCharsetOrCollationName()}
+	}
+
+	return left
+}
+
 // keywordLiterals holds the keyword tokens that stand for a literal value.
 var keywordLiterals = map[TokenType]string{
 	NULL:  "NULL",
@@ -255,16 +437,172 @@ func (p *Parser) parsePrimaryExpr() sqlast.Expr {
 		return p.parseExistsExpr()
 	case IDENT, QuotedIdent, VALUES:
 		return p.parseIdentOrValuesExpr()
+	default:
+		return p.parseOtherPrimaryExpr()
+	}
+}
+
+// parseOtherPrimaryExpr handles the fallback case in parsePrimaryExpr's
+// switch, split out to keep parsePrimaryExpr's cyclomatic complexity down:
+// the less common primary forms, then a non-reserved keyword used as an
+// identifier.
+func (p *Parser) parseOtherPrimaryExpr() sqlast.Expr {
+	switch p.tok.Type {
+	case AtVariable:
+		return p.parseUserVariable()
+	case INTERVAL:
+		return p.parseIntervalExpr()
+	case LBRACE:
+		return p.parseODBCTemporalLiteral()
+	case Introducer:
+		return p.parseIntroducedLiteral()
+	case MATCH:
+		return p.parseMatchExpr()
 	default:
 		return p.parseNonReservedKeywordAsIdentExpr()
 	}
 }
 
-// parseNonReservedKeywordAsIdentExpr handles the fallback case in
-// parsePrimaryExpr's switch: a non-reserved keyword token (e.g. COMMENT,
-// ENGINE — see token.go's nonReservedKeywords) used as an ordinary column
-// reference or function name, split out to keep parsePrimaryExpr's
-// cyclomatic complexity down.
+// parseIntervalExpr parses INTERVAL expr unit. The unit is an ordinary
+// identifier rather than a keyword, so columns named year, month, etc.
+// keep working.
+func (p *Parser) parseIntervalExpr() sqlast.Expr {
+	p.advance() // consume INTERVAL
+
+	expr := p.parseExpr()
+
+	unit, ok := sqlast.LookupIntervalUnit(p.tok.Literal)
+	if !p.at(IDENT) || !ok {
+		p.failf("expected interval unit, got %s", p.tok.Type)
+	}
+
+	p.advance()
+
+	return &sqlast.IntervalExpr{Expr: expr, Unit: unit}
+}
+
+var temporalLiteralTypes = map[string]sqlast.TemporalLiteralType{
+	"DATE":      sqlast.DateLiteral,
+	"TIME":      sqlast.TimeLiteral,
+	"TIMESTAMP": sqlast.TimestampLiteral,
+}
+
+var odbcTemporalLiteralTypes = map[string]sqlast.TemporalLiteralType{
+	"D":  sqlast.DateLiteral,
+	"T":  sqlast.TimeLiteral,
+	"TS": sqlast.TimestampLiteral,
+}
+
+// parseTemporalLiteral parses DATE/TIME/TIMESTAMP 'str'. The current token
+// is the type name, and the next is the string.
+func (p *Parser) parseTemporalLiteral(typ sqlast.TemporalLiteralType) sqlast.Expr {
+	p.advance() // consume the type name
+
+	return &sqlast.TemporalLiteral{Type: typ, Val: p.parseStringLiteral().String()}
+}
+
+// parseODBCTemporalLiteral parses the {d 'str'}, {t 'str'}, and {ts 'str'}
+// ODBC escapes.
+func (p *Parser) parseODBCTemporalLiteral() sqlast.Expr {
+	p.advance() // consume '{'
+
+	typ, ok := odbcTemporalLiteralTypes[strings.ToUpper(p.tok.Literal)]
+	if !p.at(IDENT) || !ok {
+		p.failf("expected d, t, or ts after '{'")
+	}
+
+	p.advance()
+
+	if !p.at(STRING) {
+		p.failf("expected string in ODBC escape, got %s", p.tok.Type)
+	}
+
+	val := p.parseStringLiteral().String()
+	p.expect(RBRACE)
+
+	return &sqlast.TemporalLiteral{Type: typ, Val: val, ODBC: true}
+}
+
+// parseIntroducedLiteral parses a string literal preceded by a character set
+// introducer token (_utf8mb4'abc' or N'abc').
+func (p *Parser) parseIntroducedLiteral() sqlast.Expr {
+	introducer := p.tok.Literal
+	p.advance()
+
+	return &sqlast.IntroducedLiteral{Introducer: introducer, Val: p.parseStringLiteral().String()}
+}
+
+// parseMatchExpr parses MATCH (col, ...) AGAINST (expr [modifier]). The
+// search string is parsed below the comparison level, as in MySQL's grammar,
+// so the IN of IN BOOLEAN MODE isn't read as an IN predicate.
+func (p *Parser) parseMatchExpr() sqlast.Expr {
+	p.advance() // consume MATCH
+	p.expect(LPAREN)
+
+	m := &sqlast.MatchExpr{}
+
+	for {
+		m.Columns = append(m.Columns, p.parseColName())
+
+		if !p.consume(COMMA) {
+			break
+		}
+	}
+
+	p.expect(RPAREN)
+	p.expect(AGAINST)
+	p.expect(LPAREN)
+
+	m.Against = p.parseBitOrExpr()
+	m.Modifier = p.parseMatchModifier()
+
+	p.expect(RPAREN)
+
+	return m
+}
+
+// parseMatchModifier parses AGAINST's optional search modifier: IN NATURAL
+// LANGUAGE MODE [WITH QUERY EXPANSION], IN BOOLEAN MODE, or WITH QUERY
+// EXPANSION.
+func (p *Parser) parseMatchModifier() sqlast.MatchModifier {
+	switch {
+	case p.consume(IN):
+		if p.consumeWord("BOOLEAN") {
+			p.expect(MODE)
+
+			return sqlast.BooleanModeModifier
+		}
+
+		p.expect(NATURAL)
+		p.expectWord("LANGUAGE")
+		p.expect(MODE)
+
+		if p.consume(WITH) {
+			p.parseQueryExpansion()
+
+			return sqlast.NaturalLanguageModeWithQueryExpansionModifier
+		}
+
+		return sqlast.NaturalLanguageModeModifier
+	case p.consume(WITH):
+		p.parseQueryExpansion()
+
+		return sqlast.QueryExpansionModifier
+	default:
+		return sqlast.NoMatchModifier
+	}
+}
+
+// parseQueryExpansion parses the QUERY EXPANSION following WITH in a MATCH
+// search modifier.
+func (p *Parser) parseQueryExpansion() {
+	p.expectWord("QUERY")
+	p.expectWord("EXPANSION")
+}
+
+// parseNonReservedKeywordAsIdentExpr parses a non-reserved keyword token
+// (e.g. COMMENT, ENGINE — see token.go's nonReservedKeywords) used as an
+// ordinary column reference or function name.
 func (p *Parser) parseNonReservedKeywordAsIdentExpr() sqlast.Expr {
 	if p.tok.Type.IsNonReservedKeyword() {
 		return p.parseIdentOrValuesExpr()
@@ -279,6 +617,10 @@ func (p *Parser) parseNonReservedKeywordAsIdentExpr() sqlast.Expr {
 // keyword everywhere else, and MySQL only accepts it as a function name
 // inside that clause, so it's rejected anywhere p.inOnDupUpdate is false.
 func (p *Parser) parseIdentOrValuesExpr() sqlast.Expr {
+	if typ, ok := temporalLiteralTypes[strings.ToUpper(p.tok.Literal)]; ok && p.at(IDENT) && p.peekAt(STRING) {
+		return p.parseTemporalLiteral(typ)
+	}
+
 	if !p.at(VALUES) {
 		return p.parseIdentExpr()
 	}
@@ -291,7 +633,27 @@ func (p *Parser) parseIdentOrValuesExpr() sqlast.Expr {
 	p.advance() // consume VALUES
 	p.expect(LPAREN)
 
-	return p.parseGenericFuncCall(name)
+	return p.rewriteValuesRef(p.parseGenericFuncCall(name))
+}
+
+// rewriteValuesRef rewrites call, a parsed VALUES(col) reference, to
+// p.onDupRowAlias.col when a row alias rewrite is in effect (see
+// ParseStatementWithValuesRowAlias). A call whose argument isn't a single
+// column reference is returned unchanged.
+func (p *Parser) rewriteValuesRef(call sqlast.Expr) sqlast.Expr {
+	fn, ok := call.(*sqlast.FuncExpr)
+	if p.onDupRowAlias == "" || !ok || len(fn.Exprs) != 1 {
+		return call
+	}
+
+	col, ok := fn.Exprs[0].(*sqlast.ColName)
+	if !ok {
+		return call
+	}
+
+	p.valuesRewritten = true
+
+	return &sqlast.ColName{Qualifier: sqlast.TableName{Name: p.onDupRowAlias}, Name: col.Name}
 }
 
 func (p *Parser) parseLiteralToken() sqlast.Expr {
@@ -311,11 +673,18 @@ func (p *Parser) parseStringLiteral() sqlast.Expr {
 	tok := p.tok
 	p.advance()
 
-	return &sqlast.Literal{Val: "'" + escapeStringLiteral(tok.Literal, p.mode) + "'"}
+	return &sqlast.Literal{Val: quoteStringToken(tok, p.mode)}
+}
+
+// quoteStringToken re-quotes the STRING token tok with the quote character
+// it was written with, per mode.
+func quoteStringToken(tok Token, mode SQLMode) string {
+	quote := string(tok.Quote)
+
+	return quote + escapeStringLiteral(tok.Literal, rune(tok.Quote), mode) + quote
 }
 
 var stringEscapes = map[rune]string{
-	'\'':   `\'`,
 	'\\':   `\\`,
 	'\x00': `\0`,
 	'\b':   `\b`,
@@ -326,20 +695,21 @@ var stringEscapes = map[rune]string{
 }
 
 // escapeStringLiteral re-encodes a lexer-decoded string literal so it can be
-// embedded between single quotes again, per mode.
+// embedded between quote characters (' or ") again, per mode. Only that
+// quote character is escaped; the other one needs no escaping.
 //
-// Under ModeDefault, \% and \_ are round-tripped as-is since the lexer
-// deliberately leaves them un-decoded (they are only meaningful within LIKE
-// pattern matching).
+// With backslash escapes (ModeNoBackslashEscapes unset), \% and \_ are
+// round-tripped as-is since the lexer deliberately leaves them un-decoded
+// (they are only meaningful within LIKE pattern matching).
 //
 // Under ModeNoBackslashEscapes, the lexer never treats backslash as an
 // escape character (see Lexer.readString), so the decoded value already
 // contains every backslash, control character, and literal newline verbatim.
 // Backslash therefore isn't special on the way back out either — doubling
 // the quote character is the only escaping NO_BACKSLASH_ESCAPES supports.
-func escapeStringLiteral(s string, mode SQLMode) string {
-	if mode == ModeNoBackslashEscapes {
-		return strings.ReplaceAll(s, "'", "''")
+func escapeStringLiteral(s string, quote rune, mode SQLMode) string {
+	if mode.Has(ModeNoBackslashEscapes) {
+		return strings.ReplaceAll(s, string(quote), string(quote)+string(quote))
 	}
 
 	runes := []rune(s)
@@ -353,7 +723,7 @@ func escapeStringLiteral(s string, mode SQLMode) string {
 			continue
 		}
 
-		writeEscapedRune(&b, runes[i])
+		writeEscapedRune(&b, runes[i], quote)
 	}
 
 	return b.String()
@@ -372,7 +742,14 @@ func writeLikeEscape(b *strings.Builder, runes []rune, i int) int {
 	return 2
 }
 
-func writeEscapedRune(b *strings.Builder, r rune) {
+func writeEscapedRune(b *strings.Builder, r, quote rune) {
+	if r == quote {
+		b.WriteByte('\\')
+		b.WriteRune(r)
+
+		return
+	}
+
 	if esc, ok := stringEscapes[r]; ok {
 		b.WriteString(esc)
 
@@ -395,10 +772,17 @@ func (p *Parser) parseColonPlaceholder() sqlast.Expr {
 	return &sqlast.Literal{Val: ":" + name}
 }
 
+func (p *Parser) parseUserVariable() sqlast.Expr {
+	name := p.tok.Literal
+	p.advance()
+
+	return &sqlast.UserVariable{Name: name}
+}
+
 func (p *Parser) parseParenExprOrSubquery() sqlast.Expr {
 	p.advance() // consume '('
 
-	if p.at(SELECT) || p.at(WITH) {
+	if p.at(SELECT) || p.at(WITH) || p.atParenSetOperation() {
 		sel := p.parseSubqueryStatement()
 		p.expect(RPAREN)
 
@@ -412,15 +796,17 @@ func (p *Parser) parseParenExprOrSubquery() sqlast.Expr {
 }
 
 func (p *Parser) parseCaseExpr() sqlast.Expr {
+	m := p.markComments()
 	p.advance() // consume CASE
 
-	caseExpr := p.parseOptionalCaseValue()
-	whens := p.parseWhenClauses()
-	elseExpr := p.parseOptionalElse()
+	c := &sqlast.CaseExpr{Expr: p.parseOptionalCaseValue()}
+	c.Comments = p.claimComments(m)
+	c.Whens = p.parseWhenClauses()
+	c.Else, c.ElseComments = p.parseOptionalElse()
 
 	p.expect(END)
 
-	return &sqlast.CaseExpr{Expr: caseExpr, Whens: whens, Else: elseExpr}
+	return c
 }
 
 // parseOptionalCaseValue parses the optional `expr` in a simple CASE expr
@@ -447,23 +833,32 @@ func (p *Parser) parseWhenClauses() []*sqlast.When {
 	return whens
 }
 
-func (p *Parser) parseOptionalElse() sqlast.Expr {
-	if !p.consume(ELSE) {
-		return nil
+// parseOptionalElse parses a CASE expression's optional ELSE expr, and the
+// comments from ELSE through expr.
+func (p *Parser) parseOptionalElse() (sqlast.Expr, sqlast.Comments) {
+	if !p.at(ELSE) {
+		return nil, sqlast.Comments{}
 	}
 
-	return p.parseExpr()
+	m := p.markComments()
+	p.advance() // consume ELSE
+
+	expr := p.parseExpr()
+
+	return expr, p.claimComments(m)
 }
 
 func (p *Parser) parseWhen() *sqlast.When {
+	m := p.markComments()
 	p.advance() // consume WHEN
 
 	cond := p.parseExpr()
 	p.expect(THEN)
 
-	val := p.parseExpr()
+	when := &sqlast.When{Cond: cond, Val: p.parseExpr()}
+	when.Comments = p.claimComments(m)
 
-	return &sqlast.When{Cond: cond, Val: val}
+	return when
 }
 
 func (p *Parser) parseExistsExpr() sqlast.Expr {
@@ -495,13 +890,40 @@ func (p *Parser) parseIdentExpr() sqlast.Expr {
 		return p.parseFuncCall(name)
 	}
 
+	col := &sqlast.ColName{Name: sqlast.ColIdent(name)}
+
 	if p.consume(DOT) {
-		col := p.readIdent()
+		col = &sqlast.ColName{Qualifier: sqlast.TableName{Name: sqlast.TableIdent(name)}}
+		col.Name = sqlast.ColIdent(p.readIdent())
+	}
 
-		return &sqlast.ColName{Qualifier: sqlast.TableName{Name: sqlast.TableIdent(name)}, Name: sqlast.ColIdent(col)}
+	return p.parseOptionalJSONPathSuffix(col)
+}
+
+// parseOptionalJSONPathSuffix parses a column reference's optional -> or ->>
+// JSON path operator. As in MySQL's grammar, these only follow a column and
+// take a string literal path, so they're handled here rather than as binary
+// operators.
+func (p *Parser) parseOptionalJSONPathSuffix(col *sqlast.ColName) sqlast.Expr {
+	switch {
+	case p.consume(ARROW):
+		return &sqlast.JSONExtractExpr{Column: col, Path: p.parseRequiredStringLiteral("after ->")}
+	case p.consume(DARROW):
+		return &sqlast.JSONUnquoteExtractExpr{Column: col, Path: p.parseRequiredStringLiteral("after ->>")}
+	default:
+		return col
 	}
+}
 
-	return &sqlast.ColName{Name: sqlast.ColIdent(name)}
+// parseRequiredStringLiteral parses a string literal, failing with an error
+// naming where it was expected (e.g. "after SEPARATOR") if the current token
+// isn't one.
+func (p *Parser) parseRequiredStringLiteral(where string) sqlast.Expr {
+	if !p.at(STRING) {
+		p.failf("expected string %s, got %s", where, p.tok.Type)
+	}
+
+	return p.parseStringLiteral()
 }
 
 // parseColName parses a possibly-qualified column reference (col or
@@ -529,14 +951,20 @@ func (p *Parser) parseSetExpr() *sqlast.UpdateExpr {
 
 // parseSetExprList parses a comma-separated list of "col = expr"
 // assignments, as used by UPDATE's SET clause, INSERT's SET rows, and
-// ON DUPLICATE KEY UPDATE.
+// ON DUPLICATE KEY UPDATE, each claiming its comments through the comma
+// after it.
 func (p *Parser) parseSetExprList() []*sqlast.UpdateExpr {
 	var exprs []*sqlast.UpdateExpr
 
 	for {
-		exprs = append(exprs, p.parseSetExpr())
+		m := p.markComments()
+		expr := p.parseSetExpr()
+		more := p.consume(COMMA)
+		p.attachComments(expr, m)
 
-		if !p.consume(COMMA) {
+		exprs = append(exprs, expr)
+
+		if !more {
 			return exprs
 		}
 	}
@@ -109,6 +109,30 @@ func TestParseExpr_stringLiteral_NoBackslashEscapes(t *testing.T) {
 	}
 }
 
+// TestParseExpr_doubleQuotes covers a double-quoted token: a string literal
+// by default, kept between double quotes, and a column reference under
+// parser.ModeANSIQuotes.
+func TestParseExpr_doubleQuotes(t *testing.T) {
+	got, err := parser.ParseExprWithMode(`"it's"`, parser.ModeDefault)
+	if err != nil {
+		t.Fatalf("ParseExprWithMode error = %v", err)
+	}
+
+	if want := (&sqlast.Literal{Val: `"it's"`}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseExprWithMode = %#v, want %#v", got, want)
+	}
+
+	got, err = parser.ParseExprWithMode(`t."order"`, parser.ModeANSIQuotes)
+	if err != nil {
+		t.Fatalf("ParseExprWithMode error = %v", err)
+	}
+
+	want := &sqlast.ColName{Qualifier: sqlast.TableName{Name: "t"}, Name: "order"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseExprWithMode = %#v, want %#v", got, want)
+	}
+}
+
 func TestParseExpr_columnRefs(t *testing.T) {
 	assertExpr(t, "id", col("id"))
 	assertExpr(t, "t.id", &sqlast.ColName{Qualifier: sqlast.TableName{Name: "t"}, Name: "id"})
@@ -159,6 +183,115 @@ func TestParseExpr_orAndNotPrecedence(t *testing.T) {
 	assertExpr(t, "a OR b AND NOT c", want)
 }
 
+func TestParseExpr_logicalPipesAndAmps(t *testing.T) {
+	// By default || is OR and && is AND, with the same precedence.
+	want := &sqlast.OrExpr{
+		Left:  col("a"),
+		Right: &sqlast.AndExpr{Left: col("b"), Right: col("c")},
+	}
+	assertExpr(t, "a || b && c", want)
+}
+
+func TestParseExpr_pipesAsConcat(t *testing.T) {
+	// Under PIPES_AS_CONCAT, || binds tighter than * but looser than unary
+	// minus: "-a || b * c OR d" is (((-a) || b) * c) OR d.
+	want := &sqlast.OrExpr{
+		Left: &sqlast.ArithmeticExpr{
+			Left: &sqlast.ConcatExpr{
+				Left:  &sqlast.UnaryExpr{Operator: sqlast.UMinusOp, Expr: col("a")},
+				Right: col("b"),
+			},
+			Operator: sqlast.MultOp,
+			Right:    col("c"),
+		},
+		Right: col("d"),
+	}
+
+	got, err := parser.ParseExprWithMode("-a || b * c OR d", parser.ModePipesAsConcat)
+	if err != nil {
+		t.Fatalf("ParseExprWithMode error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseExprWithMode =\n  %#v\nwant\n  %#v", got, want)
+	}
+}
+
+func TestParseExpr_bitwiseOperators(t *testing.T) {
+	arith := func(l sqlast.Expr, op sqlast.ArithmeticOperator, r sqlast.Expr) *sqlast.ArithmeticExpr {
+		return &sqlast.ArithmeticExpr{Left: l, Operator: op, Right: r}
+	}
+
+	t.Run("bit flag test", func(t *testing.T) {
+		// & binds tighter than <>: "flags & ? <> 0" is (flags & ?) <> 0.
+		assertExpr(t, "flags & ? <> 0", &sqlast.ComparisonExpr{
+			Left:     arith(col("flags"), sqlast.BitAndOp, num("?")),
+			Operator: sqlast.NotEqualOp,
+			Right:    num("0"),
+		})
+	})
+
+	t.Run("or looser than and looser than shift", func(t *testing.T) {
+		// "a | b & c << 1" is a | (b & (c << 1)).
+		assertExpr(t, "a | b & c << 1",
+			arith(col("a"), sqlast.BitOrOp, arith(col("b"), sqlast.BitAndOp, arith(col("c"), sqlast.ShiftLeftOp, num("1")))))
+	})
+
+	t.Run("shift looser than additive", func(t *testing.T) {
+		// "a >> b + 1" is a >> (b + 1).
+		assertExpr(t, "a >> b + 1", arith(col("a"), sqlast.ShiftRightOp, arith(col("b"), sqlast.PlusOp, num("1"))))
+	})
+
+	t.Run("div and mod are multiplicative", func(t *testing.T) {
+		// "a + b DIV 2 MOD 3" is a + ((b DIV 2) MOD 3).
+		assertExpr(t, "a + b DIV 2 MOD 3",
+			arith(col("a"), sqlast.PlusOp, arith(arith(col("b"), sqlast.IntDivOp, num("2")), sqlast.ModKeywordOp, num("3"))))
+	})
+
+	t.Run("caret tighter than multiplicative", func(t *testing.T) {
+		// "a * b ^ c" is a * (b ^ c).
+		assertExpr(t, "a * b ^ c", arith(col("a"), sqlast.MultOp, arith(col("b"), sqlast.BitXorOp, col("c"))))
+	})
+
+	t.Run("bit not", func(t *testing.T) {
+		assertExpr(t, "~a & b",
+			arith(&sqlast.UnaryExpr{Operator: sqlast.BitNotOp, Expr: col("a")}, sqlast.BitAndOp, col("b")))
+	})
+
+	t.Run("mod function call", func(t *testing.T) {
+		assertExpr(t, "MOD(a, 2)", &sqlast.FuncExpr{Name: "MOD", Exprs: []sqlast.Expr{col("a"), num("2")}})
+	})
+}
+
+func TestParseExpr_xor(t *testing.T) {
+	// XOR binds between OR and AND: "a OR b XOR c AND d" is
+	// a OR (b XOR (c AND d)).
+	assertExpr(t, "a OR b XOR c AND d", &sqlast.OrExpr{
+		Left: col("a"),
+		Right: &sqlast.XorExpr{
+			Left:  col("b"),
+			Right: &sqlast.AndExpr{Left: col("c"), Right: col("d")},
+		},
+	})
+}
+
+func TestParseExpr_assignment(t *testing.T) {
+	assertExpr(t, "@rank := @rank + 1", &sqlast.AssignmentExpr{
+		Name: "rank",
+		Expr: &sqlast.ArithmeticExpr{
+			Left:     &sqlast.UserVariable{Name: "rank"},
+			Operator: sqlast.PlusOp,
+			Right:    num("1"),
+		},
+	})
+
+	// := is right-associative.
+	assertExpr(t, "@a := @b := 1", &sqlast.AssignmentExpr{
+		Name: "a",
+		Expr: &sqlast.AssignmentExpr{Name: "b", Expr: num("1")},
+	})
+}
+
 func TestParseExpr_notBindsTighterThanComparisonIsWrong(t *testing.T) {
 	// Comparisons bind tighter than NOT: "NOT a = b" is NOT (a = b).
 	want := &sqlast.NotExpr{
@@ -278,6 +411,41 @@ func TestParseExpr_existsExpr(t *testing.T) {
 	assertExpr(t, "EXISTS (SELECT 1 FROM t)", want)
 }
 
+func TestParseExpr_quantifiedComparison(t *testing.T) {
+	sub := &sqlast.Subquery{Select: &sqlast.Select{
+		SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: col("price")}},
+		From:        []sqlast.TableExpr{&sqlast.AliasedTableExpr{Expr: sqlast.TableName{Name: "t"}}},
+	}}
+
+	tests := []struct {
+		name string
+		in   string
+		want sqlast.Expr
+	}{
+		{"all", "price > ALL (SELECT price FROM t)", &sqlast.QuantifiedComparisonExpr{
+			Left: col("price"), Operator: sqlast.GreaterThanOp, Quantifier: sqlast.AllQuantifier, Subquery: sub,
+		}},
+		{"any", "id = any (SELECT price FROM t)", &sqlast.QuantifiedComparisonExpr{
+			Left: col("id"), Operator: sqlast.EqualOp, Quantifier: sqlast.AnyQuantifier, Subquery: sub,
+		}},
+		{"some", "id <> SOME (SELECT price FROM t)", &sqlast.QuantifiedComparisonExpr{
+			Left: col("id"), Operator: sqlast.NotEqualOp, Quantifier: sqlast.SomeQuantifier, Subquery: sub,
+		}},
+		{"column named any", "a = any", &sqlast.ComparisonExpr{
+			Left: col("a"), Operator: sqlast.EqualOp, Right: col("any"),
+		}},
+		{"function named some", "a = some(b)", &sqlast.ComparisonExpr{
+			Left: col("a"), Operator: sqlast.EqualOp, Right: &sqlast.FuncExpr{Name: "some", Exprs: []sqlast.Expr{col("b")}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertExpr(t, tt.in, tt.want)
+		})
+	}
+}
+
 func TestParseExpr_scalarSubquery(t *testing.T) {
 	want := &sqlast.Subquery{Select: &sqlast.Select{
 		SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: num("1")}},
@@ -290,6 +458,194 @@ func TestParseExpr_genericFuncCall(t *testing.T) {
 	assertExpr(t, "NOW()", &sqlast.FuncExpr{Name: "NOW"})
 }
 
+func TestParseExpr_interval(t *testing.T) {
+	t.Run("date arithmetic", func(t *testing.T) {
+		assertExpr(t, "NOW() - INTERVAL 7 DAY", &sqlast.ArithmeticExpr{
+			Left:     &sqlast.FuncExpr{Name: "NOW"},
+			Operator: sqlast.MinusOp,
+			Right:    &sqlast.IntervalExpr{Expr: num("7"), Unit: sqlast.DayUnit},
+		})
+	})
+
+	t.Run("function argument", func(t *testing.T) {
+		assertExpr(t, "DATE_ADD(x, INTERVAL ? HOUR)", &sqlast.FuncExpr{
+			Name:  "DATE_ADD",
+			Exprs: []sqlast.Expr{col("x"), &sqlast.IntervalExpr{Expr: num("?"), Unit: sqlast.HourUnit}},
+		})
+	})
+
+	t.Run("compound unit", func(t *testing.T) {
+		assertExpr(t, "INTERVAL '1 2' day_hour",
+			&sqlast.IntervalExpr{Expr: &sqlast.Literal{Val: "'1 2'"}, Unit: sqlast.DayHourUnit})
+	})
+
+	t.Run("unit names stay usable as columns", func(t *testing.T) {
+		assertExpr(t, "year + month", &sqlast.ArithmeticExpr{Left: col("year"), Operator: sqlast.PlusOp, Right: col("month")})
+	})
+}
+
+func TestParseExpr_temporalLiterals(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *sqlast.TemporalLiteral
+	}{
+		{"DATE '2024-01-02'", &sqlast.TemporalLiteral{Type: sqlast.DateLiteral, Val: "'2024-01-02'"}},
+		{"time '12:34:56'", &sqlast.TemporalLiteral{Type: sqlast.TimeLiteral, Val: "'12:34:56'"}},
+		{"TIMESTAMP '2024-01-02 12:34:56'", &sqlast.TemporalLiteral{Type: sqlast.TimestampLiteral, Val: "'2024-01-02 12:34:56'"}},
+		{"{d '2024-01-02'}", &sqlast.TemporalLiteral{Type: sqlast.DateLiteral, Val: "'2024-01-02'", ODBC: true}},
+		{"{t '12:34:56'}", &sqlast.TemporalLiteral{Type: sqlast.TimeLiteral, Val: "'12:34:56'", ODBC: true}},
+		{"{TS '2024-01-02 12:34:56'}", &sqlast.TemporalLiteral{Type: sqlast.TimestampLiteral, Val: "'2024-01-02 12:34:56'", ODBC: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assertExpr(t, tt.in, tt.want)
+		})
+	}
+
+	t.Run("date column", func(t *testing.T) {
+		assertExpr(t, "date", col("date"))
+	})
+}
+
+func TestParseExpr_cast(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *sqlast.CastExpr
+	}{
+		{"CAST(a AS CHAR(10))", &sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "CHAR", Params: []string{"10"}}}},
+		{
+			"CAST(a AS CHAR(10) CHARACTER SET utf8mb4)",
+			&sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "CHAR", Params: []string{"10"}, Charset: "utf8mb4"}},
+		},
+		{"CAST(a AS DECIMAL(10, 2))", &sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "DECIMAL", Params: []string{"10", "2"}}}},
+		{"CAST(a AS SIGNED)", &sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "SIGNED"}}},
+		{"cast(a as unsigned integer)", &sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "UNSIGNED INTEGER"}}},
+		{"CAST(a AS SIGNED INT)", &sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "SIGNED INT"}}},
+		{"CAST(a AS JSON)", &sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "JSON"}}},
+		{"CAST(a AS BINARY(16))", &sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "BINARY", Params: []string{"16"}}}},
+		{"CAST(a AS UNSIGNED ARRAY)", &sqlast.CastExpr{Expr: col("a"), Type: sqlast.DataType{Name: "UNSIGNED"}, Array: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assertExpr(t, tt.in, tt.want)
+		})
+	}
+}
+
+func TestParseExpr_convert(t *testing.T) {
+	assertExpr(t, "CONVERT(a, CHAR(10))",
+		&sqlast.ConvertExpr{Expr: col("a"), Type: &sqlast.DataType{Name: "CHAR", Params: []string{"10"}}})
+	assertExpr(t, "CONVERT(a, UNSIGNED)", &sqlast.ConvertExpr{Expr: col("a"), Type: &sqlast.DataType{Name: "UNSIGNED"}})
+	assertExpr(t, "CONVERT(a USING utf8mb4)", &sqlast.ConvertExpr{Expr: col("a"), Charset: "utf8mb4"})
+}
+
+func TestParseExpr_binary(t *testing.T) {
+	// BINARY binds tighter than comparison: "BINARY a = b" is (BINARY a) = b.
+	assertExpr(t, "BINARY a = b", &sqlast.ComparisonExpr{
+		Left:     &sqlast.UnaryExpr{Operator: sqlast.BinaryOp, Expr: col("a")},
+		Operator: sqlast.EqualOp,
+		Right:    col("b"),
+	})
+}
+
+func TestParseExpr_collate(t *testing.T) {
+	t.Run("binds tighter than comparison", func(t *testing.T) {
+		assertExpr(t, "name COLLATE utf8mb4_bin = ?", &sqlast.ComparisonExpr{
+			Left:     &sqlast.CollateExpr{Expr: col("name"), Collation: &sqlast.Literal{Val: "utf8mb4_bin"}},
+			Operator: sqlast.EqualOp,
+			Right:    num("?"),
+		})
+	})
+
+	t.Run("binds tighter than unary minus", func(t *testing.T) {
+		assertExpr(t, "-a COLLATE x", &sqlast.UnaryExpr{
+			Operator: sqlast.UMinusOp,
+			Expr:     &sqlast.CollateExpr{Expr: col("a"), Collation: &sqlast.Literal{Val: "x"}},
+		})
+	})
+
+	t.Run("quoted collation", func(t *testing.T) {
+		assertExpr(t, "a COLLATE 'utf8mb4_bin'",
+			&sqlast.CollateExpr{Expr: col("a"), Collation: &sqlast.Literal{Val: "'utf8mb4_bin'"}})
+	})
+}
+
+func TestParseExpr_match(t *testing.T) {
+	cols := []*sqlast.ColName{col("title"), col("body")}
+
+	tests := []struct {
+		name string
+		in   string
+		want sqlast.MatchModifier
+	}{
+		{"no modifier", "MATCH (title, body) AGAINST (?)", sqlast.NoMatchModifier},
+		{"natural language mode", "MATCH (title, body) AGAINST (? IN NATURAL LANGUAGE MODE)",
+			sqlast.NaturalLanguageModeModifier},
+		{"natural language mode with query expansion",
+			"match (title, body) against (? in natural language mode with query expansion)",
+			sqlast.NaturalLanguageModeWithQueryExpansionModifier},
+		{"boolean mode", "MATCH (title, body) AGAINST (? IN BOOLEAN MODE)", sqlast.BooleanModeModifier},
+		{"query expansion", "MATCH (title, body) AGAINST (? WITH QUERY EXPANSION)", sqlast.QueryExpansionModifier},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertExpr(t, tt.in, &sqlast.MatchExpr{Columns: cols, Against: num("?"), Modifier: tt.want})
+		})
+	}
+
+	t.Run("in a comparison", func(t *testing.T) {
+		assertExpr(t, "MATCH (body) AGAINST ('+a -b' IN BOOLEAN MODE) > 0", &sqlast.ComparisonExpr{
+			Left: &sqlast.MatchExpr{
+				Columns:  []*sqlast.ColName{col("body")},
+				Against:  &sqlast.Literal{Val: "'+a -b'"},
+				Modifier: sqlast.BooleanModeModifier,
+			},
+			Operator: sqlast.GreaterThanOp,
+			Right:    num("0"),
+		})
+	})
+}
+
+func TestParseExpr_jsonPathOperators(t *testing.T) {
+	t.Run("extract", func(t *testing.T) {
+		assertExpr(t, "data->'$.user.id'", &sqlast.JSONExtractExpr{
+			Column: col("data"),
+			Path:   &sqlast.Literal{Val: "'$.user.id'"},
+		})
+	})
+
+	t.Run("unquote extract on qualified column in comparison", func(t *testing.T) {
+		assertExpr(t, "t.data ->> '$.name' = ?", &sqlast.ComparisonExpr{
+			Left: &sqlast.JSONUnquoteExtractExpr{
+				Column: &sqlast.ColName{Qualifier: sqlast.TableName{Name: "t"}, Name: "data"},
+				Path:   &sqlast.Literal{Val: "'$.name'"},
+			},
+			Operator: sqlast.EqualOp,
+			Right:    num("?"),
+		})
+	})
+
+	t.Run("binds tighter than minus", func(t *testing.T) {
+		assertExpr(t, "a->'$.x' - 1", &sqlast.ArithmeticExpr{
+			Left:     &sqlast.JSONExtractExpr{Column: col("a"), Path: &sqlast.Literal{Val: "'$.x'"}},
+			Operator: sqlast.MinusOp,
+			Right:    num("1"),
+		})
+	})
+}
+
+func TestParseExpr_introducedLiterals(t *testing.T) {
+	assertExpr(t, "_utf8mb4'abc'", &sqlast.IntroducedLiteral{Introducer: "_utf8mb4", Val: "'abc'"})
+	assertExpr(t, "N'abc'", &sqlast.IntroducedLiteral{Introducer: "N", Val: "'abc'"})
+	assertExpr(t, "_utf8mb4'abc' COLLATE utf8mb4_bin", &sqlast.CollateExpr{
+		Expr:      &sqlast.IntroducedLiteral{Introducer: "_utf8mb4", Val: "'abc'"},
+		Collation: &sqlast.Literal{Val: "utf8mb4_bin"},
+	})
+}
+
 // TestParseExpr_valuesFuncCall_rejected covers the deprecated VALUES(col)
 // reference. It only parses as a plain function call inside an ON DUPLICATE
 // KEY UPDATE clause (see TestParseInsert_onDuplicateKeyUpdate); everywhere
@@ -332,7 +688,53 @@ func TestParseExpr_simpleAggCall(t *testing.T) {
 	assertExpr(t, "VARIANCE(a)", &sqlast.Variance{Arg: col("a")})
 	assertExpr(t, "VAR_POP(a)", &sqlast.VarPop{Arg: col("a")})
 	assertExpr(t, "VAR_SAMP(a)", &sqlast.VarSamp{Arg: col("a")})
+}
+
+func TestParseExpr_jsonArrayAgg(t *testing.T) {
 	assertExpr(t, "JSON_ARRAYAGG(a)", &sqlast.JSONArrayAgg{Expr: col("a")})
+	assertExpr(t, "json_arrayagg(name ORDER BY name DESC, id)", &sqlast.JSONArrayAgg{
+		Expr: col("name"),
+		OrderBy: sqlast.OrderBy{
+			{Expr: col("name"), Direction: sqlast.DescOrder},
+			{Expr: col("id"), Direction: sqlast.AscOrder},
+		},
+	})
+	assertExpr(t, "JSON_ARRAYAGG(a) OVER w", &sqlast.JSONArrayAgg{
+		Expr:       col("a"),
+		OverClause: &sqlast.OverClause{WindowName: "w"},
+	})
+}
+
+func TestParseExpr_groupConcat(t *testing.T) {
+	t.Run("plain", func(t *testing.T) {
+		assertExpr(t, "GROUP_CONCAT(a, b)", &sqlast.GroupConcat{Args: []sqlast.Expr{col("a"), col("b")}})
+	})
+
+	t.Run("distinct order by separator", func(t *testing.T) {
+		assertExpr(t, "group_concat(DISTINCT name ORDER BY name DESC, id SEPARATOR ', ')", &sqlast.GroupConcat{
+			Args:     []sqlast.Expr{col("name")},
+			Distinct: true,
+			OrderBy: sqlast.OrderBy{
+				{Expr: col("name"), Direction: sqlast.DescOrder},
+				{Expr: col("id"), Direction: sqlast.AscOrder},
+			},
+			Separator: &sqlast.Literal{Val: "', '"},
+		})
+	})
+
+	t.Run("separator without order by", func(t *testing.T) {
+		assertExpr(t, "GROUP_CONCAT(name separator '')", &sqlast.GroupConcat{
+			Args:      []sqlast.Expr{col("name")},
+			Separator: &sqlast.Literal{Val: "''"},
+		})
+	})
+
+	t.Run("over", func(t *testing.T) {
+		assertExpr(t, "GROUP_CONCAT(name) OVER w", &sqlast.GroupConcat{
+			Args:       []sqlast.Expr{col("name")},
+			OverClause: &sqlast.OverClause{WindowName: "w"},
+		})
+	})
 }
 
 func TestParseExpr_jsonObjectAgg(t *testing.T) {
@@ -358,6 +760,16 @@ func TestParseExpr_windowFunctions(t *testing.T) {
 		})
 	})
 
+	t.Run("inherited window in spec", func(t *testing.T) {
+		assertExpr(t, "RANK() OVER (w ORDER BY id)", &sqlast.ArgumentLessWindowExpr{
+			Type: sqlast.RankExprType,
+			OverClause: &sqlast.OverClause{WindowSpec: &sqlast.WindowSpecification{
+				Name:        "w",
+				OrderClause: sqlast.OrderBy{{Expr: col("id")}},
+			}},
+		})
+	})
+
 	t.Run("first value with null treatment", func(t *testing.T) {
 		want := &sqlast.FirstOrLastValueExpr{
 			Type:                sqlast.FirstValueExprType,
@@ -464,6 +876,23 @@ func TestParseExpr_windowFunctions(t *testing.T) {
 		}
 		assertExpr(t, "ROW_NUMBER() OVER (ROWS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING)", want)
 	})
+
+	t.Run("interval frame point", func(t *testing.T) {
+		want := &sqlast.ArgumentLessWindowExpr{
+			Type: sqlast.RowNumberExprType,
+			OverClause: &sqlast.OverClause{WindowSpec: &sqlast.WindowSpecification{
+				FrameClause: &sqlast.FrameClause{
+					Unit: sqlast.FrameRangeType,
+					Start: &sqlast.FramePoint{
+						Type: sqlast.ExprPrecedingType,
+						Expr: &sqlast.IntervalExpr{Expr: num("1"), Unit: sqlast.DayUnit},
+					},
+					End: &sqlast.FramePoint{Type: sqlast.CurrentRowType},
+				},
+			}},
+		}
+		assertExpr(t, "ROW_NUMBER() OVER (RANGE BETWEEN INTERVAL 1 DAY PRECEDING AND CURRENT ROW)", want)
+	})
 }
 
 func TestParseExpr_errors(t *testing.T) {
@@ -483,6 +912,35 @@ func TestParseExpr_errors(t *testing.T) {
 		"1 2",
 		"0X1A",
 		"0B110",
+		"a COLLATE",
+		"CAST(a)",
+		"CAST(a AS)",
+		"CAST(a AS CHAR(10)",
+		"CONVERT(a)",
+		"CONVERT(a USING)",
+		"BINARY",
+		"a > ALL (SELECT 1",
+		"a > ALL (SELECT 1) extra",
+		"GROUP_CONCAT()",
+		"GROUP_CONCAT(a SEPARATOR)",
+		"GROUP_CONCAT(a SEPARATOR b)",
+		"GROUP_CONCAT(a ORDER BY)",
+		"a->",
+		"a->>b",
+		"a->?",
+		"f()->'$'",
+		"1->'$'",
+		"MATCH (a)",
+		"MATCH () AGAINST (?)",
+		"MATCH (a) AGAINST (? IN MODE)",
+		"MATCH (a) AGAINST (? IN NATURAL MODE)",
+		"MATCH (a) AGAINST (? WITH EXPANSION)",
+		"MATCH (a) AGAINST (? IN BOOLEAN MODE WITH QUERY EXPANSION)",
+		"INTERVAL 1",
+		"INTERVAL 1 FORTNIGHT",
+		"{d 1}",
+		"{x '2024-01-02'}",
+		"{d '2024-01-02'",
 		":",
 		":+",
 		"COUNT(",
@@ -43,9 +43,6 @@ var simpleAggConstructors = map[string]simpleAggCtor{
 	"VAR_SAMP": func(a sqlast.Expr, oc *sqlast.OverClause) sqlast.Expr {
 		return &sqlast.VarSamp{Arg: a, OverClause: oc}
 	},
-	"JSON_ARRAYAGG": func(a sqlast.Expr, oc *sqlast.OverClause) sqlast.Expr {
-		return &sqlast.JSONArrayAgg{Expr: a, OverClause: oc}
-	},
 }
 
 // distinctAggConstructors builds aggregate function nodes that take a single
@@ -94,8 +91,16 @@ func (p *Parser) parseFuncCall(name string) sqlast.Expr {
 		return p.parseLagLeadCall(upper == "LEAD")
 	case "FIRST_VALUE", "LAST_VALUE":
 		return p.parseFirstOrLastValueCall(upper == "LAST_VALUE")
+	case "JSON_ARRAYAGG":
+		return p.parseJSONArrayAggCall()
 	case "JSON_OBJECTAGG":
 		return p.parseJSONObjectAggCall()
+	case "GROUP_CONCAT":
+		return p.parseGroupConcatCall()
+	case "CAST":
+		return p.parseCastCall()
+	case "CONVERT":
+		return p.parseConvertCall()
 	default:
 		return p.parseMappedOrGenericFuncCall(upper, name)
 	}
@@ -233,6 +238,20 @@ func (p *Parser) parseOptionalLagLeadArgs() (sqlast.Expr, sqlast.Expr) {
 	return n, p.parseExpr()
 }
 
+// parseJSONArrayAggCall parses the rest of JSON_ARRAYAGG(expr [ORDER BY ...])
+// [over_clause] after '('. The ORDER BY is parsed as GROUP_CONCAT's is.
+func (p *Parser) parseJSONArrayAggCall() sqlast.Expr {
+	agg := &sqlast.JSONArrayAgg{Expr: p.parseExpr()}
+
+	agg.OrderBy = p.parseOptionalOrderBy(nil)
+
+	p.expect(RPAREN)
+
+	agg.OverClause = p.parseOptionalOverClause()
+
+	return agg
+}
+
 func (p *Parser) parseJSONObjectAggCall() sqlast.Expr {
 	key := p.parseExpr()
 	p.expect(COMMA)
@@ -243,6 +262,78 @@ func (p *Parser) parseJSONObjectAggCall() sqlast.Expr {
 	return &sqlast.JSONObjectAgg{Key: key, Value: val, OverClause: p.parseOptionalOverClause()}
 }
 
+// parseGroupConcatCall parses the rest of GROUP_CONCAT([DISTINCT] expr, ...
+// [ORDER BY ...] [SEPARATOR str]) [over_clause] after '('.
+func (p *Parser) parseGroupConcatCall() sqlast.Expr {
+	gc := &sqlast.GroupConcat{Distinct: p.consumeDistinct(), Args: p.parseExprList()}
+
+	gc.OrderBy = p.parseOptionalOrderBy(nil)
+
+	if p.consumeWord("SEPARATOR") {
+		gc.Separator = p.parseRequiredStringLiteral("after SEPARATOR")
+	}
+
+	p.expect(RPAREN)
+
+	gc.OverClause = p.parseOptionalOverClause()
+
+	return gc
+}
+
+// parseCastCall parses the rest of CAST(expr AS type [ARRAY]) after '('.
+func (p *Parser) parseCastCall() sqlast.Expr {
+	expr := p.parseExpr()
+	p.expect(AS)
+
+	cast := &sqlast.CastExpr{Expr: expr, Type: p.parseCastType()}
+
+	cast.Array = p.consumeWord("ARRAY")
+
+	p.expect(RPAREN)
+
+	return cast
+}
+
+// parseConvertCall parses the rest of CONVERT(expr, type) or
+// CONVERT(expr USING charset) after '('.
+func (p *Parser) parseConvertCall() sqlast.Expr {
+	conv := &sqlast.ConvertExpr{Expr: p.parseExpr()}
+
+	if p.consume(USING) {
+		conv.Charset = p.readIdent()
+	} else {
+		p.expect(COMMA)
+
+		typ := p.parseCastType()
+		conv.Type = &typ
+	}
+
+	p.expect(RPAREN)
+
+	return conv
+}
+
+// parseCastType parses the target type of a CAST or CONVERT. It is a DDL
+// data type (parseDataType), except for SIGNED/UNSIGNED [INTEGER|INT], which
+// only appear here. Their words are keywords rather than a type name, so
+// Name holds them uppercased, like every other keyword, with INTEGER and
+// INT kept apart.
+func (p *Parser) parseCastType() sqlast.DataType {
+	if !p.at(UNSIGNED) && !p.atWord("SIGNED") {
+		return p.parseDataType()
+	}
+
+	name := strings.ToUpper(p.tok.Literal)
+	p.advance()
+
+	if p.atWord("INTEGER") || p.atWord("INT") {
+		name += " " + strings.ToUpper(p.tok.Literal)
+		p.advance()
+	}
+
+	return sqlast.DataType{Name: name}
+}
+
 func (p *Parser) parseOptionalNullTreatment() *sqlast.NullTreatmentClause {
 	switch {
 	case p.consume(RESPECT):
@@ -294,9 +385,17 @@ func (p *Parser) parseOptionalOverClause() *sqlast.OverClause {
 	return &sqlast.OverClause{WindowSpec: spec}
 }
 
+// parseWindowSpecification parses the body of an OVER (...) clause or a
+// named window definition: an optional inherited window name, then the
+// optional PARTITION BY, ORDER BY, and frame clauses.
 func (p *Parser) parseWindowSpecification() *sqlast.WindowSpecification {
 	spec := &sqlast.WindowSpecification{}
 
+	if p.at(IDENT) {
+		spec.Name = sqlast.ColIdent(p.tok.Literal)
+		p.advance()
+	}
+
 	if p.consume(PARTITION) {
 		p.expect(BY)
 
@@ -358,6 +457,9 @@ func (p *Parser) parseUnboundedFramePoint() *sqlast.FramePoint {
 	}
 }
 
+// parseExprFramePoint parses an "expr PRECEDING|FOLLOWING" frame point. expr
+// is usually an unsigned integer, or an INTERVAL expression for a RANGE frame
+// over a temporal column; parsePrimaryExpr handles both.
 func (p *Parser) parseExprFramePoint() *sqlast.FramePoint {
 	expr := p.parseAdditiveExpr()
 
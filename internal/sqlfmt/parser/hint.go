@@ -0,0 +1,336 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+// optimizerHintParsers maps each optimizer hint MySQL knows, by its
+// upper-cased name, to the method parsing its arguments between the
+// parentheses.
+var optimizerHintParsers = map[string]func(*Parser, string) sqlast.OptimizerHint{
+	"BKA":                           (*Parser).parseTableLevelHint,
+	"NO_BKA":                        (*Parser).parseTableLevelHint,
+	"BNL":                           (*Parser).parseTableLevelHint,
+	"NO_BNL":                        (*Parser).parseTableLevelHint,
+	"DERIVED_CONDITION_PUSHDOWN":    (*Parser).parseTableLevelHint,
+	"NO_DERIVED_CONDITION_PUSHDOWN": (*Parser).parseTableLevelHint,
+	"HASH_JOIN":                     (*Parser).parseTableLevelHint,
+	"NO_HASH_JOIN":                  (*Parser).parseTableLevelHint,
+	"MERGE":                         (*Parser).parseTableLevelHint,
+	"NO_MERGE":                      (*Parser).parseTableLevelHint,
+	"JOIN_FIXED_ORDER":              (*Parser).parseTableLevelHint,
+	"JOIN_ORDER":                    (*Parser).parseTableLevelHint,
+	"JOIN_PREFIX":                   (*Parser).parseTableLevelHint,
+	"JOIN_SUFFIX":                   (*Parser).parseTableLevelHint,
+	"GROUP_INDEX":                   (*Parser).parseIndexLevelHint,
+	"NO_GROUP_INDEX":                (*Parser).parseIndexLevelHint,
+	"INDEX":                         (*Parser).parseIndexLevelHint,
+	"NO_INDEX":                      (*Parser).parseIndexLevelHint,
+	"INDEX_MERGE":                   (*Parser).parseIndexLevelHint,
+	"NO_INDEX_MERGE":                (*Parser).parseIndexLevelHint,
+	"JOIN_INDEX":                    (*Parser).parseIndexLevelHint,
+	"NO_JOIN_INDEX":                 (*Parser).parseIndexLevelHint,
+	"MRR":                           (*Parser).parseIndexLevelHint,
+	"NO_MRR":                        (*Parser).parseIndexLevelHint,
+	"NO_ICP":                        (*Parser).parseIndexLevelHint,
+	"NO_RANGE_OPTIMIZATION":         (*Parser).parseIndexLevelHint,
+	"ORDER_INDEX":                   (*Parser).parseIndexLevelHint,
+	"NO_ORDER_INDEX":                (*Parser).parseIndexLevelHint,
+	"SKIP_SCAN":                     (*Parser).parseIndexLevelHint,
+	"NO_SKIP_SCAN":                  (*Parser).parseIndexLevelHint,
+	"SEMIJOIN":                      (*Parser).parseSubqueryHint,
+	"NO_SEMIJOIN":                   (*Parser).parseSubqueryHint,
+	"SUBQUERY":                      (*Parser).parseSubqueryHint,
+	"MAX_EXECUTION_TIME":            (*Parser).parseMaxExecutionTimeHint,
+	"SET_VAR":                       (*Parser).parseSetVarHint,
+	"RESOURCE_GROUP":                (*Parser).parseResourceGroupHint,
+	"QB_NAME":                       (*Parser).parseQBNameHint,
+}
+
+// subqueryHintStrategies is the set of strategies each subquery hint
+// accepts.
+var subqueryHintStrategies = map[string]map[string]bool{
+	"SEMIJOIN":    semijoinStrategies,
+	"NO_SEMIJOIN": semijoinStrategies,
+	"SUBQUERY":    {"INTOEXISTS": true, "MATERIALIZATION": true},
+}
+
+var semijoinStrategies = map[string]bool{
+	"DUPSWEEDOUT":     true,
+	"FIRSTMATCH":      true,
+	"LOOSESCAN":       true,
+	"MATERIALIZATION": true,
+}
+
+// parseOptionalHintComments parses the comments following a SELECT,
+// INSERT, REPLACE, UPDATE, or DELETE keyword: any /*! ... */ executable
+// comments, kept whole, and at most one /*+ ... */ optimizer hint comment,
+// the most MySQL reads for a query block.
+func (p *Parser) parseOptionalHintComments() sqlast.HintComments {
+	var comments sqlast.HintComments
+
+	seenHints := false
+
+	for {
+		switch {
+		case p.at(ExecComment):
+			comments = append(comments, &sqlast.ExecutableComment{Text: p.tok.Literal})
+			p.advance()
+		case p.at(HintBegin):
+			if seenHints {
+				p.failf("duplicate optimizer hint comment")
+			}
+
+			seenHints = true
+
+			comments = append(comments, p.parseOptimizerHints())
+		default:
+			return comments
+		}
+	}
+}
+
+// parseOptimizerHints parses a /*+ ... */ optimizer hint comment: one or
+// more whitespace-separated hints.
+func (p *Parser) parseOptimizerHints() sqlast.OptimizerHints {
+	p.expect(HintBegin)
+
+	hints := sqlast.OptimizerHints{p.parseOptimizerHint()}
+
+	for !p.consume(HintEnd) {
+		hints = append(hints, p.parseOptimizerHint())
+	}
+
+	return hints
+}
+
+// parseOptimizerHint parses a single hint, NAME(args). The name is matched
+// case-insensitively against optimizerHintParsers; an unknown one is kept
+// as written (see parseUnknownHint).
+func (p *Parser) parseOptimizerHint() sqlast.OptimizerHint {
+	if !p.at(IDENT) && !p.tok.Type.IsKeyword() {
+		p.failf("expected optimizer hint, got %s", p.tok.Type)
+	}
+
+	name := strings.ToUpper(p.tok.Literal)
+
+	parse, ok := optimizerHintParsers[name]
+	if !ok {
+		return p.parseUnknownHint()
+	}
+
+	p.advance()
+	p.expect(LPAREN)
+
+	hint := parse(p, name)
+
+	p.expect(RPAREN)
+
+	return hint
+}
+
+// parseUnknownHint parses a hint MySQL doesn't know into an UnknownHint
+// holding its source text: the name and, if a '(' follows, everything up to
+// the matching ')'. MySQL ignores such a hint, so its arguments are only
+// required to have balanced parentheses.
+func (p *Parser) parseUnknownHint() sqlast.OptimizerHint {
+	name := p.tok.Literal
+	start := p.tok.Pos.Offset
+
+	p.advance()
+
+	if !p.at(LPAREN) {
+		return &sqlast.UnknownHint{Text: name}
+	}
+
+	for depth := 0; ; p.advance() {
+		switch {
+		case p.at(LPAREN):
+			depth++
+		case p.at(RPAREN):
+			depth--
+		case p.at(HintEnd) || p.at(EOF):
+			p.failf("unterminated arguments of optimizer hint %s", name)
+		}
+
+		if depth == 0 {
+			end := p.tok.Pos.Offset + len(")")
+			p.advance()
+
+			return &sqlast.UnknownHint{Text: p.lex.input[start:end]}
+		}
+	}
+}
+
+// parseOptionalHintQueryBlock parses an optional @query_block reference,
+// returning its name without the '@', or "" if there isn't one.
+func (p *Parser) parseOptionalHintQueryBlock() string {
+	if !p.at(AtVariable) {
+		return ""
+	}
+
+	name := p.tok.Literal
+	p.advance()
+
+	return name
+}
+
+// parseHintTable parses a table named in a hint, tbl or tbl@query_block.
+func (p *Parser) parseHintTable() sqlast.HintTable {
+	name := sqlast.TableIdent(p.readIdent())
+
+	return sqlast.HintTable{Name: name, QueryBlock: p.parseOptionalHintQueryBlock()}
+}
+
+// parseTableLevelHint parses a table-level or join-order hint's arguments:
+// [@query_block] [tbl [, tbl] ...].
+func (p *Parser) parseTableLevelHint(name string) sqlast.OptimizerHint {
+	hint := &sqlast.TableLevelHint{Name: name, QueryBlock: p.parseOptionalHintQueryBlock()}
+
+	if p.at(RPAREN) {
+		return hint
+	}
+
+	for {
+		hint.Tables = append(hint.Tables, p.parseHintTable())
+
+		if !p.consume(COMMA) {
+			return hint
+		}
+	}
+}
+
+// parseIndexLevelHint parses an index-level hint's arguments:
+// [@query_block] tbl [index [, index] ...].
+func (p *Parser) parseIndexLevelHint(name string) sqlast.OptimizerHint {
+	hint := &sqlast.IndexLevelHint{Name: name, QueryBlock: p.parseOptionalHintQueryBlock()}
+	hint.Table = p.parseHintTable()
+
+	if p.at(RPAREN) {
+		return hint
+	}
+
+	for {
+		hint.Indexes = append(hint.Indexes, p.parseHintIndexName())
+
+		if !p.consume(COMMA) {
+			return hint
+		}
+	}
+}
+
+// parseHintIndexName parses an index name in an index-level hint, which,
+// unlike an ordinary identifier, may be PRIMARY.
+func (p *Parser) parseHintIndexName() sqlast.ColIdent {
+	if p.consume(PRIMARY) {
+		return "PRIMARY"
+	}
+
+	return sqlast.ColIdent(p.readIdent())
+}
+
+// parseSubqueryHint parses a SEMIJOIN, NO_SEMIJOIN, or SUBQUERY hint's
+// arguments: [@query_block] [strategy [, strategy] ...], where SUBQUERY
+// takes exactly one strategy.
+func (p *Parser) parseSubqueryHint(name string) sqlast.OptimizerHint {
+	hint := &sqlast.SubqueryHint{Name: name, QueryBlock: p.parseOptionalHintQueryBlock()}
+
+	if p.at(RPAREN) && name != "SUBQUERY" {
+		return hint
+	}
+
+	for {
+		hint.Strategies = append(hint.Strategies, p.parseSubqueryHintStrategy(name))
+
+		if name == "SUBQUERY" || !p.consume(COMMA) {
+			return hint
+		}
+	}
+}
+
+// parseSubqueryHintStrategy parses one of the strategies the subquery hint
+// name accepts, returning it upper-cased.
+func (p *Parser) parseSubqueryHintStrategy(name string) string {
+	strategy := strings.ToUpper(p.tok.Literal)
+	if !p.at(IDENT) || !subqueryHintStrategies[name][strategy] {
+		p.failf("expected %s strategy, got %s", name, p.tok.Type)
+	}
+
+	p.advance()
+
+	return strategy
+}
+
+// parseMaxExecutionTimeHint parses MAX_EXECUTION_TIME's argument, an
+// integer number of milliseconds.
+func (p *Parser) parseMaxExecutionTimeHint(string) sqlast.OptimizerHint {
+	timeout := p.tok.Literal
+	p.expect(INT)
+
+	return &sqlast.MaxExecutionTimeHint{Timeout: timeout}
+}
+
+// parseSetVarHint parses SET_VAR's argument, name = value.
+func (p *Parser) parseSetVarHint(string) sqlast.OptimizerHint {
+	name := p.readIdent()
+
+	p.expect(EQ)
+
+	return &sqlast.SetVarHint{Name: name, Value: p.parseSetVarHintValue()}
+}
+
+// parseSetVarHintValue parses the value SET_VAR assigns, returning its SQL
+// text: a string, a keyword or identifier such as ON, or a number, which
+// MySQL lets carry a K/M/G size suffix with no space before it (16M). The
+// lexer reads such a number as an INT followed by an IDENT, so the two are
+// rejoined here when they're adjacent.
+func (p *Parser) parseSetVarHintValue() string {
+	switch {
+	case p.at(STRING):
+		return p.parseStringLiteral().String()
+	case p.at(INT) || p.at(FLOAT):
+		num := p.tok.Literal
+		end := p.tok.Pos.Offset + len(num)
+		p.advance()
+
+		return num + p.parseOptionalSizeSuffix(end)
+	case p.at(IDENT) || p.tok.Type.IsKeyword():
+		value := p.tok.Literal
+		p.advance()
+
+		return value
+	default:
+		return failReturn[string](p, "expected SET_VAR value, got %s", p.tok.Type)
+	}
+}
+
+// parseOptionalSizeSuffix parses a K, M, or G size suffix if one starts at
+// byte offset end, right after a number, returning "" if there isn't one.
+func (p *Parser) parseOptionalSizeSuffix(end int) string {
+	if !p.at(IDENT) || p.tok.Pos.Offset != end {
+		return ""
+	}
+
+	switch suffix := p.tok.Literal; strings.ToUpper(suffix) {
+	case "K", "M", "G":
+		p.advance()
+
+		return suffix
+	default:
+		return ""
+	}
+}
+
+// parseResourceGroupHint parses RESOURCE_GROUP's argument, a resource group
+// name.
+func (p *Parser) parseResourceGroupHint(string) sqlast.OptimizerHint {
+	return &sqlast.ResourceGroupHint{Name: p.readIdent()}
+}
+
+// parseQBNameHint parses QB_NAME's argument, the name it gives its query
+// block.
+func (p *Parser) parseQBNameHint(string) sqlast.OptimizerHint {
+	return &sqlast.QBNameHint{Name: p.readIdent()}
+}
@@ -0,0 +1,248 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+func TestParseOptimizerHints(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{
+			"max execution time",
+			"SELECT /*+ MAX_EXECUTION_TIME(1000) */ * FROM t",
+			"SELECT /*+ MAX_EXECUTION_TIME(1000) */ * FROM t",
+		},
+		{
+			"hint names are case-insensitive",
+			"SELECT /*+ max_execution_time(1000) no_merge() */ 1",
+			"SELECT /*+ MAX_EXECUTION_TIME(1000) NO_MERGE() */ 1",
+		},
+		{
+			"table-level hint with query block",
+			"SELECT /*+ BKA(@qb1 t1, t2) */ 1",
+			"SELECT /*+ BKA(@qb1 t1, t2) */ 1",
+		},
+		{
+			"table-level hint with qualified tables",
+			"SELECT /*+ NO_BNL(t1@qb1, t2@qb2) */ 1",
+			"SELECT /*+ NO_BNL(t1@qb1, t2@qb2) */ 1",
+		},
+		{"table-level hint with only a query block", "SELECT /*+ MERGE(@qb1) */ 1", "SELECT /*+ MERGE(@qb1) */ 1"},
+		{"join-order hint", "SELECT /*+ JOIN_ORDER(t2, t1) */ 1", "SELECT /*+ JOIN_ORDER(t2, t1) */ 1"},
+		{"index-level hint", "SELECT /*+ INDEX(t idx1, idx2) */ 1", "SELECT /*+ INDEX(t idx1, idx2) */ 1"},
+		{"index-level hint on primary", "SELECT /*+ NO_INDEX(t PRIMARY) */ 1", "SELECT /*+ NO_INDEX(t PRIMARY) */ 1"},
+		{"index-level hint without indexes", "SELECT /*+ NO_ICP(t1@qb1) */ 1", "SELECT /*+ NO_ICP(t1@qb1) */ 1"},
+		{
+			"index-level hint with query block",
+			"SELECT /*+ SKIP_SCAN(@qb1 t idx) */ 1",
+			"SELECT /*+ SKIP_SCAN(@qb1 t idx) */ 1",
+		},
+		{
+			"semijoin strategies",
+			"SELECT /*+ SEMIJOIN(@qb1 firstmatch, LooseScan) */ 1",
+			"SELECT /*+ SEMIJOIN(@qb1 FIRSTMATCH, LOOSESCAN) */ 1",
+		},
+		{"semijoin without strategies", "SELECT /*+ NO_SEMIJOIN() */ 1", "SELECT /*+ NO_SEMIJOIN() */ 1"},
+		{"subquery strategy", "SELECT /*+ SUBQUERY(MATERIALIZATION) */ 1", "SELECT /*+ SUBQUERY(MATERIALIZATION) */ 1"},
+		{
+			"set var with size suffix",
+			"SELECT /*+ SET_VAR(sort_buffer_size=16M) */ 1",
+			"SELECT /*+ SET_VAR(sort_buffer_size = 16M) */ 1",
+		},
+		{
+			"set var with keyword and string values",
+			"SELECT /*+ SET_VAR(foreign_key_checks = OFF) SET_VAR(optimizer_switch = 'mrr=on') */ 1",
+			"SELECT /*+ SET_VAR(foreign_key_checks = OFF) SET_VAR(optimizer_switch = 'mrr=on') */ 1",
+		},
+		{
+			"resource group and query block name",
+			"SELECT /*+ RESOURCE_GROUP(batch) QB_NAME(qb1) */ 1",
+			"SELECT /*+ RESOURCE_GROUP(batch) QB_NAME(qb1) */ 1",
+		},
+		{
+			"unknown hints kept as written",
+			"SELECT /*+ UNKNOWN_HINT(x y  z) BKA(t) no_args Other((a, 'b)') c) */ 1",
+			"SELECT /*+ UNKNOWN_HINT(x y  z) BKA(t) no_args Other((a, 'b)') c) */ 1",
+		},
+		{
+			"hints before select modifiers",
+			"SELECT /*+ BKA(t) */ DISTINCT SQL_NO_CACHE a FROM t",
+			"SELECT /*+ BKA(t) */ DISTINCT SQL_NO_CACHE a FROM t",
+		},
+		{
+			"hints in a subquery's query block",
+			"SELECT a FROM t WHERE a IN (SELECT /*+ QB_NAME(sub) */ b FROM u)",
+			"SELECT a FROM t WHERE a IN (SELECT /*+ QB_NAME(sub) */ b FROM u)",
+		},
+		{
+			"insert",
+			"INSERT /*+ SET_VAR(foreign_key_checks = OFF) */ IGNORE INTO t VALUES (1)",
+			"INSERT /*+ SET_VAR(foreign_key_checks = OFF) */ IGNORE INTO t VALUES (1)",
+		},
+		{
+			"replace",
+			"REPLACE /*+ MAX_EXECUTION_TIME(10) */ INTO t VALUES (1)",
+			"REPLACE /*+ MAX_EXECUTION_TIME(10) */ INTO t VALUES (1)",
+		},
+		{
+			"update",
+			"UPDATE /*+ NO_MERGE(t) */ t SET a = 1",
+			"UPDATE /*+ NO_MERGE(t) */ t SET a = 1",
+		},
+		{
+			"delete",
+			"DELETE /*+ BKA(t) */ FROM t WHERE a = 1",
+			"DELETE /*+ BKA(t) */ FROM t WHERE a = 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := parser.ParseStatement(tt.in)
+			if err != nil {
+				t.Fatalf("ParseStatement(%q) error = %v", tt.in, err)
+			}
+
+			if got := stmt.String(); got != tt.want {
+				t.Errorf("ParseStatement(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOptimizerHints_structure(t *testing.T) {
+	sel, err := parser.ParseSelect("SELECT /*+ INDEX(@qb t1@qb2 idx) */ 1")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	if len(sel.Hints) != 1 {
+		t.Fatalf("len(Hints) = %d, want 1", len(sel.Hints))
+	}
+
+	hints, ok := sel.Hints[0].(sqlast.OptimizerHints)
+	if !ok || len(hints) != 1 {
+		t.Fatalf("Hints[0] = %#v, want one OptimizerHints entry", sel.Hints[0])
+	}
+
+	hint, ok := hints[0].(*sqlast.IndexLevelHint)
+	if !ok {
+		t.Fatalf("hint = %T, want *sqlast.IndexLevelHint", hints[0])
+	}
+
+	want := sqlast.HintTable{Name: "t1", QueryBlock: "qb2"}
+	if hint.Name != "INDEX" || hint.QueryBlock != "qb" || hint.Table != want || len(hint.Indexes) != 1 {
+		t.Errorf("hint = %+v, want INDEX on t1@qb2 in query block qb with one index", hint)
+	}
+}
+
+func TestParseOptimizerHints_unknownHint(t *testing.T) {
+	sel, err := parser.ParseSelect("SELECT /*+ UNKNOWN_HINT(x y z) */ 1")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	hints, ok := sel.Hints[0].(sqlast.OptimizerHints)
+	if !ok || len(hints) != 1 {
+		t.Fatalf("Hints[0] = %#v, want one OptimizerHints entry", sel.Hints[0])
+	}
+
+	if hint, ok := hints[0].(*sqlast.UnknownHint); !ok || hint.Text != "UNKNOWN_HINT(x y z)" {
+		t.Errorf("hint = %#v, want UnknownHint UNKNOWN_HINT(x y z)", hints[0])
+	}
+}
+
+func TestParseExecutableComments(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{
+			"kept verbatim",
+			"SELECT /*!40001   sql_no_cache */ a FROM t",
+			"SELECT /*!40001   sql_no_cache */ a FROM t",
+		},
+		{
+			"without a version",
+			"DELETE /*! QUICK */ FROM t",
+			"DELETE /*! QUICK */ FROM t",
+		},
+		{
+			"alongside an optimizer hint comment, in order",
+			"SELECT /*!40001 SQL_NO_CACHE */ /*+ BKA(t) */ /*!50700 HIGH_PRIORITY */ 1",
+			"SELECT /*!40001 SQL_NO_CACHE */ /*+ BKA(t) */ /*!50700 HIGH_PRIORITY */ 1",
+		},
+		{"a whole statement", "/*!40101 SET NAMES utf8 */;", "/*!40101 SET NAMES utf8 */"},
+		{"after an operand", "SELECT 1 /*! + 1 */", "SELECT 1 /*! + 1 */"},
+		{"after a select list item", "SELECT a /*!40001 , b */ FROM t", "SELECT a /*!40001 , b */ FROM t"},
+		{
+			"after operands inside an expression",
+			"SELECT -a /*!1*/ /*!2*/ + f(b /*!3*/) FROM t WHERE c /*!50700 OR d */ = 1",
+			"SELECT -a /*!1*/ /*!2*/ + f(b /*!3*/) FROM t WHERE c /*!50700 OR d */ = 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := parser.ParseStatement(tt.in)
+			if err != nil {
+				t.Fatalf("ParseStatement(%q) error = %v", tt.in, err)
+			}
+
+			if got := stmt.String(); got != tt.want {
+				t.Errorf("ParseStatement(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOptimizerHints_errors(t *testing.T) {
+	tests := []string{
+		"SELECT /*+ */ 1",
+		"SELECT /*+ UNKNOWN_HINT(t */ 1",
+		"SELECT /*+ UNKNOWN_HINT((t) */ 1",
+		"SELECT /*+ 1 */ 1",
+		"SELECT /*+ BKA */ 1",
+		"SELECT /*+ BKA(t1 t2) */ 1",
+		"SELECT /*+ BKA(t1), BNL(t2) */ 1",
+		"SELECT /*+ INDEX() */ 1",
+		"SELECT /*+ INDEX(t idx1 idx2) */ 1",
+		"SELECT /*+ SEMIJOIN(INTOEXISTS) */ 1",
+		"SELECT /*+ SUBQUERY() */ 1",
+		"SELECT /*+ SUBQUERY(INTOEXISTS, MATERIALIZATION) */ 1",
+		"SELECT /*+ MAX_EXECUTION_TIME(x) */ 1",
+		"SELECT /*+ SET_VAR(a) */ 1",
+		"SELECT /*+ SET_VAR(a = ) */ 1",
+		"SELECT /*+ SET_VAR(a = 16 M) */ 1",
+		"SELECT /*+ QB_NAME() */ 1",
+		"SELECT /*+ BKA(t) */ /*+ BNL(t) */ 1",
+		"SELECT a /*+ BKA(t) */ FROM t",
+		"SELECT a FROM t /*!50700 FORCE INDEX (i) */",
+		"INSERT INTO /*+ BKA(t) */ t VALUES (1)",
+	}
+
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			stmt, err := parser.ParseStatement(in)
+			if err == nil {
+				t.Fatalf("ParseStatement(%q) expected error, got nil (result: %v)", in, stmt)
+			}
+
+			if _, ok := err.(*parser.ParseError); !ok { //nolint:errorlint // asserting the concrete parse-error type
+				t.Errorf("ParseStatement(%q) error type = %T, want *parser.ParseError", in, err)
+			}
+		})
+	}
+}
+
+func TestParseOptimizerHints_lexError(t *testing.T) {
+	for _, in := range []string{"SELECT /*+ BKA(t) 1", "SELECT /*!50700 1"} {
+		_, err := parser.ParseStatement(in)
+		if _, ok := err.(*parser.LexError); !ok { //nolint:errorlint // asserting the concrete lex-error type
+			t.Errorf("ParseStatement(%q) error = %v (%T), want *parser.LexError", in, err, err)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package parser
 
-import "github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+import (
+	"slices"
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
 
 // ParseInsert parses an INSERT or REPLACE statement from input, using
 // ModeDefault.
@@ -18,13 +23,9 @@ func ParseInsertWithMode(input string, mode SQLMode) (ins *sqlast.Insert, err er
 	defer recoverParseError(&err)
 
 	p := NewParserWithMode(input, mode)
+	m := p.markComments()
 	result := p.parseInsertStatement()
-
-	p.consume(SEMICOLON)
-
-	if !p.at(EOF) {
-		p.failf("unexpected token %s after statement", p.tok.Type)
-	}
+	p.finishStatement(result, m)
 
 	return result, nil
 }
@@ -32,7 +33,7 @@ func ParseInsertWithMode(input string, mode SQLMode) (ins *sqlast.Insert, err er
 // parseInsertStatement parses an INSERT or REPLACE statement. The current
 // token must be INSERT or REPLACE.
 func (p *Parser) parseInsertStatement() *sqlast.Insert {
-	ins := &sqlast.Insert{Action: p.parseInsertAction()}
+	ins := &sqlast.Insert{Action: p.parseInsertAction(), Hints: p.parseOptionalHintComments()}
 
 	if ins.Action == sqlast.InsertAct {
 		ins.Ignore = p.consume(IGNORE)
@@ -41,16 +42,80 @@ func (p *Parser) parseInsertStatement() *sqlast.Insert {
 	p.expect(INTO)
 
 	ins.Table = p.parseTableName()
-	ins.Columns = p.parseOptionalColumnList()
-	ins.Rows = p.parseInsertRows()
+	p.parseOptionalInsertColumns(ins)
+	ins.Rows = p.parseInsertRows(&ins.Clauses)
 
 	if ins.Action == sqlast.InsertAct {
-		ins.OnDup = p.parseOptionalOnDup()
+		ins.RowAlias = p.parseOptionalRowAlias(ins.Rows)
+		ins.OnDup = p.parseOptionalOnDup(&ins.Clauses, p.valuesRewriteAlias(ins))
+
+		if p.valuesRewritten && ins.RowAlias == nil {
+			ins.RowAlias = &sqlast.RowAlias{Name: p.valuesRowAlias}
+		}
 	}
 
 	return ins
 }
 
+// parseOptionalRowAlias parses the optional `AS alias [(col, ...)]` row
+// alias following rows. MySQL only accepts one after a VALUES or SET row
+// source, and only accepts the column list after VALUES.
+func (p *Parser) parseOptionalRowAlias(rows sqlast.InsertRows) *sqlast.RowAlias {
+	if !p.at(AS) {
+		return nil
+	}
+
+	if !acceptsRowAlias(rows) {
+		return failReturn[*sqlast.RowAlias](p, "row alias is only allowed after VALUES or SET")
+	}
+
+	p.advance()
+
+	alias := &sqlast.RowAlias{Name: sqlast.TableIdent(p.readIdent())}
+
+	if _, isSet := rows.(sqlast.SetExprs); !isSet {
+		alias.Columns = p.parseOptionalColumnList()
+	}
+
+	return alias
+}
+
+// acceptsRowAlias reports whether rows is a VALUES clause, in either its
+// parenthesized or ROW(...) form, or a SET clause: the row sources MySQL
+// accepts a row alias after.
+func acceptsRowAlias(rows sqlast.InsertRows) bool {
+	switch rows.(type) {
+	case sqlast.Values, *sqlast.ValuesStatement, sqlast.SetExprs:
+		return true
+	default:
+		return false
+	}
+}
+
+// valuesRewriteAlias returns the row alias ins's VALUES(col) references
+// should be rewritten to per ParseStatementWithValuesRowAlias, or "" if the
+// rewrite is disabled or doesn't apply to ins. An INSERT that already has a
+// row alias without column aliases keeps it, and is rewritten to it.
+func (p *Parser) valuesRewriteAlias(ins *sqlast.Insert) sqlast.TableIdent {
+	if p.valuesRowAlias == "" {
+		return ""
+	}
+
+	if ins.RowAlias != nil {
+		if len(ins.RowAlias.Columns) > 0 {
+			return ""
+		}
+
+		return ins.RowAlias.Name
+	}
+
+	if !acceptsRowAlias(ins.Rows) || strings.EqualFold(p.valuesRowAlias.String(), ins.Table.Name.String()) {
+		return ""
+	}
+
+	return p.valuesRowAlias
+}
+
 // parseInsertAction parses the leading INSERT or REPLACE keyword.
 func (p *Parser) parseInsertAction() sqlast.InsertAction {
 	if p.consume(REPLACE) {
@@ -62,6 +127,30 @@ func (p *Parser) parseInsertAction() sqlast.InsertAction {
 	return sqlast.InsertAct
 }
 
+// parseOptionalInsertColumns parses the optional parenthesized column list
+// following an INSERT's table name into ins, each column claiming its
+// comments through the comma after it.
+func (p *Parser) parseOptionalInsertColumns(ins *sqlast.Insert) {
+	if !p.consume(LPAREN) {
+		return
+	}
+
+	var comments []sqlast.Comments
+
+	for more := true; more; {
+		m := p.markComments()
+		ins.Columns = append(ins.Columns, sqlast.ColIdent(p.readIdent()))
+		more = p.consume(COMMA)
+		comments = append(comments, p.claimComments(m))
+	}
+
+	p.expect(RPAREN)
+
+	if slices.ContainsFunc(comments, func(c sqlast.Comments) bool { return !c.IsEmpty() }) {
+		ins.ColumnComments = comments
+	}
+}
+
 // parseOptionalColumnList parses an optional parenthesized column list
 // following the target table name.
 func (p *Parser) parseOptionalColumnList() sqlast.Columns {
@@ -84,30 +173,42 @@ func (p *Parser) parseColumnList() sqlast.Columns {
 	return cols
 }
 
-// parseInsertRows parses the VALUES/SET/SELECT form of an INSERT statement's
-// row source. The SELECT form also accepts a UNION of SELECT branches (with
-// an optional leading WITH clause), since INSERT INTO ... SELECT ... UNION
-// SELECT ... is valid MySQL.
-func (p *Parser) parseInsertRows() sqlast.InsertRows {
+// parseInsertRows parses the VALUES/SET/SELECT/TABLE form of an INSERT
+// statement's row source. VALUES takes either parenthesized rows or ROW(...)
+// table value constructors. The SELECT form also accepts a UNION of SELECT
+// branches (with an optional leading WITH clause), since INSERT INTO ...
+// SELECT ... UNION SELECT ... is valid MySQL. The comments around a VALUES
+// or SET keyword are recorded in clauses, or for VALUES ROW(...) in the
+// ValuesStatement's own.
+func (p *Parser) parseInsertRows(clauses *sqlast.ClauseComments) sqlast.InsertRows {
 	switch {
+	case p.at(VALUES) && p.peekAt(ROW):
+		vs := &sqlast.ValuesStatement{}
+		p.consumeClause(&vs.Clauses, "VALUES", VALUES)
+		vs.Rows = p.parseRowConstructors(true)
+
+		return vs
 	case p.at(VALUES):
-		return p.parseValuesRows()
+		return p.parseValuesRows(clauses)
 	case p.at(SET):
-		return p.parseSetRows()
+		return p.parseSetRows(clauses)
 	case p.at(SELECT) || p.at(WITH):
 		with := p.parseOptionalWith()
 		stmt := p.parseSelectOrUnionAfterWith(with)
+		p.rejectSelectInto(stmt, "an INSERT row source")
 
 		return stmt.(sqlast.InsertRows) //nolint:forcetypeassert // *sqlast.Select/*sqlast.Union both implement InsertRows
+	case p.at(TABLE):
+		return p.parseTableStatement()
 	default:
-		return failReturn[sqlast.InsertRows](p, "expected VALUES, SET, or SELECT, got %s", p.tok.Type)
+		return failReturn[sqlast.InsertRows](p, "expected VALUES, SET, SELECT, or TABLE, got %s", p.tok.Type)
 	}
 }
 
 // parseValuesRows parses a VALUES clause: one or more parenthesized,
 // comma-separated expression lists. The current token must be VALUES.
-func (p *Parser) parseValuesRows() sqlast.Values {
-	p.advance()
+func (p *Parser) parseValuesRows(clauses *sqlast.ClauseComments) sqlast.Values {
+	p.consumeClause(clauses, "VALUES", VALUES)
 
 	var rows sqlast.Values
 
@@ -126,26 +227,26 @@ func (p *Parser) parseValuesRows() sqlast.Values {
 
 // parseSetRows parses a SET clause: a comma-separated list of "col = expr"
 // assignments. The current token must be SET.
-func (p *Parser) parseSetRows() sqlast.SetExprs {
-	p.advance()
+func (p *Parser) parseSetRows(clauses *sqlast.ClauseComments) sqlast.SetExprs {
+	p.consumeClause(clauses, "SET", SET)
 
 	return sqlast.SetExprs(p.parseSetExprList())
 }
 
 // parseOptionalOnDup parses an optional trailing ON DUPLICATE KEY UPDATE
-// clause.
-func (p *Parser) parseOptionalOnDup() sqlast.OnDup {
-	if !p.consume(ON) {
+// clause, rewriting its VALUES(col) references to rowAlias.col unless
+// rowAlias is "". The comments around its keywords are recorded in clauses.
+func (p *Parser) parseOptionalOnDup(clauses *sqlast.ClauseComments, rowAlias sqlast.TableIdent) sqlast.OnDup {
+	if !p.consumeClause(clauses, "ON DUPLICATE KEY UPDATE", ON, DUPLICATE, KEY, UPDATE) {
 		return nil
 	}
 
-	p.expect(DUPLICATE)
-	p.expect(KEY)
-	p.expect(UPDATE)
-
 	p.inOnDupUpdate = true
+	p.onDupRowAlias = rowAlias
+	p.valuesRewritten = false
 	exprs := p.parseSetExprList()
 	p.inOnDupUpdate = false
+	p.onDupRowAlias = ""
 
 	return sqlast.OnDup(exprs)
 }
@@ -60,6 +60,16 @@ func TestParseInsert_basic(t *testing.T) {
 			"INSERT INTO t (a) VALUES (1 + 2)",
 			"INSERT INTO t (a) VALUES (1 + 2)",
 		},
+		{
+			"row constructors",
+			"INSERT INTO t (a, b) VALUES ROW(1, 2), ROW(3, 4)",
+			"INSERT INTO t (a, b) VALUES ROW(1, 2), ROW(3, 4)",
+		},
+		{
+			"empty row constructor",
+			"INSERT INTO t VALUES ROW()",
+			"INSERT INTO t VALUES ROW()",
+		},
 	}
 
 	for _, tt := range tests {
@@ -168,6 +178,110 @@ func TestParseInsert_onDuplicateKeyUpdate(t *testing.T) {
 	}
 }
 
+func TestParseInsert_rowAlias(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{
+			"values with column aliases",
+			"INSERT INTO t (a, b) VALUES (?, ?) AS new (x, y) ON DUPLICATE KEY UPDATE b = new.y",
+			"INSERT INTO t (a, b) VALUES (?, ?) AS new (x, y) ON DUPLICATE KEY UPDATE b = new.y",
+		},
+		{
+			"values without column aliases",
+			"insert into t (a, b) values (1, 2), (3, 4) as new on duplicate key update b = new.b",
+			"INSERT INTO t (a, b) VALUES (1, 2), (3, 4) AS new ON DUPLICATE KEY UPDATE b = new.b",
+		},
+		{
+			"row constructors",
+			"INSERT INTO t VALUES ROW(1, 2) AS new ON DUPLICATE KEY UPDATE b = new.b",
+			"INSERT INTO t VALUES ROW(1, 2) AS new ON DUPLICATE KEY UPDATE b = new.b",
+		},
+		{
+			"set",
+			"INSERT INTO t SET a = 1, b = 2 AS new ON DUPLICATE KEY UPDATE b = new.b",
+			"INSERT INTO t SET a = 1, b = 2 AS new ON DUPLICATE KEY UPDATE b = new.b",
+		},
+		{"without on duplicate key update", "INSERT INTO t VALUES (1) AS new", "INSERT INTO t VALUES (1) AS new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertInsertRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
+func TestParseInsert_table(t *testing.T) {
+	assertInsertRoundTrip(t, "insert into t (a, b) table u", "INSERT INTO t (a, b) TABLE u")
+	assertInsertRoundTrip(t,
+		"INSERT INTO t TABLE u ORDER BY a LIMIT 5 ON DUPLICATE KEY UPDATE b = 1",
+		"INSERT INTO t TABLE u ORDER BY a LIMIT 5 ON DUPLICATE KEY UPDATE b = 1")
+}
+
+// TestParseStatementWithValuesRowAlias verifies the VALUES(col) rewrite, and
+// that it's skipped wherever MySQL wouldn't accept the row alias.
+func TestParseStatementWithValuesRowAlias(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{
+			"values",
+			"INSERT INTO t (a, b) VALUES (1, 2) ON DUPLICATE KEY UPDATE a = VALUES(a), b = b + VALUES(b)",
+			"INSERT INTO t (a, b) VALUES (1, 2) AS new ON DUPLICATE KEY UPDATE a = new.a, b = b + new.b",
+		},
+		{
+			"set",
+			"INSERT INTO t SET a = 1 ON DUPLICATE KEY UPDATE a = VALUES(a)",
+			"INSERT INTO t SET a = 1 AS new ON DUPLICATE KEY UPDATE a = new.a",
+		},
+		{
+			"existing row alias reused",
+			"INSERT INTO t (a) VALUES (1) AS n ON DUPLICATE KEY UPDATE a = VALUES(a)",
+			"INSERT INTO t (a) VALUES (1) AS n ON DUPLICATE KEY UPDATE a = n.a",
+		},
+		{
+			"existing column aliases",
+			"INSERT INTO t (a) VALUES (1) AS n (x) ON DUPLICATE KEY UPDATE a = VALUES(a)",
+			"INSERT INTO t (a) VALUES (1) AS n (x) ON DUPLICATE KEY UPDATE a = VALUES(a)",
+		},
+		{
+			"select rows",
+			"INSERT INTO t (a) SELECT x FROM u ON DUPLICATE KEY UPDATE a = VALUES(a)",
+			"INSERT INTO t (a) SELECT x FROM u ON DUPLICATE KEY UPDATE a = VALUES(a)",
+		},
+		{
+			"table named like the alias",
+			"INSERT INTO NEW (a) VALUES (1) ON DUPLICATE KEY UPDATE a = VALUES(a)",
+			"INSERT INTO NEW (a) VALUES (1) ON DUPLICATE KEY UPDATE a = VALUES(a)",
+		},
+		{
+			"no VALUES reference",
+			"INSERT INTO t (a) VALUES (1) ON DUPLICATE KEY UPDATE a = 2",
+			"INSERT INTO t (a) VALUES (1) ON DUPLICATE KEY UPDATE a = 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := parser.ParseStatementWithValuesRowAlias(tt.in, parser.ModeDefault, "new")
+			if err != nil {
+				t.Fatalf("ParseStatementWithValuesRowAlias(%q) error = %v", tt.in, err)
+			}
+
+			if got := stmt.String(); got != tt.want {
+				t.Errorf("ParseStatementWithValuesRowAlias(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	for _, alias := range []string{"values", "`new`", "new old", "1x"} {
+		if _, err := parser.ParseStatementWithValuesRowAlias("SELECT 1", parser.ModeDefault, alias); err == nil {
+			t.Errorf("ParseStatementWithValuesRowAlias(alias %q) expected error, got nil", alias)
+		}
+	}
+}
+
 func TestParseInsert_errors(t *testing.T) {
 	tests := []string{
 		"",
@@ -181,6 +295,9 @@ func TestParseInsert_errors(t *testing.T) {
 		"INSERT INTO t (a) VALUES (",
 		"INSERT INTO t (a) VALUES (1",
 		"INSERT INTO t (a) VALUES (1)(2)",
+		"INSERT INTO t (a) VALUES ROW",
+		"INSERT INTO t (a) VALUES ROW(1), (2)",
+		"INSERT INTO t (a) VALUES ROW(1) ORDER BY a",
 		"INSERT INTO t SET",
 		"INSERT INTO t SET a",
 		"INSERT INTO t SET a =",
@@ -193,6 +310,14 @@ func TestParseInsert_errors(t *testing.T) {
 		"REPLACE",
 		"REPLACE IGNORE INTO t (a) VALUES (1)",
 		"REPLACE INTO t (a, b) VALUES (1, 2) ON DUPLICATE KEY UPDATE b = 3",
+		"REPLACE INTO t (a) VALUES (1) AS new",
+		"INSERT INTO t (a) VALUES (1) AS",
+		"INSERT INTO t (a) VALUES (1) AS new (",
+		"INSERT INTO t (a) VALUES (1) AS new (x",
+		"INSERT INTO t SET a = 1 AS new (x)",
+		"INSERT INTO t (a) SELECT x FROM u AS v AS new",
+		"INSERT INTO t TABLE u AS new",
+		"INSERT INTO t TABLE",
 
 		// Lexer-level errors positioned deep inside each clause.
 		"INSERT INTO 'unterminated",
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
 )
 
 const eof = rune(-1)
@@ -31,6 +33,12 @@ type Lexer struct {
 	line    int
 	col     int // rune column of ch, 1-based
 	mode    SQLMode
+
+	// inHint is true between a HintBegin token and its HintEnd, the only
+	// place "*/" lexes as a token of its own; hintPos is where the hint
+	// comment started.
+	inHint  bool
+	hintPos Position
 }
 
 // New creates a Lexer over input, using ModeDefault.
@@ -47,30 +55,60 @@ func NewWithMode(input string, mode SQLMode) *Lexer {
 }
 
 // Next returns the next token in the input, or an error if the input is malformed.
-// The final token before an error-free end of input has Type EOF.
+// The final token before an error-free end of input has Type EOF. Ordinary
+// comments aren't tokens: each is attached to the nearest token, as Trailing
+// if it follows the token on its line, and as the next token's Leading
+// otherwise.
 func (l *Lexer) Next() (Token, error) {
-	if err := l.skipWhitespaceAndComments(); err != nil {
+	leading, err := l.readComments(false)
+	if err != nil {
+		return Token{}, err
+	}
+
+	tok, err := l.readToken()
+	if err != nil {
 		return Token{}, err
 	}
 
+	tok.Leading = leading
+
+	if tok.Type == EOF {
+		return tok, nil
+	}
+
+	// An unterminated comment after the token is left for the next call to
+	// report, so the token itself still lexes: rewind to the comment's start.
+	saved := *l
+
+	if tok.Trailing, err = l.readComments(true); err != nil {
+		*l = saved
+		tok.Trailing = nil
+	}
+
+	return tok, nil
+}
+
+func (l *Lexer) readToken() (Token, error) {
 	pos := l.currentPos()
 
 	switch {
 	case l.ch == eof:
-		return Token{Type: EOF, Pos: pos}, nil
+		return l.readEOF(pos)
 	case isIdentStart(l.ch):
 		return l.readIdentifierOrPrefixedLiteral(pos)
-	case l.ch == '`':
+	case l.ch == '`' || (l.ch == '"' && l.mode.Has(ModeANSIQuotes)):
 		return l.readQuotedIdentToken(pos)
 	case l.ch == '@':
 		return l.readAtVariable(pos)
-	case l.ch == '\'':
+	case l.ch == '\'' || l.ch == '"':
+		quote := byte(l.ch)
+
 		lit, err := l.readString(pos)
 		if err != nil {
 			return Token{}, err
 		}
 
-		return Token{Type: STRING, Literal: lit, Pos: pos}, nil
+		return Token{Type: STRING, Literal: lit, Pos: pos, Quote: quote}, nil
 	case l.startsNumber():
 		tt, lit := l.readNumber()
 
@@ -80,19 +118,81 @@ func (l *Lexer) Next() (Token, error) {
 	}
 }
 
-// readIdentifierOrPrefixedLiteral reads an unquoted identifier/keyword, or a
-// x'..'/b'..' prefixed hex/bit string literal if l.ch starts one. l.ch must
-// satisfy isIdentStart.
+// readIdentifierOrPrefixedLiteral reads an unquoted identifier/keyword, a
+// x'..'/b'..' prefixed hex/bit string literal if l.ch starts one, or a
+// character set introducer. l.ch must satisfy isIdentStart.
+//
+// An introducer is an N immediately followed by a quote, or an identifier
+// starting with '_' (_utf8mb4) followed by a quote. MySQL allows whitespace
+// between a '_' introducer and its string (_latin1 'x'); that form is only
+// recognized for a known character set name, since `_id 'x'` is otherwise a
+// column with a string alias. The string itself is lexed as the next token.
 func (l *Lexer) readIdentifierOrPrefixedLiteral(pos Position) (Token, error) {
 	if tok, ok, err := l.tryReadPrefixedStringLiteral(pos); err != nil || ok {
 		return tok, err
 	}
 
+	if (l.ch == 'n' || l.ch == 'N') && l.peek() == '\'' {
+		lit := string(l.ch)
+		l.readChar()
+
+		return Token{Type: Introducer, Literal: lit, Pos: pos}, nil
+	}
+
 	lit := l.readIdentifier()
 
+	if strings.HasPrefix(lit, "_") && (l.ch == '\'' || l.skipIntroducerSpace(lit)) {
+		return Token{Type: Introducer, Literal: lit, Pos: pos}, nil
+	}
+
 	return Token{Type: lookupIdent(lit), Literal: lit, Pos: pos}, nil
 }
 
+// skipIntroducerSpace skips the whitespace between a '_' introducer and its
+// string literal and reports true if lit names a known character set and the
+// next non-space rune is a quote. Otherwise it consumes nothing.
+func (l *Lexer) skipIntroducerSpace(lit string) bool {
+	if !isSpace(l.ch) || !charsetNames[strings.ToLower(lit[1:])] {
+		return false
+	}
+
+	n := 1
+	for isSpace(l.peekAt(n)) {
+		n++
+	}
+
+	if l.peekAt(n) != '\'' {
+		return false
+	}
+
+	for range n {
+		l.readChar()
+	}
+
+	return true
+}
+
+// charsetNames lists the character sets MySQL accepts as a '_' introducer.
+var charsetNames = map[string]bool{
+	"armscii8": true, "ascii": true, "big5": true, "binary": true, "cp1250": true, "cp1251": true,
+	"cp1256": true, "cp1257": true, "cp850": true, "cp852": true, "cp866": true, "cp932": true,
+	"dec8": true, "eucjpms": true, "euckr": true, "gb18030": true, "gb2312": true, "gbk": true,
+	"geostd8": true, "greek": true, "hebrew": true, "hp8": true, "keybcs2": true, "koi8r": true,
+	"koi8u": true, "latin1": true, "latin2": true, "latin5": true, "latin7": true, "macce": true,
+	"macroman": true, "sjis": true, "swe7": true, "tis620": true, "ucs2": true, "ujis": true,
+	"utf16": true, "utf16le": true, "utf32": true, "utf8": true, "utf8mb3": true, "utf8mb4": true,
+}
+
+// readEOF returns the EOF token, or an error if input ended inside an
+// optimizer hint comment.
+func (l *Lexer) readEOF(pos Position) (Token, error) {
+	if l.inHint {
+		return Token{}, &LexError{Pos: l.hintPos, Msg: "unterminated optimizer hint comment"}
+	}
+
+	return Token{Type: EOF, Pos: pos}, nil
+}
+
 func (l *Lexer) currentPos() Position {
 	return Position{Offset: l.pos, Line: l.line, Column: l.col}
 }
@@ -141,31 +241,72 @@ func (l *Lexer) peekAt(n int) rune {
 
 func (l *Lexer) peek() rune { return l.peekAt(1) }
 
-func (l *Lexer) skipWhitespaceAndComments() error {
+// readComments skips whitespace, returning the comments in it. With
+// sameLine set it stops at the end of the current line, reading the
+// comments trailing the token just read; otherwise it reads up to the next
+// token, the comments leading it.
+func (l *Lexer) readComments(sameLine bool) ([]sqlast.Comment, error) {
+	var comments []sqlast.Comment
+
+	ownLine := !sameLine
+
 	for {
 		switch {
+		case l.ch == '\n' && sameLine:
+			return comments, nil
 		case isSpace(l.ch):
+			ownLine = ownLine || l.ch == '\n'
 			l.readChar()
-		case l.ch == '#':
-			l.skipLineComment()
-		case l.isDashComment():
-			l.readChar()
-			l.readChar()
-			l.skipLineComment()
-		case l.ch == '/' && l.peek() == '*':
-			if err := l.skipBlockComment(); err != nil {
-				return err
-			}
-		default:
-			return nil
+
+			continue
+		}
+
+		start := l.pos
+
+		ok, err := l.skipComment()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return comments, nil
 		}
+
+		text := strings.TrimRight(l.input[start:l.pos], " \t\r")
+		comments = append(comments, sqlast.Comment{Text: text, OwnLine: ownLine})
+		ownLine = false
 	}
 }
 
+// skipComment skips the ordinary comment starting at ch, if there is one,
+// reporting whether there was.
+func (l *Lexer) skipComment() (bool, error) {
+	switch {
+	case l.ch == '#' || l.isDashComment():
+		l.skipLineComment()
+
+		return true, nil
+	case l.atPlainBlockComment():
+		return true, l.skipBlockComment()
+	default:
+		return false, nil
+	}
+}
+
+// atPlainBlockComment reports whether a /* ... */ block comment starts at
+// ch, other than a /*+ ... */ optimizer hint or /*! ... */ executable
+// comment: those carry meaning, so they're lexed as tokens instead of being
+// skipped.
+func (l *Lexer) atPlainBlockComment() bool {
+	return l.ch == '/' && l.peek() == '*' && l.peekAt(2) != '+' && l.peekAt(2) != '!'
+}
+
 func (l *Lexer) isDashComment() bool {
 	return l.ch == '-' && l.peek() == '-' && isCommentBoundary(l.peekAt(2))
 }
 
+// skipLineComment skips a -- or # comment up to, but not including, the
+// newline ending it.
 func (l *Lexer) skipLineComment() {
 	for l.ch != '\n' && l.ch != eof {
 		l.readChar()
@@ -177,16 +318,6 @@ func (l *Lexer) skipBlockComment() error {
 	l.readChar() // consume '/'
 	l.readChar() // consume '*'
 
-	// /*+ ... */ optimizer hints and /*! ... */ (or /*!50700 ... */
-	// version-gated) executable comments carry semantic content that a
-	// plain comment does not. Discarding them like an ordinary block
-	// comment would silently drop meaning from the formatted output, so
-	// this is reported as a lex error instead: callers fall back to
-	// returning the input unchanged rather than mangling it.
-	if l.ch == '+' || l.ch == '!' {
-		return &LexError{Pos: startPos, Msg: "MySQL optimizer hints and executable comments are not supported"}
-	}
-
 	for {
 		switch {
 		case l.ch == eof:
@@ -271,9 +402,9 @@ func (l *Lexer) readPrefixedStringContent(startPos Position, tt TokenType) (stri
 	return l.input[start:l.pos], nil
 }
 
-// readQuotedIdentToken reads a backtick-quoted identifier and wraps it as a
-// QuotedIdent token, extracted from Next to keep its cyclomatic complexity
-// down.
+// readQuotedIdentToken reads a backtick-quoted identifier (or, under
+// ModeANSIQuotes, a double-quoted one) and wraps it as a QuotedIdent token,
+// extracted from Next to keep its cyclomatic complexity down.
 func (l *Lexer) readQuotedIdentToken(pos Position) (Token, error) {
 	lit, err := l.readQuotedIdent(pos)
 	if err != nil {
@@ -285,10 +416,16 @@ func (l *Lexer) readQuotedIdentToken(pos Position) (Token, error) {
 
 // readAtVariable reads a user-defined variable reference: '@' followed by an
 // identifier, e.g. @my_var. The Literal carries only the name, without the
-// leading '@'. l.ch must be '@'.
+// leading '@'. An '@' followed by a quote is instead the AT of an account
+// name such as 'app'@'%', whose quoted host is lexed as the next token.
+// l.ch must be '@'.
 func (l *Lexer) readAtVariable(startPos Position) (Token, error) {
 	l.readChar() // consume '@'
 
+	if l.ch == '\'' || l.ch == '"' || l.ch == '`' {
+		return Token{Type: AT, Literal: "@", Pos: startPos}, nil
+	}
+
 	if !isIdentStart(l.ch) {
 		return Token{}, &LexError{Pos: startPos, Msg: "expected identifier after '@'"}
 	}
@@ -306,10 +443,12 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[start:l.pos]
 }
 
-// readQuotedIdent reads a backtick-quoted identifier. A doubled backtick
-// represents a literal backtick within the identifier.
+// readQuotedIdent reads a quoted identifier, quoted by l.ch: a backtick, or
+// a double quote under ModeANSIQuotes. A doubled quote character represents
+// a literal one within the identifier.
 func (l *Lexer) readQuotedIdent(startPos Position) (string, error) {
-	l.readChar() // consume opening `
+	quote := l.ch
+	l.readChar() // consume opening quote
 
 	var sb strings.Builder
 
@@ -317,12 +456,12 @@ func (l *Lexer) readQuotedIdent(startPos Position) (string, error) {
 		switch {
 		case l.ch == eof:
 			return "", &LexError{Pos: startPos, Msg: "unterminated quoted identifier"}
-		case l.ch == '`' && l.peek() == '`':
-			sb.WriteRune('`')
+		case l.ch == quote && l.peek() == quote:
+			sb.WriteRune(quote)
 			l.readChar()
 			l.readChar()
-		case l.ch == '`':
-			l.readChar() // consume closing `
+		case l.ch == quote:
+			l.readChar() // consume closing quote
 
 			return sb.String(), nil
 		default:
@@ -332,13 +471,15 @@ func (l *Lexer) readQuotedIdent(startPos Position) (string, error) {
 	}
 }
 
-// readString reads a single-quoted string literal. It supports MySQL's
-// doubled-quote escaping unconditionally, plus backslash escape sequences
-// when l.mode is ModeDefault. Under ModeNoBackslashEscapes, backslash has no
-// special meaning and is read like any other character (falling through to
-// the default case below).
+// readString reads a string literal quoted by l.ch: a single quote, or a
+// double quote outside ModeANSIQuotes. It supports MySQL's doubled-quote
+// escaping unconditionally, plus backslash escape sequences unless l.mode
+// has ModeNoBackslashEscapes, under which backslash has no special meaning
+// and is read like any other character (falling through to the default case
+// below).
 func (l *Lexer) readString(startPos Position) (string, error) {
-	l.readChar() // consume opening '
+	quote := l.ch
+	l.readChar() // consume opening quote
 
 	var sb strings.Builder
 
@@ -346,15 +487,15 @@ func (l *Lexer) readString(startPos Position) (string, error) {
 		switch {
 		case l.ch == eof:
 			return "", &LexError{Pos: startPos, Msg: unterminatedStringMsg}
-		case l.ch == '\'' && l.peek() == '\'':
-			sb.WriteRune('\'')
+		case l.ch == quote && l.peek() == quote:
+			sb.WriteRune(quote)
 			l.readChar()
 			l.readChar()
-		case l.ch == '\'':
-			l.readChar() // consume closing '
+		case l.ch == quote:
+			l.readChar() // consume closing quote
 
 			return sb.String(), nil
-		case l.ch == '\\' && l.mode == ModeDefault:
+		case l.ch == '\\' && !l.mode.Has(ModeNoBackslashEscapes):
 			s, err := l.readEscapedString(startPos)
 			if err != nil {
 				return "", err
@@ -522,11 +663,104 @@ func (l *Lexer) readOperator(pos Position) (Token, error) {
 		return l.readGreater(pos), nil
 	case '!':
 		return l.readBang(pos)
+	case '|', '&':
+		return l.readPipeOrAmp(pos), nil
+	case ':':
+		return l.readColon(pos), nil
+	case '-':
+		return l.readMinus(pos), nil
+	case '/':
+		return l.readSlash(pos)
+	case '*':
+		return l.readStar(pos)
 	default:
 		return l.readSingleCharToken(pos)
 	}
 }
 
+// readSlash reads '/', or the "/*+" opening an optimizer hint comment, or a
+// whole /*! ... */ executable comment. readComments has already
+// skipped any plain block comment, so a "/*" here is always one of the two.
+func (l *Lexer) readSlash(pos Position) (Token, error) {
+	if l.peek() != '*' {
+		return l.readSingleCharToken(pos)
+	}
+
+	if l.peekAt(2) == '!' {
+		return l.readExecutableComment(pos)
+	}
+
+	l.readChar() // consume '/'
+	l.readChar() // consume '*'
+	l.readChar() // consume '+'
+
+	l.inHint = true
+	l.hintPos = pos
+
+	return Token{Type: HintBegin, Literal: "/*+", Pos: pos}, nil
+}
+
+// readStar reads '*', or the "*/" closing the optimizer hint comment being
+// lexed.
+func (l *Lexer) readStar(pos Position) (Token, error) {
+	if !l.inHint || l.peek() != '/' {
+		return l.readSingleCharToken(pos)
+	}
+
+	l.readChar() // consume '*'
+	l.readChar() // consume '/'
+
+	l.inHint = false
+
+	return Token{Type: HintEnd, Literal: "*/", Pos: pos}, nil
+}
+
+// readExecutableComment reads a /*! ... */ executable comment, version-gated
+// or not, as a single ExecComment token whose Literal is the comment's full
+// text. Its content is never lexed: MySQL runs it as SQL only on a server
+// new enough for its version, so it's kept exactly as written. l.ch must be
+// the comment's leading '/'.
+func (l *Lexer) readExecutableComment(pos Position) (Token, error) {
+	start := l.pos
+
+	l.readChar() // consume '/'
+	l.readChar() // consume '*'
+	l.readChar() // consume '!'
+
+	for {
+		switch {
+		case l.ch == eof:
+			return Token{}, &LexError{Pos: pos, Msg: "unterminated executable comment"}
+		case l.ch == '*' && l.peek() == '/':
+			l.readChar()
+			l.readChar()
+
+			return Token{Type: ExecComment, Literal: l.input[start:l.pos], Pos: pos}, nil
+		default:
+			l.readChar()
+		}
+	}
+}
+
+// readMinus reads '-', or the JSON path operators "->" and "->>".
+func (l *Lexer) readMinus(pos Position) Token {
+	l.readChar() // consume '-'
+
+	if l.ch != '>' {
+		return Token{Type: MINUS, Literal: "-", Pos: pos}
+	}
+
+	l.readChar()
+
+	if l.ch == '>' {
+		l.readChar()
+
+		return Token{Type: DARROW, Literal: "->>", Pos: pos}
+	}
+
+	return Token{Type: ARROW, Literal: "->", Pos: pos}
+}
+
 func (l *Lexer) readLess(pos Position) Token {
 	l.readChar() // consume '<'
 
@@ -535,6 +769,10 @@ func (l *Lexer) readLess(pos Position) Token {
 		l.readChar()
 
 		return Token{Type: NE, Literal: "<>", Pos: pos}
+	case '<':
+		l.readChar()
+
+		return Token{Type: SHL, Literal: "<<", Pos: pos}
 	case '=':
 		l.readChar()
 
@@ -553,13 +791,18 @@ func (l *Lexer) readLess(pos Position) Token {
 func (l *Lexer) readGreater(pos Position) Token {
 	l.readChar() // consume '>'
 
-	if l.ch == '=' {
+	switch l.ch {
+	case '=':
 		l.readChar()
 
 		return Token{Type: GE, Literal: ">=", Pos: pos}
-	}
+	case '>':
+		l.readChar()
 
-	return Token{Type: GT, Literal: ">", Pos: pos}
+		return Token{Type: SHR, Literal: ">>", Pos: pos}
+	default:
+		return Token{Type: GT, Literal: ">", Pos: pos}
+	}
 }
 
 func (l *Lexer) readBang(pos Position) (Token, error) {
@@ -576,6 +819,42 @@ func (l *Lexer) readBang(pos Position) (Token, error) {
 	return Token{}, &LexError{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", ch)}
 }
 
+// pipeAmpOperators maps | and & to the token types of the operator they
+// spell alone (bitwise) and doubled (logical).
+var pipeAmpOperators = map[rune][2]TokenType{
+	'|': {PIPE, PIPES},
+	'&': {AMP, AMPS},
+}
+
+// readPipeOrAmp reads |, ||, &, or &&.
+func (l *Lexer) readPipeOrAmp(pos Position) Token {
+	ch := l.ch
+	types := pipeAmpOperators[ch]
+	l.readChar()
+
+	if l.ch != ch {
+		return Token{Type: types[0], Literal: string(ch), Pos: pos}
+	}
+
+	l.readChar()
+
+	return Token{Type: types[1], Literal: string(ch) + string(ch), Pos: pos}
+}
+
+// readColon reads the := assignment operator, or a lone : (which starts a
+// :name placeholder).
+func (l *Lexer) readColon(pos Position) Token {
+	l.readChar() // consume ':'
+
+	if l.ch == '=' {
+		l.readChar()
+
+		return Token{Type: ASSIGN, Literal: ":=", Pos: pos}
+	}
+
+	return Token{Type: COLON, Literal: ":", Pos: pos}
+}
+
 func (l *Lexer) readSingleCharToken(pos Position) (Token, error) {
 	ch := l.ch
 
@@ -594,15 +873,17 @@ func (l *Lexer) readSingleCharToken(pos Position) (Token, error) {
 var singleCharTokens = map[rune]TokenType{
 	'=': EQ,
 	'+': PLUS,
-	'-': MINUS,
 	'*': STAR,
 	'/': SLASH,
 	'%': PERCENT,
+	'^': CARET,
+	'~': TILDE,
 	'(': LPAREN,
 	')': RPAREN,
+	'{': LBRACE,
+	'}': RBRACE,
 	',': COMMA,
 	'.': DOT,
-	':': COLON,
 	'?': QUESTION,
 	';': SEMICOLON,
 }
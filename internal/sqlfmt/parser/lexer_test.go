@@ -2,9 +2,11 @@ package parser_test
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
 )
 
 type wantToken struct {
@@ -174,6 +176,15 @@ func TestLexer_AtVariable(t *testing.T) {
 		}
 	})
 
+	t.Run("before a quoted account host", func(t *testing.T) {
+		assertTokens(t, "'app'@'%'", []wantToken{
+			{parser.STRING, "app"},
+			{parser.AT, "@"},
+			{parser.STRING, "%"},
+			{parser.EOF, ""},
+		})
+	})
+
 	t.Run("at-at system variable syntax is rejected", func(t *testing.T) {
 		l := parser.New("@@global.sql_mode")
 
@@ -379,6 +390,53 @@ func TestLexer_StringLiterals_NoBackslashEscapes(t *testing.T) {
 	}
 }
 
+// TestLexer_DoubleQuotes covers a double-quoted token, which is a string
+// literal by default and a quoted identifier under ModeANSIQuotes. Modes
+// combine: under ModeANSIQuotes|ModeNoBackslashEscapes a single-quoted
+// string still reads backslash as a literal character.
+func TestLexer_DoubleQuotes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		mode parser.SQLMode
+		typ  parser.TokenType
+		want string
+	}{
+		{"string by default", `"it's"`, parser.ModeDefault, parser.STRING, "it's"},
+		{"doubled quote in a string", `"a""b"`, parser.ModeDefault, parser.STRING, `a"b`},
+		{"backslash escape in a string", `"a\"b"`, parser.ModeDefault, parser.STRING, `a"b`},
+		{"identifier under ANSI_QUOTES", `"order"`, parser.ModeANSIQuotes, parser.QuotedIdent, "order"},
+		{"doubled quote in an identifier", `"a""b"`, parser.ModeANSIQuotes, parser.QuotedIdent, `a"b`},
+		{"backslash is not an escape in an identifier", `"a\b"`, parser.ModeANSIQuotes, parser.QuotedIdent, `a\b`},
+		{
+			"combined modes", `'a\nb'`, parser.ModeANSIQuotes | parser.ModeNoBackslashEscapes,
+			parser.STRING, `a\nb`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertTokensWithMode(t, tt.in, tt.mode, []wantToken{
+				{tt.typ, tt.want},
+				{parser.EOF, ""},
+			})
+		})
+	}
+}
+
+func TestLexer_StringQuote(t *testing.T) {
+	for _, in := range []string{`'a'`, `"a"`} {
+		tok, err := parser.New(in).Next()
+		if err != nil {
+			t.Fatalf("Next(%s) error = %v", in, err)
+		}
+
+		if tok.Quote != in[0] {
+			t.Errorf("Next(%s).Quote = %q, want %q", in, tok.Quote, in[0])
+		}
+	}
+}
+
 func TestLexer_PrefixedStringLiterals(t *testing.T) {
 	tests := []struct {
 		name string
@@ -418,13 +476,50 @@ func TestLexer_PrefixedStringLiterals(t *testing.T) {
 	})
 
 	t.Run("other identifier immediately followed by quote is not a prefixed literal", func(t *testing.T) {
-		assertTokens(t, "n'x'", []wantToken{
-			{parser.IDENT, "n"},
+		assertTokens(t, "a'x'", []wantToken{
+			{parser.IDENT, "a"},
 			{parser.STRING, "x"},
 			{parser.EOF, ""},
 		})
 	})
 
+	t.Run("national character set introducer", func(t *testing.T) {
+		assertTokens(t, "N'x' n'y'", []wantToken{
+			{parser.Introducer, "N"},
+			{parser.STRING, "x"},
+			{parser.Introducer, "n"},
+			{parser.STRING, "y"},
+			{parser.EOF, ""},
+		})
+	})
+
+	t.Run("character set introducer", func(t *testing.T) {
+		assertTokens(t, "_utf8mb4'x'", []wantToken{
+			{parser.Introducer, "_utf8mb4"},
+			{parser.STRING, "x"},
+			{parser.EOF, ""},
+		})
+	})
+
+	t.Run("character set introducer separated by whitespace", func(t *testing.T) {
+		assertTokens(t, "_latin1 \n 'x' _UTF8MB4 name", []wantToken{
+			{parser.Introducer, "_latin1"},
+			{parser.STRING, "x"},
+			{parser.IDENT, "_UTF8MB4"},
+			{parser.IDENT, "name"},
+			{parser.EOF, ""},
+		})
+	})
+
+	t.Run("unknown character set separated by whitespace stays an identifier", func(t *testing.T) {
+		assertTokens(t, "_col 'x' name", []wantToken{
+			{parser.IDENT, "_col"},
+			{parser.STRING, "x"},
+			{parser.IDENT, "name"},
+			{parser.EOF, ""},
+		})
+	})
+
 	t.Run("unterminated hex string", func(t *testing.T) {
 		l := parser.New("x'1A")
 		if _, err := l.Next(); err == nil {
@@ -480,6 +575,17 @@ func TestLexer_Operators(t *testing.T) {
 		{"star", "*", parser.STAR},
 		{"slash", "/", parser.SLASH},
 		{"percent", "%", parser.PERCENT},
+		{"pipes", "||", parser.PIPES},
+		{"amps", "&&", parser.AMPS},
+		{"pipe", "|", parser.PIPE},
+		{"amp", "&", parser.AMP},
+		{"caret", "^", parser.CARET},
+		{"tilde", "~", parser.TILDE},
+		{"shift left", "<<", parser.SHL},
+		{"shift right", ">>", parser.SHR},
+		{"assign", ":=", parser.ASSIGN},
+		{"arrow", "->", parser.ARROW},
+		{"double arrow", "->>", parser.DARROW},
 	}
 
 	for _, tt := range tests {
@@ -497,6 +603,25 @@ func TestLexer_Operators(t *testing.T) {
 			t.Fatal("expected error for lone '!'")
 		}
 	})
+
+	t.Run("json path operators", func(t *testing.T) {
+		assertTokens(t, "data->>'$.a' - >", []wantToken{
+			{parser.IDENT, "data"},
+			{parser.DARROW, "->>"},
+			{parser.STRING, "$.a"},
+			{parser.MINUS, "-"},
+			{parser.GT, ">"},
+			{parser.EOF, ""},
+		})
+	})
+
+	t.Run("colon not followed by equals", func(t *testing.T) {
+		assertTokens(t, ":id", []wantToken{
+			{parser.COLON, ":"},
+			{parser.IDENT, "id"},
+			{parser.EOF, ""},
+		})
+	})
 }
 
 func TestLexer_Punctuation(t *testing.T) {
@@ -507,6 +632,8 @@ func TestLexer_Punctuation(t *testing.T) {
 	}{
 		{"lparen", "(", parser.LPAREN},
 		{"rparen", ")", parser.RPAREN},
+		{"lbrace", "{", parser.LBRACE},
+		{"rbrace", "}", parser.RBRACE},
 		{"comma", ",", parser.COMMA},
 		{"dot", ".", parser.DOT},
 		{"colon", ":", parser.COLON},
@@ -616,31 +743,129 @@ func TestLexer_Comments(t *testing.T) {
 		}
 	})
 
-	t.Run("optimizer hint is rejected", func(t *testing.T) {
-		l := parser.New("SELECT /*+ MAX_EXECUTION_TIME(1000) */ 1")
-		if _, err := l.Next(); err != nil {
-			t.Fatalf("Next() error = %v, want nil for first token", err)
-		}
+	t.Run("unterminated optimizer hint comment", func(t *testing.T) {
+		l := parser.New("SELECT /*+ BKA(t1) 1")
 
-		if _, err := l.Next(); err == nil {
-			t.Fatal("expected error for /*+ ... */ optimizer hint")
+		for {
+			tok, err := l.Next()
+			if err != nil {
+				return
+			}
+
+			if tok.Type == parser.EOF {
+				t.Fatal("expected error for unterminated optimizer hint comment")
+			}
 		}
 	})
 
-	t.Run("executable comment is rejected", func(t *testing.T) {
-		l := parser.New("SELECT /*!50700 1 */")
+	t.Run("unterminated executable comment", func(t *testing.T) {
+		l := parser.New("SELECT /*!50700 1")
 		if _, err := l.Next(); err != nil {
 			t.Fatalf("Next() error = %v, want nil for first token", err)
 		}
 
 		if _, err := l.Next(); err == nil {
-			t.Fatal("expected error for /*! ... */ executable comment")
+			t.Fatal("expected error for unterminated executable comment")
 		}
 	})
 }
 
+func TestLexer_CommentTrivia(t *testing.T) {
+	l := parser.New("-- head\nid /* a */ -- b   \n# c\n, name /* d */\n/* e */")
+
+	want := []struct {
+		typ      parser.TokenType
+		leading  []sqlast.Comment
+		trailing []sqlast.Comment
+	}{
+		{
+			parser.IDENT,
+			[]sqlast.Comment{{Text: "-- head", OwnLine: true}},
+			[]sqlast.Comment{{Text: "/* a */"}, {Text: "-- b"}},
+		},
+		{parser.COMMA, []sqlast.Comment{{Text: "# c", OwnLine: true}}, nil},
+		{parser.IDENT, nil, []sqlast.Comment{{Text: "/* d */"}}},
+		{parser.EOF, []sqlast.Comment{{Text: "/* e */", OwnLine: true}}, nil},
+	}
+
+	for i, w := range want {
+		tok, err := l.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+
+		if tok.Type != w.typ || !reflect.DeepEqual(tok.Leading, w.leading) || !reflect.DeepEqual(tok.Trailing, w.trailing) {
+			t.Errorf("token %d = %s %+v %+v, want %s %+v %+v",
+				i, tok.Type, tok.Leading, tok.Trailing, w.typ, w.leading, w.trailing)
+		}
+	}
+}
+
+func TestLexer_HintAndExecutableComments(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []wantToken
+	}{
+		{
+			name: "optimizer hint comment",
+			in:   "SELECT /*+ INDEX(t1@qb idx) */ 1",
+			want: []wantToken{
+				{parser.SELECT, "SELECT"},
+				{parser.HintBegin, "/*+"},
+				{parser.INDEX, "INDEX"},
+				{parser.LPAREN, "("},
+				{parser.IDENT, "t1"},
+				{parser.AtVariable, "qb"},
+				{parser.IDENT, "idx"},
+				{parser.RPAREN, ")"},
+				{parser.HintEnd, "*/"},
+				{parser.INT, "1"},
+				{parser.EOF, ""},
+			},
+		},
+		{
+			name: "star slash outside a hint is not a hint end",
+			in:   "a */ b",
+			want: []wantToken{
+				{parser.IDENT, "a"},
+				{parser.STAR, "*"},
+				{parser.SLASH, "/"},
+				{parser.IDENT, "b"},
+				{parser.EOF, ""},
+			},
+		},
+		{
+			name: "executable comment is one token",
+			in:   "SELECT /*!40001 SQL_NO_CACHE */ 1",
+			want: []wantToken{
+				{parser.SELECT, "SELECT"},
+				{parser.ExecComment, "/*!40001 SQL_NO_CACHE */"},
+				{parser.INT, "1"},
+				{parser.EOF, ""},
+			},
+		},
+		{
+			name: "plain block comment is still skipped",
+			in:   "a /* + not a hint */ / b",
+			want: []wantToken{
+				{parser.IDENT, "a"},
+				{parser.SLASH, "/"},
+				{parser.IDENT, "b"},
+				{parser.EOF, ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertTokens(t, tt.in, tt.want)
+		})
+	}
+}
+
 func TestLexer_IllegalCharacter(t *testing.T) {
-	l := parser.New("~")
+	l := parser.New(`\`)
 
 	_, err := l.Next()
 	if err == nil {
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
 )
@@ -37,6 +38,21 @@ type Parser struct {
 	// DUPLICATE KEY UPDATE clause, the only place MySQL accepts VALUES(col)
 	// as a function call rather than treating VALUES as a keyword.
 	inOnDupUpdate bool
+
+	// valuesRowAlias is the row alias ParseStatementWithValuesRowAlias
+	// rewrites VALUES(col) references to, or "" to keep them as written.
+	valuesRowAlias sqlast.TableIdent
+
+	// onDupRowAlias is the row alias VALUES(col) is rewritten to while
+	// parsing the current ON DUPLICATE KEY UPDATE clause, or "" if the
+	// current INSERT can't take one. valuesRewritten records whether any
+	// reference was rewritten, so the INSERT only gains the alias if so.
+	onDupRowAlias   sqlast.TableIdent
+	valuesRewritten bool
+
+	// comments holds the comments of the tokens consumed so far that no
+	// node has claimed yet; see markComments.
+	comments []sqlast.Comment
 }
 
 // NewParser creates a Parser over input using ModeDefault, priming its
@@ -61,7 +77,11 @@ func NewParserWithMode(input string, mode SQLMode) *Parser {
 
 // advance consumes the current token and shifts the lookahead buffer,
 // lexing one new token into the tail slot. It panics on a lexer failure.
+// The consumed token's comments join the unclaimed ones.
 func (p *Parser) advance() {
+	p.comments = append(p.comments, p.tok.Leading...)
+	p.comments = append(p.comments, p.tok.Trailing...)
+
 	p.tok = p.peekTok
 	p.peekTok = p.peek2Tok
 
@@ -120,6 +140,33 @@ func (p *Parser) consume(tt TokenType) bool {
 	return true
 }
 
+// atWord reports whether the current token is an unquoted identifier
+// spelling word, case-insensitively. It's for the words of a construct that
+// aren't keywords, so they stay usable as ordinary identifiers elsewhere.
+func (p *Parser) atWord(word string) bool {
+	return p.at(IDENT) && strings.EqualFold(p.tok.Literal, word)
+}
+
+// consumeWord advances past the current token if atWord(word), reporting
+// whether it did so.
+func (p *Parser) consumeWord(word string) bool {
+	if !p.atWord(word) {
+		return false
+	}
+
+	p.advance()
+
+	return true
+}
+
+// expectWord verifies the current token is atWord(word) and consumes it,
+// failing if it isn't.
+func (p *Parser) expectWord(word string) {
+	if !p.consumeWord(word) {
+		p.failf("expected %s, got %s", word, p.tok.Type)
+	}
+}
+
 // recoverParseError recovers a *ParseError or *LexError panic raised by this
 // package and assigns it to *err; any other panic (a real bug, not a parse
 // error) keeps propagating.
@@ -179,13 +226,9 @@ func ParseSelectWithMode(input string, mode SQLMode) (sel *sqlast.Select, err er
 	defer recoverParseError(&err)
 
 	p := NewParserWithMode(input, mode)
+	m := p.markComments()
 	result := p.parseSelectStatement()
-
-	p.consume(SEMICOLON)
-
-	if !p.at(EOF) {
-		p.failf("unexpected token %s after statement", p.tok.Type)
-	}
+	p.finishStatement(result, m)
 
 	return result, nil
 }
@@ -0,0 +1,156 @@
+package parser
+
+import "github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+
+// ParseCall parses a CALL statement from input, using ModeDefault.
+func ParseCall(input string) (*sqlast.Call, error) {
+	return ParseCallWithMode(input, ModeDefault)
+}
+
+// ParseCallWithMode parses a CALL statement from input, decoding string
+// literals in its arguments per mode.
+func ParseCallWithMode(input string, mode SQLMode) (*sqlast.Call, error) {
+	return parseDDLEntry(input, mode, (*Parser).parseCallStatement)
+}
+
+// ParsePrepare parses a PREPARE statement from input, using ModeDefault.
+func ParsePrepare(input string) (*sqlast.Prepare, error) {
+	return ParsePrepareWithMode(input, ModeDefault)
+}
+
+// ParsePrepareWithMode parses a PREPARE statement from input, decoding the
+// statement text's string literal per mode.
+func ParsePrepareWithMode(input string, mode SQLMode) (*sqlast.Prepare, error) {
+	return parseDDLEntry(input, mode, (*Parser).parsePrepareStatement)
+}
+
+// ParseExecute parses an EXECUTE statement from input.
+func ParseExecute(input string) (*sqlast.Execute, error) {
+	return parseDDLEntry(input, ModeDefault, (*Parser).parseExecuteStatement)
+}
+
+// ParseDeallocatePrepare parses a DEALLOCATE PREPARE (or DROP PREPARE)
+// statement from input.
+func ParseDeallocatePrepare(input string) (*sqlast.DeallocatePrepare, error) {
+	return parseDDLEntry(input, ModeDefault, (*Parser).parseDeallocatePrepareStatement)
+}
+
+// ParseDo parses a DO statement from input, using ModeDefault.
+func ParseDo(input string) (*sqlast.Do, error) {
+	return ParseDoWithMode(input, ModeDefault)
+}
+
+// ParseDoWithMode parses a DO statement from input, decoding string literals
+// per mode.
+func ParseDoWithMode(input string, mode SQLMode) (*sqlast.Do, error) {
+	return parseDDLEntry(input, mode, (*Parser).parseDoStatement)
+}
+
+// parseProgramStatement dispatches a leading CALL, PREPARE, EXECUTE,
+// DEALLOCATE, or DO to its statement parser, split out of parseStatement to
+// keep that switch's cyclomatic complexity down. It reports whether the
+// current token started a recognized stored-program utility statement.
+func (p *Parser) parseProgramStatement() (sqlast.Statement, bool) {
+	switch {
+	case p.at(CALL):
+		return p.parseCallStatement(), true
+	case p.at(PREPARE):
+		return p.parsePrepareStatement(), true
+	case p.at(EXECUTE):
+		return p.parseExecuteStatement(), true
+	case p.at(DEALLOCATE):
+		return p.parseDeallocatePrepareStatement(), true
+	case p.at(DO):
+		return p.parseDoStatement(), true
+	default:
+		return nil, false
+	}
+}
+
+// parseCallStatement parses a CALL sp_name [([expr [, expr] ...])]
+// statement. The current token must be CALL.
+func (p *Parser) parseCallStatement() *sqlast.Call {
+	p.expect(CALL)
+
+	call := &sqlast.Call{Procedure: p.parseTableName()}
+
+	if p.consume(LPAREN) {
+		call.Parens = true
+
+		if !p.at(RPAREN) {
+			call.Args = p.parseExprList()
+		}
+
+		p.expect(RPAREN)
+	}
+
+	return call
+}
+
+// parsePrepareStatement parses a PREPARE stmt_name FROM preparable_stmt
+// statement, where preparable_stmt is a string literal or a user variable.
+// The statement text is kept as the string literal it was written as; the
+// formatter parses it separately. The current token must be PREPARE.
+func (p *Parser) parsePrepareStatement() *sqlast.Prepare {
+	p.expect(PREPARE)
+
+	prep := &sqlast.Prepare{Name: sqlast.TableIdent(p.readIdent())}
+
+	p.expect(FROM)
+
+	switch {
+	case p.at(STRING):
+		prep.From = p.parseStringLiteral()
+	case p.at(AtVariable):
+		prep.From = p.parseUserVariable()
+	default:
+		p.failf("expected string or user variable after FROM, got %s", p.tok.Type)
+	}
+
+	return prep
+}
+
+// parseExecuteStatement parses an EXECUTE stmt_name [USING @var [, @var]
+// ...] statement. The current token must be EXECUTE.
+func (p *Parser) parseExecuteStatement() *sqlast.Execute {
+	p.expect(EXECUTE)
+
+	exec := &sqlast.Execute{Name: sqlast.TableIdent(p.readIdent())}
+
+	if p.consume(USING) {
+		for {
+			if !p.at(AtVariable) {
+				p.failf("expected user variable in USING, got %s", p.tok.Type)
+			}
+
+			exec.Using = append(exec.Using, p.parseUserVariable())
+
+			if !p.consume(COMMA) {
+				break
+			}
+		}
+	}
+
+	return exec
+}
+
+// parseDeallocatePrepareStatement parses a {DEALLOCATE | DROP} PREPARE
+// stmt_name statement. The current token must be DEALLOCATE or DROP.
+func (p *Parser) parseDeallocatePrepareStatement() *sqlast.DeallocatePrepare {
+	drop := p.consume(DROP)
+	if !drop {
+		p.expect(DEALLOCATE)
+	}
+
+	p.expect(PREPARE)
+
+	return &sqlast.DeallocatePrepare{Drop: drop, Name: sqlast.TableIdent(p.readIdent())}
+}
+
+// parseDoStatement parses a DO expr [, expr] ... statement. The current
+// token must be DO.
+func (p *Parser) parseDoStatement() *sqlast.Do {
+	p.expect(DO)
+
+	return &sqlast.Do{Exprs: p.parseExprList()}
+}
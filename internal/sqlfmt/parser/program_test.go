@@ -0,0 +1,203 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+)
+
+func TestParseCall(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"no argument list", "CALL refresh", "CALL refresh"},
+		{"empty argument list", "call refresh()", "CALL refresh()"},
+		{"arguments", "CALL refresh_stats(?, @day)", "CALL refresh_stats(?, @day)"},
+		{"qualified", "CALL app.refresh_stats(1 + 2, 'x')", "CALL app.refresh_stats(1 + 2, 'x')"},
+		{"subquery argument", "CALL p((SELECT MAX(id) FROM t))", "CALL p((SELECT MAX(id) FROM t))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parser.ParseCall(tt.in)
+			if err != nil {
+				t.Fatalf("ParseCall(%q) error = %v", tt.in, err)
+			}
+
+			if got := c.String(); got != tt.want {
+				t.Errorf("ParseCall(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCall_errors(t *testing.T) {
+	tests := []string{
+		"CALL",
+		"CALL p(",
+		"CALL p(1,)",
+		"CALL p() extra",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseCall(in); err == nil {
+			t.Errorf("ParseCall(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParsePrepare(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"string literal", "PREPARE stmt FROM 'SELECT * FROM t WHERE id = ?'", "PREPARE stmt FROM 'SELECT * FROM t WHERE id = ?'"},
+		{"double-quoted string", `prepare stmt from "SELECT 'a'"`, `PREPARE stmt FROM "SELECT 'a'"`},
+		{"user variable", "PREPARE stmt FROM @sql", "PREPARE stmt FROM @sql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := parser.ParsePrepare(tt.in)
+			if err != nil {
+				t.Fatalf("ParsePrepare(%q) error = %v", tt.in, err)
+			}
+
+			if got := p.String(); got != tt.want {
+				t.Errorf("ParsePrepare(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePrepareWithMode_noBackslashEscapes(t *testing.T) {
+	p, err := parser.ParsePrepareWithMode(`PREPARE stmt FROM 'SELECT ''C:\'''`, parser.ModeNoBackslashEscapes)
+	if err != nil {
+		t.Fatalf("ParsePrepareWithMode error = %v", err)
+	}
+
+	if got, want := p.String(), `PREPARE stmt FROM 'SELECT ''C:\'''`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestParsePrepare_errors(t *testing.T) {
+	tests := []string{
+		"PREPARE stmt",
+		"PREPARE stmt FROM",
+		"PREPARE stmt FROM sql_text",
+		"PREPARE FROM 'SELECT 1'",
+		"PREPARE stmt FROM 'SELECT 1' extra",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParsePrepare(in); err == nil {
+			t.Errorf("ParsePrepare(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseExecute(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"plain", "EXECUTE stmt", "EXECUTE stmt"},
+		{"using", "execute stmt using @a, @b", "EXECUTE stmt USING @a, @b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := parser.ParseExecute(tt.in)
+			if err != nil {
+				t.Fatalf("ParseExecute(%q) error = %v", tt.in, err)
+			}
+
+			if got := e.String(); got != tt.want {
+				t.Errorf("ParseExecute(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseExecute_errors(t *testing.T) {
+	tests := []string{
+		"EXECUTE",
+		"EXECUTE stmt USING",
+		"EXECUTE stmt USING a",
+		"EXECUTE stmt USING @a,",
+		"EXECUTE stmt extra",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseExecute(in); err == nil {
+			t.Errorf("ParseExecute(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseDeallocatePrepare(t *testing.T) {
+	d, err := parser.ParseDeallocatePrepare("deallocate prepare stmt")
+	if err != nil {
+		t.Fatalf("ParseDeallocatePrepare error = %v", err)
+	}
+
+	if got, want := d.String(), "DEALLOCATE PREPARE stmt"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	d, err = parser.ParseDeallocatePrepare("drop prepare stmt")
+	if err != nil {
+		t.Fatalf("ParseDeallocatePrepare error = %v", err)
+	}
+
+	if !d.Drop {
+		t.Error("Drop = false, want true")
+	}
+
+	if got, want := d.String(), "DROP PREPARE stmt"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestParseDeallocatePrepare_errors(t *testing.T) {
+	tests := []string{
+		"DEALLOCATE stmt",
+		"DEALLOCATE PREPARE",
+		"DEALLOCATE PREPARE stmt extra",
+		"DROP stmt",
+		"DROP PREPARE",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseDeallocatePrepare(in); err == nil {
+			t.Errorf("ParseDeallocatePrepare(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseDo(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"single expression", "DO SLEEP(1)", "DO SLEEP(1)"},
+		{"expression list", "do release_lock('a'), @x := 1", "DO release_lock('a'), @x := 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := parser.ParseDo(tt.in)
+			if err != nil {
+				t.Fatalf("ParseDo(%q) error = %v", tt.in, err)
+			}
+
+			if got := d.String(); got != tt.want {
+				t.Errorf("ParseDo(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDo_errors(t *testing.T) {
+	tests := []string{
+		"DO",
+		"DO 1,",
+		"DO 1 extra",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseDo(in); err == nil {
+			t.Errorf("ParseDo(%q) expected error, got nil", in)
+		}
+	}
+}
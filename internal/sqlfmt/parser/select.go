@@ -18,75 +18,324 @@ func (p *Parser) parseSelectStatement() *sqlast.Select {
 }
 
 // parseSelectCore parses a SELECT statement's body (the SELECT keyword
-// through the WHERE/GROUP BY/HAVING filters), leaving out the optional
+// through the WHERE/GROUP BY/HAVING filters and WINDOW clause, including an
+// INTO clause right after the select expressions), leaving out the optional
 // leading WITH clause and the trailing ORDER BY/LIMIT/locking clauses.
 // Shared with the UNION parser, where each branch's tail belongs to the
 // union as a whole rather than to the individual branch.
 func (p *Parser) parseSelectCore(sel *sqlast.Select) {
+	m := p.markComments()
+
 	p.expect(SELECT)
+
+	sel.Hints = p.parseOptionalHintComments()
 	p.parseSelectModifiers(sel)
+	p.claimClause(&sel.Clauses, "SELECT", m)
 
 	sel.SelectExprs = p.parseSelectExprList()
-	sel.From = p.parseOptionalFromClause()
+	p.parseOptionalSelectInto(sel, sqlast.IntoBeforeFrom)
+	sel.From = p.parseOptionalFromClause(&sel.Clauses)
 
 	p.parseSelectFilters(sel)
 }
 
-// parseSelectModifiers parses the [DISTINCT|ALL] modifier following SELECT.
+// parseSelectModifiers parses the modifiers following SELECT: DISTINCT or
+// ALL, and any of MySQL's HIGH_PRIORITY, STRAIGHT_JOIN, SQL_SMALL_RESULT,
+// SQL_BIG_RESULT, SQL_BUFFER_RESULT, SQL_NO_CACHE, and SQL_CALC_FOUND_ROWS,
+// in any order.
 func (p *Parser) parseSelectModifiers(sel *sqlast.Select) {
-	sel.Distinct = p.consumeDistinct()
+	for {
+		switch {
+		case p.consumeDistinct():
+			sel.Distinct = true
+		case p.consume(ALL):
+		case p.consume(StraightJoin):
+			sel.Modifiers |= sqlast.StraightJoinModifier
+		default:
+			if !p.consumeSelectModifierWord(sel) {
+				return
+			}
+		}
+	}
+}
+
+// consumeSelectModifierWord consumes the current token if it's one of the
+// SELECT modifiers spelled as a plain identifier, adding it to sel's set.
+func (p *Parser) consumeSelectModifierWord(sel *sqlast.Select) bool {
+	if !p.at(IDENT) {
+		return false
+	}
+
+	mod, ok := sqlast.SelectModifierByName(p.tok.Literal)
+	if !ok {
+		return false
+	}
+
+	sel.Modifiers |= mod
+	p.advance()
+
+	return true
+}
+
+// parseOptionalSelectInto parses an optional INTO clause into sel, recording
+// pos as where it was written. It fails if sel already has one.
+func (p *Parser) parseOptionalSelectInto(sel *sqlast.Select, pos sqlast.IntoPosition) {
+	if !p.at(INTO) {
+		return
+	}
+
+	if sel.Into != nil {
+		p.failf("duplicate INTO clause")
+	}
+
+	p.advance()
+
+	sel.Into = p.parseSelectIntoTarget()
+	sel.Into.Position = pos
+}
+
+// parseSelectIntoTarget parses the target following INTO: `OUTFILE 'file'`
+// with its optional CHARACTER SET and export options, `DUMPFILE 'file'`, or
+// a list of user (@name) or local variables. OUTFILE is reserved in MySQL,
+// but DUMPFILE isn't, so DUMPFILE is only recognized before a string and a
+// local variable may still carry that name.
+func (p *Parser) parseSelectIntoTarget() *sqlast.SelectInto {
+	switch {
+	case p.consumeWord("OUTFILE"):
+		into := &sqlast.SelectInto{Type: sqlast.IntoOutfile, File: p.parseRequiredStringLiteral("after OUTFILE")}
+		into.Charset = p.parseOptionalIntoCharset()
+		into.Export = p.parseOptionalExportOptions()
+
+		return into
+	case p.atWord("DUMPFILE") && p.peekAt(STRING):
+		p.advance()
+
+		return &sqlast.SelectInto{Type: sqlast.IntoDumpfile, File: p.parseStringLiteral()}
+	default:
+		return &sqlast.SelectInto{Type: sqlast.IntoVariables, Variables: p.parseIntoVariables()}
+	}
+}
+
+// parseIntoVariables parses INTO's comma-separated variable list.
+func (p *Parser) parseIntoVariables() []sqlast.Expr {
+	var vars []sqlast.Expr
+
+	for {
+		if p.at(AtVariable) {
+			vars = append(vars, p.parseUserVariable())
+		} else {
+			vars = append(vars, &sqlast.ColName{Name: sqlast.ColIdent(p.readIdent())})
+		}
+
+		if !p.consume(COMMA) {
+			return vars
+		}
+	}
+}
+
+// parseOptionalIntoCharset parses an optional `CHARACTER SET name` (or
+// `CHARSET name`) following INTO OUTFILE's file name.
+func (p *Parser) parseOptionalIntoCharset() string {
+	switch {
+	case p.consume(CHARACTER):
+		p.expect(SET)
+
+		return p.readIdent()
+	case p.consume(CHARSET):
+		return p.readIdent()
+	default:
+		return ""
+	}
+}
+
+// parseOptionalExportOptions parses INTO OUTFILE's optional
+// `{FIELDS|COLUMNS} ...` and `LINES ...` options, returning nil if neither
+// is present.
+func (p *Parser) parseOptionalExportOptions() *sqlast.ExportOptions {
+	opts := &sqlast.ExportOptions{}
+	present := false
+
+	if p.atWord("FIELDS") || p.at(COLUMNS) {
+		opts.ColumnsKeyword = p.at(COLUMNS)
+		p.advance()
+		p.parseExportFieldsOptions(opts)
+
+		present = true
+	}
+
+	if p.consumeWord("LINES") {
+		p.parseExportLinesOptions(opts)
+
+		present = true
+	}
+
+	if !present {
+		return nil
+	}
+
+	return opts
+}
+
+// parseExportFieldsOptions parses one or more of FIELDS' `TERMINATED BY`,
+// `[OPTIONALLY] ENCLOSED BY`, and `ESCAPED BY` sub-options, in any order.
+func (p *Parser) parseExportFieldsOptions(opts *sqlast.ExportOptions) {
+	for parsed := false; ; parsed = true {
+		switch {
+		case p.consumeWord("TERMINATED"):
+			opts.FieldsTerminatedBy = p.parseExportOptionValue()
+		case p.atWord("OPTIONALLY") || p.atWord("ENCLOSED"):
+			opts.OptionallyEnclosed = p.consumeWord("OPTIONALLY")
+			p.expectWord("ENCLOSED")
+			opts.FieldsEnclosedBy = p.parseExportOptionValue()
+		case p.consumeWord("ESCAPED"):
+			opts.FieldsEscapedBy = p.parseExportOptionValue()
+		default:
+			if !parsed {
+				p.failf("expected TERMINATED, ENCLOSED, or ESCAPED, got %s", p.tok.Type)
+			}
+
+			return
+		}
+	}
+}
 
-	if !sel.Distinct {
-		p.consume(ALL)
+// parseExportLinesOptions parses one or more of LINES' `STARTING BY` and
+// `TERMINATED BY` sub-options, in any order.
+func (p *Parser) parseExportLinesOptions(opts *sqlast.ExportOptions) {
+	for parsed := false; ; parsed = true {
+		switch {
+		case p.consumeWord("STARTING"):
+			opts.LinesStartingBy = p.parseExportOptionValue()
+		case p.consumeWord("TERMINATED"):
+			opts.LinesTerminatedBy = p.parseExportOptionValue()
+		default:
+			if !parsed {
+				p.failf("expected STARTING or TERMINATED, got %s", p.tok.Type)
+			}
+
+			return
+		}
 	}
 }
 
-// parseSelectFilters parses the WHERE/GROUP BY/HAVING clauses into sel.
+// parseExportOptionValue parses the `BY 'string'` following an export
+// sub-option's keyword.
+func (p *Parser) parseExportOptionValue() sqlast.Expr {
+	p.expect(BY)
+
+	return p.parseRequiredStringLiteral("after BY")
+}
+
+// rejectSelectInto fails if stmt is a SELECT with an INTO clause. MySQL only
+// accepts INTO on a top-level SELECT; context names where stmt appeared.
+func (p *Parser) rejectSelectInto(stmt sqlast.Statement, context string) {
+	if sel, ok := stmt.(*sqlast.Select); ok && sel.Into != nil {
+		p.failf("INTO is not allowed in %s", context)
+	}
+}
+
+// parseSelectFilters parses the WHERE/GROUP BY/HAVING/WINDOW clauses into
+// sel.
 func (p *Parser) parseSelectFilters(sel *sqlast.Select) {
-	sel.Where = p.parseOptionalWhereClause(WHERE)
-	sel.GroupBy = p.parseOptionalGroupBy()
-	sel.Having = p.parseOptionalWhereClause(HAVING)
+	sel.Where = p.parseOptionalWhereClause(&sel.Clauses, WHERE)
+	sel.GroupBy = p.parseOptionalGroupBy(&sel.Clauses)
+	sel.Having = p.parseOptionalWhereClause(&sel.Clauses, HAVING)
+	sel.Window = p.parseOptionalWindowClause(&sel.Clauses)
 }
 
-// parseSelectTail parses the ORDER BY/LIMIT/locking clauses into sel.
+// parseOptionalWindowClause parses an optional
+// `WINDOW name AS (spec) [, name AS (spec) ...]` clause.
+func (p *Parser) parseOptionalWindowClause(clauses *sqlast.ClauseComments) sqlast.NamedWindows {
+	if !p.consumeClause(clauses, "WINDOW", WINDOW) {
+		return nil
+	}
+
+	var windows sqlast.NamedWindows
+
+	for {
+		windows = append(windows, p.parseNamedWindow())
+
+		if !p.consume(COMMA) {
+			break
+		}
+	}
+
+	return windows
+}
+
+func (p *Parser) parseNamedWindow() *sqlast.NamedWindow {
+	if !p.at(IDENT) {
+		return failReturn[*sqlast.NamedWindow](p, "expected window name, got %s", p.tok.Type)
+	}
+
+	name := sqlast.ColIdent(p.tok.Literal)
+	p.advance()
+
+	p.expect(AS)
+	p.expect(LPAREN)
+
+	spec := p.parseWindowSpecification()
+
+	p.expect(RPAREN)
+
+	return &sqlast.NamedWindow{Name: name, Spec: spec}
+}
+
+// parseSelectTail parses the ORDER BY/LIMIT/locking clauses into sel, along
+// with an INTO clause written just before or just after the locking clause.
 func (p *Parser) parseSelectTail(sel *sqlast.Select) {
-	sel.OrderBy = p.parseOptionalOrderBy()
-	sel.Limit = p.parseOptionalLimit()
+	sel.OrderBy = p.parseOptionalOrderBy(&sel.Clauses)
+	sel.Limit = p.parseOptionalLimit(&sel.Clauses)
+	p.parseOptionalSelectInto(sel, sqlast.IntoBeforeLock)
 	sel.Lock, sel.LockWait = p.parseOptionalLock()
+	p.parseOptionalSelectInto(sel, sqlast.IntoAfterLock)
 }
 
-// parseSelectOrUnionAfterWith parses a SELECT statement, or a UNION of two or
-// more SELECT branches, given an already-parsed (possibly nil) leading WITH
-// clause. The current token must be SELECT. Shared by the top-level
-// statement dispatcher and INSERT's SELECT/UNION row source, both of which
-// need to look past the first SELECT branch to see whether a UNION follows
-// before committing to which statement type they're building.
+// parseSelectOrUnionAfterWith parses a SELECT statement, or a set operation
+// (UNION/INTERSECT/EXCEPT) of two or more branches, given an already-parsed
+// (possibly nil) leading WITH clause. The current token must be SELECT or
+// LPAREN; a parenthesized first branch must be followed by a set operator.
+// Shared by the top-level statement dispatcher and INSERT's SELECT/UNION
+// row source, both of which need to look past the first branch to see
+// whether a set operator follows before committing to which statement type
+// they're building.
 func (p *Parser) parseSelectOrUnionAfterWith(with *sqlast.With) sqlast.Statement {
+	if p.at(LPAREN) {
+		left := p.parseParenSelect()
+
+		if !p.atSetOperator() {
+			return failReturn[sqlast.Statement](p,
+				"expected UNION, INTERSECT, or EXCEPT after parenthesized query, got %s", p.tok.Type)
+		}
+
+		return p.parseUnionTailAndClauses(left, with)
+	}
+
 	sel := &sqlast.Select{}
 	p.parseSelectCore(sel)
 
-	if !p.at(UNION) {
+	if !p.atSetOperator() {
 		sel.With = with
 		p.parseSelectTail(sel)
 
 		return sel
 	}
 
-	u := p.parseUnionTail(sel)
-	u.With = with
-	u.OrderBy = p.parseOptionalOrderBy()
-	u.Limit = p.parseOptionalLimit()
-	u.Lock, u.LockWait = p.parseOptionalLock()
+	p.rejectSelectInto(sel, "a set operation branch")
 
-	return u
+	return p.parseUnionTailAndClauses(sel, with)
 }
 
 // parseSubqueryStatement parses the body of a parenthesized subquery — a
-// SELECT statement, or a UNION of SELECT branches, optionally preceded by a
-// WITH clause. The current token must be WITH or SELECT. Used wherever a
-// subquery appears: CTEs, derived tables, scalar/IN subqueries, and EXISTS.
+// SELECT statement, or a set operation of SELECT or parenthesized branches,
+// optionally preceded by a WITH clause. The current token must be WITH,
+// SELECT, or LPAREN. Used wherever a subquery appears: CTEs, derived tables,
+// scalar/IN subqueries, EXISTS, and parenthesized set operation branches.
 func (p *Parser) parseSubqueryStatement() sqlast.Statement {
-	return p.parseSelectOrUnionAfterWith(p.parseOptionalWith())
+	stmt := p.parseSelectOrUnionAfterWith(p.parseOptionalWith())
+	p.rejectSelectInto(stmt, "a subquery")
+
+	return stmt
 }
 
 func (p *Parser) parseOptionalWith() *sqlast.With {
@@ -141,13 +390,20 @@ func (p *Parser) parseIdentList() sqlast.Columns {
 	}
 }
 
+// parseSelectExprList parses the select list, each item claiming its
+// comments through the comma after it.
 func (p *Parser) parseSelectExprList() []sqlast.SelectExpr {
 	var exprs []sqlast.SelectExpr
 
 	for {
-		exprs = append(exprs, p.parseSelectExpr())
+		m := p.markComments()
+		expr := p.parseSelectExpr()
+		more := p.consume(COMMA)
+		p.attachComments(expr, m)
 
-		if !p.consume(COMMA) {
+		exprs = append(exprs, expr)
+
+		if !more {
 			return exprs
 		}
 	}
@@ -197,8 +453,8 @@ func (p *Parser) parseOptionalColAlias() sqlast.ColIdent {
 	return ""
 }
 
-func (p *Parser) parseOptionalFromClause() []sqlast.TableExpr {
-	if !p.consume(FROM) {
+func (p *Parser) parseOptionalFromClause(clauses *sqlast.ClauseComments) []sqlast.TableExpr {
+	if !p.consumeClause(clauses, "FROM", FROM) {
 		return nil
 	}
 
@@ -206,24 +462,42 @@ func (p *Parser) parseOptionalFromClause() []sqlast.TableExpr {
 }
 
 // parseTableReferenceList parses a comma-separated list of table references
-// (each possibly a chain of JOINs). Shared by the FROM clause and the
-// target-table list of multi-table UPDATE/DELETE statements.
+// (each possibly a chain of JOINs), each claiming its comments through the
+// comma after it. Shared by the FROM clause, the target-table list of
+// multi-table UPDATE/DELETE statements, and a parenthesized table list.
 func (p *Parser) parseTableReferenceList() []sqlast.TableExpr {
 	var tables []sqlast.TableExpr
 
 	for {
-		tables = append(tables, p.parseTableReference())
+		m := p.markComments()
+		table := p.parseTableReference()
+		more := p.consume(COMMA)
+		p.attachComments(table, m)
 
-		if !p.consume(COMMA) {
+		tables = append(tables, table)
+
+		if !more {
 			return tables
 		}
 	}
 }
 
+// parseTableReference parses a table factor and the joins onto it. The
+// comments up to each join keyword go to the join's left operand, which the
+// formatter ends on the line before the keyword, and those in a join's
+// right table factor to that factor (see parseJoin); the rest, such as
+// those in the last join's ON condition, are left for the enclosing list
+// item to claim.
 func (p *Parser) parseTableReference() sqlast.TableExpr {
+	m := p.markComments()
 	left := p.parseTableFactor()
 
 	for p.isJoinStart() {
+		p.comments = append(p.comments, p.tok.Leading...)
+		p.tok.Leading = nil
+		p.attachComments(left, m)
+
+		m = p.markComments()
 		left = p.parseJoin(left)
 	}
 
@@ -240,25 +514,26 @@ func (p *Parser) isJoinStart() bool {
 }
 
 func (p *Parser) parseJoin(left sqlast.TableExpr) sqlast.TableExpr {
-	joinType := p.parseJoinType()
-	right := p.parseTableFactor()
+	join := &sqlast.JoinTableExpr{LeftExpr: left, Join: p.parseJoinType()}
+
+	m := p.markComments()
+	join.RightExpr = p.parseTableFactor()
+	p.attachComments(join.RightExpr, m)
 
 	// NATURAL joins determine their join columns implicitly and MySQL
 	// rejects an explicit ON/USING alongside NATURAL, so this doesn't even
 	// look for one: any ON/USING left in the token stream is then unconsumed
 	// input, which fails elsewhere with a parse error instead of being
 	// silently accepted (and possibly dropped) here.
-	if isNaturalJoinType(joinType) {
-		return &sqlast.JoinTableExpr{LeftExpr: left, Join: joinType, RightExpr: right}
-	}
-
-	cond := p.parseOptionalJoinCondition()
+	if !isNaturalJoinType(join.Join) {
+		join.Condition = p.parseOptionalJoinCondition()
 
-	if cond == nil && joinRequiresCondition(joinType) {
-		p.failf("expected ON or USING after %s", strings.ToUpper(joinType.ToString()))
+		if join.Condition == nil && joinRequiresCondition(join.Join) {
+			p.failf("expected ON or USING after %s", strings.ToUpper(join.Join.ToString()))
+		}
 	}
 
-	return &sqlast.JoinTableExpr{LeftExpr: left, Join: joinType, RightExpr: right, Condition: cond}
+	return join
 }
 
 // joinRequiresCondition reports whether MySQL requires an ON or USING clause
@@ -357,6 +632,20 @@ func (p *Parser) parseTableFactor() sqlast.TableExpr {
 		return p.parseParenTableExpr()
 	}
 
+	if p.consume(LATERAL) {
+		p.expect(LPAREN)
+
+		if !p.at(SELECT) && !p.at(WITH) && !p.at(LPAREN) {
+			return failReturn[sqlast.TableExpr](p, "expected subquery after LATERAL, got %s", p.tok.Type)
+		}
+
+		return p.parseDerivedTable(true)
+	}
+
+	if p.atWord("JSON_TABLE") && p.peekAt(LPAREN) {
+		return p.parseJSONTable()
+	}
+
 	name := p.parseTableName()
 	alias := p.parseOptionalTableAlias()
 	hints := p.parseOptionalIndexHints()
@@ -377,14 +666,75 @@ func (p *Parser) parseTableName() sqlast.TableName {
 func (p *Parser) parseParenTableExpr() sqlast.TableExpr {
 	p.advance() // consume '('
 
-	if p.at(SELECT) || p.at(WITH) {
-		return p.parseDerivedTable()
+	if p.at(SELECT) || p.at(WITH) || p.at(VALUES) || p.atParenSetOperation() {
+		return p.parseDerivedTable(false)
 	}
 
-	var tables []sqlast.TableExpr
+	tables := p.parseTableReferenceList()
+
+	p.expect(RPAREN)
+
+	return &sqlast.ParenTableExpr{Exprs: tables}
+}
+
+// parseDerivedTable parses a derived table's body after its opening
+// parenthesis, a subquery or a VALUES table value constructor, then its
+// alias and optional column list. The current token must be SELECT, WITH,
+// VALUES, or the ( of a parenthesized set operation branch (see
+// atParenSetOperation).
+func (p *Parser) parseDerivedTable(lateral bool) sqlast.TableExpr {
+	var sel sqlast.Statement
+	if p.at(VALUES) {
+		sel = p.parseValuesStatement()
+	} else {
+		sel = p.parseSubqueryStatement()
+	}
+
+	p.expect(RPAREN)
+
+	t := &sqlast.AliasedTableExpr{Expr: &sqlast.DerivedTable{Lateral: lateral, Select: sel}}
+
+	t.As = p.parseOptionalTableAlias()
+	if !t.As.IsEmpty() && p.consume(LPAREN) {
+		t.Columns = p.parseIdentList()
+		p.expect(RPAREN)
+	}
+
+	return t
+}
+
+// parseJSONTable parses JSON_TABLE(expr, path COLUMNS (...)) [AS] alias.
+// The current token must be the JSON_TABLE identifier.
+func (p *Parser) parseJSONTable() sqlast.TableExpr {
+	p.advance() // consume JSON_TABLE
+	p.expect(LPAREN)
+
+	jt := &sqlast.JSONTableExpr{Expr: p.parseExpr()}
+
+	p.expect(COMMA)
+
+	jt.Path = p.parseRequiredStringLiteral("for JSON_TABLE path")
+	jt.Columns = p.parseJSONTableColumns()
+
+	p.expect(RPAREN)
+
+	jt.As = p.parseOptionalTableAlias()
+	if jt.As.IsEmpty() {
+		p.failf("JSON_TABLE requires an alias")
+	}
+
+	return jt
+}
+
+// parseJSONTableColumns parses a JSON_TABLE COLUMNS (column, ...) list.
+func (p *Parser) parseJSONTableColumns() []sqlast.JSONTableColumn {
+	p.expect(COLUMNS)
+	p.expect(LPAREN)
+
+	var cols []sqlast.JSONTableColumn
 
 	for {
-		tables = append(tables, p.parseTableReference())
+		cols = append(cols, p.parseJSONTableColumn())
 
 		if !p.consume(COMMA) {
 			break
@@ -393,17 +743,87 @@ func (p *Parser) parseParenTableExpr() sqlast.TableExpr {
 
 	p.expect(RPAREN)
 
-	return &sqlast.ParenTableExpr{Exprs: tables}
+	return cols
 }
 
-func (p *Parser) parseDerivedTable() sqlast.TableExpr {
-	sel := p.parseSubqueryStatement()
+// parseJSONTableColumn parses one JSON_TABLE column: NESTED [PATH] path
+// COLUMNS (...), name FOR ORDINALITY, or name type [EXISTS] PATH path
+// [response ON EMPTY] [response ON ERROR]. NESTED, PATH, ORDINALITY, EMPTY,
+// and ERROR are matched as ordinary identifiers, so a column may still be
+// named after them.
+func (p *Parser) parseJSONTableColumn() sqlast.JSONTableColumn {
+	if p.atWord("NESTED") && (p.peekAt(STRING) || (p.peekAt(IDENT) && strings.EqualFold(p.peekTok.Literal, "PATH"))) {
+		p.advance() // consume NESTED
+		p.consumeWord("PATH")
 
-	p.expect(RPAREN)
+		path := p.parseRequiredStringLiteral("for NESTED PATH")
 
-	alias := p.parseOptionalTableAlias()
+		return &sqlast.JSONTableNestedPath{Path: path, Columns: p.parseJSONTableColumns()}
+	}
+
+	name := sqlast.ColIdent(p.readIdent())
+
+	if p.consume(FOR) {
+		p.expectWord("ORDINALITY")
+
+		return &sqlast.JSONTableOrdinalityColumn{Name: name}
+	}
+
+	col := &sqlast.JSONTablePathColumn{Name: name, Type: p.parseDataType()}
+	col.Exists = p.consume(EXISTS)
+
+	p.expectWord("PATH")
+
+	col.Path = p.parseRequiredStringLiteral("for PATH")
+
+	if !col.Exists {
+		p.parseJSONTableResponses(col)
+	}
+
+	return col
+}
 
-	return &sqlast.AliasedTableExpr{Expr: &sqlast.DerivedTable{Select: sel}, As: alias}
+// parseJSONTableResponses parses a path column's optional response ON EMPTY
+// and response ON ERROR clauses, which MySQL requires in that order.
+func (p *Parser) parseJSONTableResponses(col *sqlast.JSONTablePathColumn) {
+	resp := p.parseOptionalJSONTableResponse()
+	if resp == nil {
+		return
+	}
+
+	p.expect(ON)
+
+	if p.consumeWord("EMPTY") {
+		col.OnEmpty = resp
+
+		if resp = p.parseOptionalJSONTableResponse(); resp == nil {
+			return
+		}
+
+		p.expect(ON)
+	}
+
+	p.expectWord("ERROR")
+
+	col.OnError = resp
+}
+
+// parseOptionalJSONTableResponse parses a NULL, ERROR, or DEFAULT 'json'
+// response, returning nil if the current token doesn't start one.
+func (p *Parser) parseOptionalJSONTableResponse() *sqlast.JSONTableResponse {
+	switch {
+	case p.consume(NULL):
+		return &sqlast.JSONTableResponse{Type: sqlast.JSONTableNullResponse}
+	case p.consumeWord("ERROR"):
+		return &sqlast.JSONTableResponse{Type: sqlast.JSONTableErrorResponse}
+	case p.consume(DEFAULT):
+		return &sqlast.JSONTableResponse{
+			Type:    sqlast.JSONTableDefaultResponse,
+			Default: p.parseRequiredStringLiteral("after DEFAULT"),
+		}
+	default:
+		return nil
+	}
 }
 
 func (p *Parser) parseOptionalTableAlias() sqlast.TableIdent {
@@ -475,38 +895,71 @@ func (p *Parser) parseOptionalIndexHintFor() sqlast.IndexHintForType {
 	}
 }
 
-func (p *Parser) parseOptionalWhereClause(kw TokenType) *sqlast.Where {
-	if !p.consume(kw) {
+// parseOptionalWhereClause parses an optional WHERE or HAVING clause (per
+// kw), recording the comments around kw in clauses.
+func (p *Parser) parseOptionalWhereClause(clauses *sqlast.ClauseComments, kw TokenType) *sqlast.Where {
+	if !p.consumeClause(clauses, kw.String(), kw) {
 		return nil
 	}
 
-	return &sqlast.Where{Expr: p.parseExpr()}
+	m := p.markComments()
+	where := &sqlast.Where{Expr: p.parseExpr()}
+	where.Comments = p.claimComments(m)
+
+	return where
 }
 
-func (p *Parser) parseOptionalGroupBy() *sqlast.GroupBy {
-	if !p.consume(GROUP) {
+func (p *Parser) parseOptionalGroupBy(clauses *sqlast.ClauseComments) *sqlast.GroupBy {
+	if !p.consumeClause(clauses, "GROUP BY", GROUP, BY) {
 		return nil
 	}
 
-	p.expect(BY)
-
+	m := p.markComments()
 	gb := &sqlast.GroupBy{Exprs: p.parseExprList()}
 
-	if p.consume(WITH) {
-		p.expect(ROLLUP)
+	// Only WITH ROLLUP belongs to GROUP BY: a view body's WITH CHECK OPTION
+	// may follow it.
+	if p.at(WITH) && p.peekAt(ROLLUP) {
+		p.advance() // consume WITH
+		p.advance() // consume ROLLUP
 
 		gb.WithRollup = true
 	}
 
+	gb.Comments = p.claimComments(m)
+
 	return gb
 }
 
-func (p *Parser) parseOptionalOrderBy() sqlast.OrderBy {
+// parseOptionalOrderBy parses an optional ORDER BY clause, recording the
+// comments around ORDER BY in clauses, and each item's comments on the
+// item, unless clauses is nil: then it's in an expression (GROUP_CONCAT's),
+// written on one line, where neither has anywhere to go.
+func (p *Parser) parseOptionalOrderBy(clauses *sqlast.ClauseComments) sqlast.OrderBy {
 	if !p.at(ORDER) {
 		return nil
 	}
 
-	return p.parseOrderByClause()
+	if clauses == nil {
+		return p.parseOrderByClause()
+	}
+
+	p.consumeClause(clauses, "ORDER BY", ORDER, BY)
+
+	var orders sqlast.OrderBy
+
+	for {
+		m := p.markComments()
+		order := p.parseOrderItem()
+		more := p.consume(COMMA)
+		p.attachComments(order, m)
+
+		orders = append(orders, order)
+
+		if !more {
+			return orders
+		}
+	}
 }
 
 func (p *Parser) parseOptionalOrderDirection() sqlast.OrderDirection {
@@ -519,8 +972,9 @@ func (p *Parser) parseOptionalOrderDirection() sqlast.OrderDirection {
 	return sqlast.AscOrder
 }
 
-// parseOrderByClause parses an ORDER BY clause; the current token must be
-// ORDER. Shared by top-level SELECT/UPDATE/DELETE and window specifications.
+// parseOrderByClause parses an ORDER BY clause, claiming no comments; the
+// current token must be ORDER. Shared by GROUP_CONCAT (through
+// parseOptionalOrderBy) and window specifications.
 func (p *Parser) parseOrderByClause() sqlast.OrderBy {
 	p.advance() // consume ORDER
 	p.expect(BY)
@@ -528,10 +982,7 @@ func (p *Parser) parseOrderByClause() sqlast.OrderBy {
 	var orders sqlast.OrderBy
 
 	for {
-		e := p.parseExpr()
-		dir := p.parseOptionalOrderDirection()
-
-		orders = append(orders, &sqlast.Order{Expr: e, Direction: dir})
+		orders = append(orders, p.parseOrderItem())
 
 		if !p.consume(COMMA) {
 			return orders
@@ -539,27 +990,35 @@ func (p *Parser) parseOrderByClause() sqlast.OrderBy {
 	}
 }
 
+// parseOrderItem parses a single ORDER BY item: an expression and its
+// optional direction.
+func (p *Parser) parseOrderItem() *sqlast.Order {
+	e := p.parseExpr()
+
+	return &sqlast.Order{Expr: e, Direction: p.parseOptionalOrderDirection()}
+}
+
 // parseOptionalLimit parses an optional LIMIT clause, accepting either
 // `LIMIT row_count [OFFSET offset]` or the older `LIMIT offset, row_count`
 // comma form; both are normalized into the same Rowcount/Offset shape.
-func (p *Parser) parseOptionalLimit() *sqlast.Limit {
-	if !p.consume(LIMIT) {
+func (p *Parser) parseOptionalLimit(clauses *sqlast.ClauseComments) *sqlast.Limit {
+	if !p.consumeClause(clauses, "LIMIT", LIMIT) {
 		return nil
 	}
 
-	first := p.parseExpr()
+	m := p.markComments()
+	limit := &sqlast.Limit{Rowcount: p.parseExpr()}
 
-	if p.consume(COMMA) {
-		return &sqlast.Limit{Rowcount: p.parseExpr(), Offset: first}
+	switch {
+	case p.consume(COMMA):
+		limit.Rowcount, limit.Offset = p.parseExpr(), limit.Rowcount
+	case p.consume(OFFSET):
+		limit.Offset = p.parseExpr()
 	}
 
-	var offset sqlast.Expr
-
-	if p.consume(OFFSET) {
-		offset = p.parseExpr()
-	}
+	limit.Comments = p.claimComments(m)
 
-	return &sqlast.Limit{Rowcount: first, Offset: offset}
+	return limit
 }
 
 func (p *Parser) parseOptionalLock() (sqlast.Lock, sqlast.LockWaitType) {
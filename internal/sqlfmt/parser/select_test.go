@@ -113,12 +113,190 @@ func TestParseSelect_derivedTable(t *testing.T) {
 		"SELECT id FROM (SELECT id FROM t) sub")
 }
 
+func TestParseSelect_lateralAndValuesDerivedTables(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"lateral join", "SELECT * FROM t1 JOIN LATERAL (SELECT MAX(x) AS m FROM t2 WHERE t2.a = t1.a) AS d ON TRUE",
+			"SELECT * FROM t1 JOIN LATERAL (SELECT MAX(x) AS m FROM t2 WHERE t2.a = t1.a) d ON TRUE"},
+		{"lateral comma", "select * from t1, lateral (select t1.a) d",
+			"SELECT * FROM t1, LATERAL (SELECT t1.a) d"},
+		{"values derived table", "SELECT * FROM (VALUES ROW(1, 2), ROW(3, 4)) AS v",
+			"SELECT * FROM (VALUES ROW(1, 2), ROW(3, 4)) v"},
+		{"values derived table column list", "SELECT * FROM (VALUES ROW(1, 2), ROW(3, 4)) AS v (a, b)",
+			"SELECT * FROM (VALUES ROW(1, 2), ROW(3, 4)) v (a, b)"},
+		{"subquery column list", "select a from (select 1, 2) d(a, b) join t on t.id = d.b",
+			"SELECT a FROM (SELECT 1, 2) d (a, b) JOIN t ON t.id = d.b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSelectRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
+func TestParseSelect_jsonTable(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{
+			"path column",
+			"SELECT jt.id FROM JSON_TABLE(data, '$[*]' COLUMNS(id INT PATH '$.id')) AS jt",
+			"SELECT jt.id FROM JSON_TABLE(data, '$[*]' COLUMNS (id INT PATH '$.id')) jt",
+		},
+		{
+			"ordinality, exists and responses",
+			"SELECT * FROM json_table(?, '$' columns (rn FOR ORDINALITY, ok BOOL EXISTS PATH '$.ok', " +
+				"n INT PATH '$.n' DEFAULT '0' ON EMPTY ERROR ON ERROR, m INT PATH '$.m' NULL ON ERROR)) jt",
+			"SELECT * FROM JSON_TABLE(?, '$' COLUMNS (rn FOR ORDINALITY, ok BOOL EXISTS PATH '$.ok', " +
+				"n INT PATH '$.n' DEFAULT '0' ON EMPTY ERROR ON ERROR, m INT PATH '$.m' NULL ON ERROR)) jt",
+		},
+		{
+			"nested path",
+			"SELECT * FROM t, JSON_TABLE(t.doc, '$' COLUMNS (NESTED '$.tags[*]' COLUMNS (tag TEXT PATH '$'))) AS jt",
+			"SELECT * FROM t, JSON_TABLE(t.doc, '$' COLUMNS (NESTED PATH '$.tags[*]' COLUMNS (tag TEXT PATH '$'))) jt",
+		},
+		{
+			"columns named after json_table words",
+			"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (nested INT PATH '$.a', path TEXT PATH '$.b')) jt",
+			"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (nested INT PATH '$.a', path TEXT PATH '$.b')) jt",
+		},
+		{
+			"table named json_table",
+			"SELECT * FROM json_table",
+			"SELECT * FROM json_table",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSelectRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
+func TestParseSelect_windowClause(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"single", "SELECT SUM(a) OVER w FROM t WINDOW w AS (PARTITION BY b ORDER BY c)",
+			"SELECT SUM(a) OVER w FROM t WINDOW w AS (PARTITION BY b ORDER BY c)"},
+		{"several with inheritance",
+			"select rank() over w2 from t window w1 as (partition by b), w2 as (w1 order by c rows unbounded preceding)",
+			"SELECT RANK() OVER w2 FROM t WINDOW w1 AS (PARTITION BY b), w2 AS (w1 ORDER BY c ROWS UNBOUNDED PRECEDING)"},
+		{"empty spec", "SELECT COUNT(*) OVER w FROM t WINDOW w AS ()",
+			"SELECT COUNT(*) OVER w FROM t WINDOW w AS ()"},
+		{"after having before order by",
+			"SELECT a FROM t GROUP BY a HAVING a > 1 WINDOW w AS (ORDER BY a) ORDER BY a LIMIT 1",
+			"SELECT a FROM t GROUP BY a HAVING a > 1 WINDOW w AS (ORDER BY a) ORDER BY a LIMIT 1"},
+		{"over inherits named window", "SELECT SUM(a) OVER (w ORDER BY c) FROM t WINDOW w AS (PARTITION BY b)",
+			"SELECT SUM(a) OVER (w ORDER BY c) FROM t WINDOW w AS (PARTITION BY b)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSelectRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
+func TestParseSelect_modifiers(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"calc found rows", "SELECT SQL_CALC_FOUND_ROWS * FROM t LIMIT 10",
+			"SELECT SQL_CALC_FOUND_ROWS * FROM t LIMIT 10"},
+		{"after distinct", "select distinct sql_no_cache a from t", "SELECT DISTINCT SQL_NO_CACHE a FROM t"},
+		{"before distinct", "SELECT SQL_CALC_FOUND_ROWS DISTINCT a FROM t",
+			"SELECT DISTINCT SQL_CALC_FOUND_ROWS a FROM t"},
+		{"canonical order",
+			"SELECT SQL_CALC_FOUND_ROWS SQL_BUFFER_RESULT SQL_BIG_RESULT SQL_SMALL_RESULT STRAIGHT_JOIN HIGH_PRIORITY a FROM t",
+			"SELECT HIGH_PRIORITY STRAIGHT_JOIN SQL_SMALL_RESULT SQL_BIG_RESULT SQL_BUFFER_RESULT SQL_CALC_FOUND_ROWS a FROM t"},
+		{"all with modifier", "SELECT ALL HIGH_PRIORITY a FROM t", "SELECT HIGH_PRIORITY a FROM t"},
+		{"straight_join modifier and join", "SELECT STRAIGHT_JOIN * FROM a STRAIGHT_JOIN b",
+			"SELECT STRAIGHT_JOIN * FROM a STRAIGHT_JOIN b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSelectRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
+func TestParseSelect_into(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"user variables", "SELECT a, b INTO @a, @b FROM t WHERE id = 1",
+			"SELECT a, b INTO @a, @b FROM t WHERE id = 1"},
+		{"local variables", "select a into x from t", "SELECT a INTO x FROM t"},
+		{"without from", "SELECT 1 INTO @one", "SELECT 1 INTO @one"},
+		{"before lock", "SELECT a FROM t LIMIT 1 INTO @a FOR UPDATE", "SELECT a FROM t LIMIT 1 INTO @a FOR UPDATE"},
+		{"after lock", "SELECT a FROM t FOR UPDATE INTO @a", "SELECT a FROM t FOR UPDATE INTO @a"},
+		{"at end", "SELECT a FROM t ORDER BY a INTO @a", "SELECT a FROM t ORDER BY a INTO @a"},
+		{"outfile", "SELECT * FROM t INTO OUTFILE '/tmp/t.txt'", "SELECT * FROM t INTO OUTFILE '/tmp/t.txt'"},
+		{"outfile options",
+			`select * from t into outfile '/tmp/t.csv' charset utf8mb4 columns escaped by '\\' optionally enclosed by '"' ` +
+				`terminated by ',' lines terminated by '\n' starting by '>'`,
+			`SELECT * FROM t INTO OUTFILE '/tmp/t.csv' CHARACTER SET utf8mb4 COLUMNS TERMINATED BY ',' ` +
+				`OPTIONALLY ENCLOSED BY '"' ESCAPED BY '\\' LINES STARTING BY '>' TERMINATED BY '\n'`},
+		{"outfile enclosed", "SELECT * FROM t INTO OUTFILE 'f' CHARACTER SET latin1 FIELDS ENCLOSED BY '\"'",
+			"SELECT * FROM t INTO OUTFILE 'f' CHARACTER SET latin1 FIELDS ENCLOSED BY '\"'"},
+		{"dumpfile", "SELECT doc INTO DUMPFILE '/tmp/doc' FROM t WHERE id = 1",
+			"SELECT doc INTO DUMPFILE '/tmp/doc' FROM t WHERE id = 1"},
+		{"variable named dumpfile", "SELECT 1 INTO dumpfile", "SELECT 1 INTO dumpfile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSelectRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
+func TestParseSelect_intoStructural(t *testing.T) {
+	sel, err := parser.ParseSelect("SELECT SQL_NO_CACHE a FROM t LIMIT 1 INTO @a, b")
+	if err != nil {
+		t.Fatalf("ParseSelect error = %v", err)
+	}
+
+	if sel.Modifiers != sqlast.SQLNoCacheModifier {
+		t.Errorf("Modifiers = %v, want %v", sel.Modifiers, sqlast.SQLNoCacheModifier)
+	}
+
+	want := &sqlast.SelectInto{
+		Type:      sqlast.IntoVariables,
+		Position:  sqlast.IntoBeforeLock,
+		Variables: []sqlast.Expr{&sqlast.UserVariable{Name: "a"}, col("b")},
+	}
+	if !reflect.DeepEqual(sel.Into, want) {
+		t.Errorf("Into = %#v, want %#v", sel.Into, want)
+	}
+}
+
 func TestParseSelect_subqueryInWhere(t *testing.T) {
 	assertSelectRoundTrip(t,
 		"SELECT id FROM t WHERE id IN (SELECT id FROM u)",
 		"SELECT id FROM t WHERE id IN (SELECT id FROM u)")
 }
 
+func TestParseSelect_parenthesizedSetOperationSubqueries(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"derived table", "select * from ((select 1) union (select 2)) as d",
+			"SELECT * FROM ((SELECT 1) UNION (SELECT 2)) d"},
+		{"nested branches", "select * from (((select 1) union (select 2)) intersect select 3) d",
+			"SELECT * FROM (((SELECT 1) UNION (SELECT 2)) INTERSECT SELECT 3) d"},
+		{"lateral", "select * from t, lateral ((select t.a) except (select 2)) d",
+			"SELECT * FROM t, LATERAL ((SELECT t.a) EXCEPT (SELECT 2)) d"},
+		{"in subquery", "select a from t where a in ((select 1) union (select 2))",
+			"SELECT a FROM t WHERE a IN ((SELECT 1) UNION (SELECT 2))"},
+		{"scalar subquery", "select ((select 1) union (select 2) limit 1)",
+			"SELECT ((SELECT 1) UNION (SELECT 2) LIMIT 1)"},
+		{"parenthesized table reference", "select * from ((select 1) as d)",
+			"SELECT * FROM ((SELECT 1) d)"},
+		{"parenthesized expression", "select ((select 1) + 1)",
+			"SELECT ((SELECT 1) + 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSelectRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
 func TestParseSelect_cte(t *testing.T) {
 	tests := []struct {
 		name, in, want string
@@ -219,6 +397,46 @@ func TestParseSelect_errors(t *testing.T) {
 		"WITH c (a",
 		"SELECT * FROM (",
 		"SELECT * FROM (SELECT 1",
+		"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (a INT PATH '$.a'))",
+		"SELECT * FROM JSON_TABLE(d '$' COLUMNS (a INT PATH '$.a')) jt",
+		"SELECT * FROM JSON_TABLE(d, ? COLUMNS (a INT PATH '$.a')) jt",
+		"SELECT * FROM JSON_TABLE(d, '$' COLUMNS ()) jt",
+		"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (a INT '$.a')) jt",
+		"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (a FOR '$.a')) jt",
+		"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (a INT PATH '$.a' NULL ON)) jt",
+		"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (a INT PATH '$.a' ERROR ON ERROR NULL ON EMPTY)) jt",
+		"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (a INT PATH '$.a' DEFAULT 0 ON EMPTY)) jt",
+		"SELECT * FROM JSON_TABLE(d, '$' COLUMNS (a INT EXISTS PATH '$.a' NULL ON EMPTY)) jt",
+		"SELECT * FROM LATERAL",
+		"SELECT * FROM LATERAL t",
+		"SELECT * FROM LATERAL (VALUES ROW(1)) v",
+		"SELECT * FROM LATERAL (t1 JOIN t2) d",
+		"SELECT * FROM (VALUES (1)) v",
+		"SELECT * FROM (VALUES ROW(1) v",
+		"SELECT * FROM t WINDOW",
+		"SELECT * FROM t WINDOW w",
+		"SELECT * FROM t WINDOW w AS",
+		"SELECT * FROM t WINDOW w AS (PARTITION BY a",
+		"SELECT * FROM t WINDOW w AS (PARTITION BY a),",
+		"SELECT * FROM t WINDOW 1 AS ()",
+		"SELECT * FROM t WINDOW w AS () HAVING a > 1",
+		"SELECT a INTO FROM t",
+		"SELECT a INTO @a,",
+		"SELECT a INTO @a FROM t INTO @b",
+		"SELECT a INTO @a FROM t LIMIT 1 FOR UPDATE INTO @b",
+		"SELECT a FROM t INTO OUTFILE",
+		"SELECT a FROM t INTO OUTFILE 'f' FIELDS",
+		"SELECT a FROM t INTO OUTFILE 'f' FIELDS TERMINATED ','",
+		"SELECT a FROM t INTO OUTFILE 'f' FIELDS TERMINATED BY 1",
+		"SELECT a FROM t INTO OUTFILE 'f' FIELDS OPTIONALLY BY ','",
+		"SELECT a FROM t INTO OUTFILE 'f' LINES",
+		"SELECT a FROM t INTO OUTFILE 'f' LINES ESCAPED BY 'x'",
+		"SELECT a FROM t INTO OUTFILE 'f' CHARACTER utf8mb4",
+		"SELECT a FROM t INTO DUMPFILE 'f' FIELDS TERMINATED BY ','",
+		"SELECT a INTO @a FROM t UNION SELECT 1",
+		"SELECT 1 UNION SELECT a INTO @a FROM t",
+		"SELECT * FROM t WHERE a IN (SELECT a INTO @a FROM u)",
+		"SELECT * FROM (SELECT a FROM u INTO @a) d",
 		"FROM t",
 		"SELECT * FROM t extra tokens FROM u",
 
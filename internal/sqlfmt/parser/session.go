@@ -190,8 +190,8 @@ func (p *Parser) parseSetNamesRest() *sqlast.SetNames {
 }
 
 // parseCharsetOrCollationName parses a SET NAMES charset or COLLATE
-// collation value: the DEFAULT keyword, a bare identifier, or a quoted
-// string. Unlike DDL's DEFAULT/COMMENT values, this is deliberately not the
+// collation value, also used for the collation of an expression's COLLATE
+// clause: the DEFAULT keyword, a bare identifier, or a quoted string. Unlike DDL's DEFAULT/COMMENT values, this is deliberately not the
 // full expression grammar — MySQL doesn't accept any other expression shape
 // (arithmetic, function calls, qualified names, ...) in either position.
 func (p *Parser) parseCharsetOrCollationName() sqlast.Expr {
@@ -216,7 +216,8 @@ func (p *Parser) parseSetVariableStatement() *sqlast.SetVariable {
 
 // parseSetVariableRest parses the remainder of a variable-assigning SET
 // statement after SET has been consumed: an optional SESSION/GLOBAL scope
-// or a "@"-prefixed user variable, then "= expr".
+// or a "@"-prefixed user variable, then "= expr" (or ":= expr", which SET
+// accepts as a synonym).
 func (p *Parser) parseSetVariableRest() *sqlast.SetVariable {
 	sv := &sqlast.SetVariable{}
 
@@ -235,7 +236,9 @@ func (p *Parser) parseSetVariableRest() *sqlast.SetVariable {
 		sv.Name = p.readIdent()
 	}
 
-	p.expect(EQ)
+	if !p.consume(ASSIGN) {
+		p.expect(EQ)
+	}
 
 	sv.Value = p.parseExpr()
 
@@ -180,6 +180,8 @@ func TestParseSetVariable(t *testing.T) {
 		{"session scope", "SET SESSION sql_mode = 'STRICT_TRANS_TABLES'", "SET SESSION sql_mode = 'STRICT_TRANS_TABLES'"},
 		{"global scope", "SET GLOBAL max_connections = 200", "SET GLOBAL max_connections = 200"},
 		{"expression value", "SET @total = 1 + 2", "SET @total = 1 + 2"},
+		{"colon-equals", "SET @rank := 0", "SET @rank = 0"},
+		{"user variable value", "SET @next = @rank + 1", "SET @next = @rank + 1"},
 	}
 
 	for _, tt := range tests {
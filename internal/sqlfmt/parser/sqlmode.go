@@ -1,18 +1,36 @@
 package parser
 
 // SQLMode selects which MySQL sql_mode-dependent lexing and parsing
-// behavior the lexer and parser use for string literals.
-type SQLMode int
+// behavior the lexer and parser use for quoted strings and identifiers and
+// for ||. It is a set of flags, combined with | the way MySQL's
+// comma-separated sql_mode combines modes.
+type SQLMode uint
 
-const (
-	// ModeDefault matches MySQL's behavior without NO_BACKSLASH_ESCAPES set:
-	// backslash is a string-literal escape character. It is the zero value,
-	// so a Lexer or Parser constructed without an explicit mode behaves the
-	// same as before SQLMode was introduced.
-	ModeDefault SQLMode = iota
+// ModeDefault matches MySQL's behavior with none of the modes below set:
+// backslash is a string-literal escape character, a double-quoted token is
+// a string, and || means OR. It is the zero value, so a Lexer or Parser
+// constructed without an explicit mode behaves the same as before SQLMode
+// was introduced.
+const ModeDefault SQLMode = 0
 
+const (
 	// ModeNoBackslashEscapes matches MySQL's NO_BACKSLASH_ESCAPES sql_mode:
 	// backslash has no special meaning in a string literal, and a literal
 	// quote can only be embedded by doubling it.
-	ModeNoBackslashEscapes
+	ModeNoBackslashEscapes SQLMode = 1 << iota
+
+	// ModeANSIQuotes matches MySQL's ANSI_QUOTES sql_mode: a double-quoted
+	// token ("order") is a quoted identifier, like a backtick-quoted one,
+	// rather than a string.
+	ModeANSIQuotes
+
+	// ModePipesAsConcat matches MySQL's PIPES_AS_CONCAT sql_mode: || is
+	// string concatenation, as in standard SQL, rather than a synonym for
+	// OR.
+	ModePipesAsConcat
 )
+
+// Has reports whether every flag set in flag is also set in m.
+func (m SQLMode) Has(flag SQLMode) bool {
+	return m&flag == flag
+}
@@ -1,16 +1,23 @@
 package parser
 
-import "github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+import (
+	"fmt"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
 
 // ParseStatement parses a single top-level SQL statement — SELECT, INSERT,
-// UPDATE, DELETE, a UNION of SELECT branches, a DDL statement (CREATE TABLE,
-// ALTER TABLE, CREATE INDEX, DROP INDEX, DROP TABLE, TRUNCATE TABLE), a
-// transaction/session statement (START TRANSACTION, BEGIN, COMMIT, ROLLBACK,
-// SAVEPOINT, RELEASE SAVEPOINT, SET), or an admin/utility statement (SHOW
-// TABLES/CREATE TABLE/COLUMNS/INDEX/DATABASES/VARIABLES/STATUS, DESCRIBE,
-// EXPLAIN, USE), optionally preceded by a WITH clause (except before
-// INSERT/REPLACE or any DDL/transaction/session/admin statement, none of
-// which MySQL allows WITH before — EXPLAIN's wrapped select_stmt accepts its
+// UPDATE, DELETE, a UNION/INTERSECT/EXCEPT set operation, VALUES, TABLE, a
+// DDL statement (CREATE TABLE, ALTER TABLE, CREATE INDEX, DROP INDEX, DROP
+// TABLE, TRUNCATE TABLE), a transaction/session statement (START
+// TRANSACTION, BEGIN, COMMIT, ROLLBACK, SAVEPOINT, RELEASE SAVEPOINT, SET),
+// an admin/utility statement (SHOW TABLES/CREATE TABLE/COLUMNS/INDEX/
+// DATABASES/VARIABLES/STATUS, DESCRIBE, EXPLAIN, USE), or a stored-program
+// utility statement (CALL, PREPARE, EXECUTE, DEALLOCATE PREPARE, DO),
+// optionally preceded by a WITH clause (except before INSERT/REPLACE,
+// VALUES, TABLE, or any DDL/transaction/session/admin/stored-program
+// statement, none of which this parser
+// accepts WITH before — EXPLAIN's wrapped select_stmt accepts its
 // own WITH clause instead) — dispatching on the statement's leading keyword.
 // This is the formatter's entry point, using ModeDefault. See
 // ParseStatementWithMode for mode-sensitive string-literal handling.
@@ -27,25 +34,58 @@ func ParseStatement(input string) (stmt sqlast.Statement, err error) {
 //
 //nolint:nonamedreturns // the named results are mutated by the deferred recover
 func ParseStatementWithMode(input string, mode SQLMode) (stmt sqlast.Statement, err error) {
+	return ParseStatementWithValuesRowAlias(input, mode, "")
+}
+
+// ParseStatementWithValuesRowAlias parses a single top-level SQL statement
+// like ParseStatementWithMode, additionally migrating an INSERT off the
+// deprecated VALUES(col) form: each VALUES(col) reference in its ON
+// DUPLICATE KEY UPDATE clause becomes alias.col, and the INSERT gains the
+// row alias `AS alias`. An INSERT that already has a row alias is rewritten
+// to that alias instead, unless it also names column aliases. The rewrite is
+// skipped for an INSERT whose row source can't take a row alias (SELECT or
+// TABLE) or whose target table is itself named alias. An empty alias
+// disables the rewrite; otherwise alias must be a plain, unquoted,
+// non-keyword identifier.
+//
+//nolint:nonamedreturns // the named results are mutated by the deferred recover
+func ParseStatementWithValuesRowAlias(input string, mode SQLMode, alias string) (stmt sqlast.Statement, err error) {
 	defer recoverParseError(&err)
 
+	if alias != "" && !IsPlainIdent(alias) {
+		return nil, &ParseError{Pos: Position{Line: 1, Column: 1}, Msg: fmt.Sprintf("invalid row alias %q", alias)}
+	}
+
 	p := NewParserWithMode(input, mode)
+	p.valuesRowAlias = sqlast.TableIdent(alias)
+	m := p.markComments()
 	result := p.parseStatement()
+	p.finishStatement(result, m)
 
-	p.consume(SEMICOLON)
+	return result, nil
+}
+
+// IsPlainIdent reports whether s lexes as exactly one unquoted identifier
+// that isn't a keyword, so it can be emitted as an identifier without
+// quoting.
+func IsPlainIdent(s string) bool {
+	lex := New(s)
 
-	if !p.at(EOF) {
-		p.failf("unexpected token %s after statement", p.tok.Type)
+	tok, err := lex.Next()
+	if err != nil || tok.Type != IDENT || tok.Literal != s {
+		return false
 	}
 
-	return result, nil
+	next, err := lex.Next()
+
+	return err == nil && next.Type == EOF
 }
 
 func (p *Parser) parseStatement() sqlast.Statement {
 	with := p.parseOptionalWith()
 
 	switch {
-	case p.at(SELECT):
+	case p.atQueryExpressionStart():
 		return p.parseSelectOrUnionAfterWith(with)
 	case p.at(UPDATE):
 		return p.parseUpdateStatementAfterWith(with)
@@ -63,18 +103,35 @@ func (p *Parser) parseStatement() sqlast.Statement {
 		return failReturn[sqlast.Statement](p, "WITH is not supported before %s", p.tok.Type)
 	}
 
-	msg := "expected SELECT, INSERT, UPDATE, DELETE, REPLACE, CREATE, ALTER, DROP, TRUNCATE, " +
-		"START, BEGIN, COMMIT, ROLLBACK, SAVEPOINT, RELEASE, SET, SHOW, DESCRIBE, EXPLAIN, or USE, got %s"
+	msg := "expected SELECT, INSERT, UPDATE, DELETE, REPLACE, VALUES, TABLE, CREATE, ALTER, DROP, TRUNCATE, " +
+		"START, BEGIN, COMMIT, ROLLBACK, SAVEPOINT, RELEASE, SET, SHOW, DESCRIBE, EXPLAIN, USE, " +
+		"CALL, PREPARE, EXECUTE, DEALLOCATE, or DO, got %s"
 
 	return failReturn[sqlast.Statement](p, msg, p.tok.Type)
 }
 
-// parseStatementWithoutWith dispatches to the DDL, transaction/session, or
-// admin/utility statement parsers, split out of parseStatement to keep that
-// switch's cyclomatic complexity down — none of these statement kinds accept
-// a leading WITH clause. It reports whether the current token started a
+// atQueryExpressionStart reports whether the current token can begin a
+// SELECT or a set operation: SELECT itself, or the ( of a parenthesized
+// first branch.
+func (p *Parser) atQueryExpressionStart() bool {
+	return p.at(SELECT) || p.at(LPAREN)
+}
+
+// parseStatementWithoutWith dispatches to the VALUES/TABLE, DDL,
+// transaction/session, admin/utility, or stored-program utility statement
+// parsers, split out of parseStatement to keep that switch's cyclomatic
+// complexity down — none of these statement kinds accept a leading WITH
+// clause. It reports whether the current token started a
 // recognized statement of one of those kinds.
 func (p *Parser) parseStatementWithoutWith() (sqlast.Statement, bool) {
+	if p.at(ExecComment) {
+		return p.parseExecutableCommentStatement(), true
+	}
+
+	if stmt, ok := p.parseTableValueStatement(); ok {
+		return stmt, true
+	}
+
 	if stmt, ok := p.parseDDLStatement(); ok {
 		return stmt, true
 	}
@@ -83,7 +140,20 @@ func (p *Parser) parseStatementWithoutWith() (sqlast.Statement, bool) {
 		return stmt, true
 	}
 
-	return p.parseAdminStatement()
+	if stmt, ok := p.parseAdminStatement(); ok {
+		return stmt, true
+	}
+
+	return p.parseProgramStatement()
+}
+
+// parseExecutableCommentStatement parses a statement that is a lone /*! ...
+// */ executable comment. The current token must be ExecComment.
+func (p *Parser) parseExecutableCommentStatement() *sqlast.ExecutableCommentStatement {
+	stmt := &sqlast.ExecutableCommentStatement{Text: p.tok.Literal}
+	p.expect(ExecComment)
+
+	return stmt
 }
 
 // parseDDLStatement dispatches a leading CREATE, ALTER, DROP, or TRUNCATE to
@@ -95,7 +165,7 @@ func (p *Parser) parseDDLStatement() (sqlast.Statement, bool) {
 	case p.at(CREATE):
 		return p.parseCreateStatement(), true
 	case p.at(ALTER):
-		return p.parseAlterTableStatement(), true
+		return p.parseAlterStatement(), true
 	case p.at(DROP):
 		return p.parseDropStatement(), true
 	case p.at(TRUNCATE):
@@ -43,6 +43,11 @@ func TestParseStatement_dispatch(t *testing.T) {
 		{"union", "SELECT 1 UNION SELECT 2", "SELECT 1 UNION SELECT 2", &sqlast.Union{}},
 		{"with union", "WITH c AS (SELECT 1) SELECT * FROM c UNION SELECT * FROM u",
 			"WITH c AS (SELECT 1) SELECT * FROM c UNION SELECT * FROM u", &sqlast.Union{}},
+		{"intersect", "SELECT 1 INTERSECT SELECT 2", "SELECT 1 INTERSECT SELECT 2", &sqlast.Union{}},
+		{"parenthesized branches", "(SELECT 1 LIMIT 1) EXCEPT (SELECT 2)",
+			"(SELECT 1 LIMIT 1) EXCEPT (SELECT 2)", &sqlast.Union{}},
+		{"values", "VALUES ROW(1), ROW(2)", "VALUES ROW(1), ROW(2)", &sqlast.ValuesStatement{}},
+		{"table", "TABLE t LIMIT 1", "TABLE t LIMIT 1", &sqlast.TableStatement{}},
 		{"insert", "INSERT INTO t (a) VALUES (1)", "INSERT INTO t (a) VALUES (1)", &sqlast.Insert{}},
 		{"replace", "REPLACE INTO t (a) VALUES (1)", "REPLACE INTO t (a) VALUES (1)", &sqlast.Insert{}},
 		{"update", "UPDATE t SET a = 1", "UPDATE t SET a = 1", &sqlast.Update{}},
@@ -57,6 +62,15 @@ func TestParseStatement_dispatch(t *testing.T) {
 		{"drop table", "DROP TABLE t", "DROP TABLE t", &sqlast.DropTable{}},
 		{"drop index", "DROP INDEX idx ON t", "DROP INDEX idx ON t", &sqlast.DropIndex{}},
 		{"truncate table", "TRUNCATE TABLE t", "TRUNCATE TABLE t", &sqlast.TruncateTable{}},
+		{"create view", "CREATE VIEW v AS SELECT 1", "CREATE VIEW v AS SELECT 1", &sqlast.CreateView{}},
+		{"create or replace view", "CREATE OR REPLACE VIEW v AS SELECT 1",
+			"CREATE OR REPLACE VIEW v AS SELECT 1", &sqlast.CreateView{}},
+		{"create view with options", "CREATE DEFINER = CURRENT_USER VIEW v AS SELECT 1",
+			"CREATE DEFINER = CURRENT_USER VIEW v AS SELECT 1", &sqlast.CreateView{}},
+		{"alter view", "ALTER VIEW v AS SELECT 1", "ALTER VIEW v AS SELECT 1", &sqlast.AlterView{}},
+		{"alter view with options", "ALTER SQL SECURITY INVOKER VIEW v AS SELECT 1",
+			"ALTER SQL SECURITY INVOKER VIEW v AS SELECT 1", &sqlast.AlterView{}},
+		{"drop view", "DROP VIEW v", "DROP VIEW v", &sqlast.DropView{}},
 		{"start transaction", "START TRANSACTION", "START TRANSACTION", &sqlast.StartTransaction{}},
 		{"begin", "BEGIN", "BEGIN", &sqlast.Begin{}},
 		{"commit", "COMMIT", "COMMIT", &sqlast.Commit{}},
@@ -75,6 +89,12 @@ func TestParseStatement_dispatch(t *testing.T) {
 		{"describe", "DESCRIBE t", "DESCRIBE t", &sqlast.Describe{}},
 		{"explain", "EXPLAIN SELECT 1", "EXPLAIN SELECT 1", &sqlast.Explain{}},
 		{"use", "USE db", "USE db", &sqlast.Use{}},
+		{"call", "CALL p(1)", "CALL p(1)", &sqlast.Call{}},
+		{"prepare", "PREPARE s FROM 'SELECT 1'", "PREPARE s FROM 'SELECT 1'", &sqlast.Prepare{}},
+		{"execute", "EXECUTE s", "EXECUTE s", &sqlast.Execute{}},
+		{"deallocate prepare", "DEALLOCATE PREPARE s", "DEALLOCATE PREPARE s", &sqlast.DeallocatePrepare{}},
+		{"drop prepare", "DROP PREPARE s", "DROP PREPARE s", &sqlast.DeallocatePrepare{}},
+		{"do", "DO 1", "DO 1", &sqlast.Do{}},
 	}
 
 	for _, tt := range tests {
@@ -99,9 +119,14 @@ func TestParseStatement_errors(t *testing.T) {
 		"WITH c AS (SELECT 1) SHOW TABLES",
 		"WITH c AS (SELECT 1) DESCRIBE t",
 		"WITH c AS (SELECT 1) USE db",
-		"CALL proc()",
+		"WITH c AS (SELECT 1) CALL proc()",
+		"GRANT ALL ON db.* TO app",
 		"SELECT 1 extra tokens",
 		"SELECT 1 UNION SELECT 2 extra tokens",
+		"(SELECT 1)",
+		"WITH c AS (SELECT 1) VALUES ROW(1)",
+		"WITH c AS (SELECT 1) TABLE c",
+		"(UPDATE t SET a = 1) UNION SELECT 1",
 		"INSERT INTO t (a) VALUES (1) extra tokens",
 		"UPDATE t SET a = 1 extra tokens",
 		"DELETE FROM t extra tokens",
@@ -127,6 +152,12 @@ func TestParseStatement_errors(t *testing.T) {
 		"EXPLAIN extra tokens",
 		"EXPLAIN FORMAT = XML SELECT 1",
 		"USE db extra tokens",
+		"CALL proc() extra tokens",
+		"CALL proc(1",
+		"PREPARE stmt FROM 1",
+		"EXECUTE stmt USING a",
+		"DEALLOCATE stmt",
+		"DO",
 		";SELECT 1",
 		"SELECT 1;;",
 		"SELECT 1; SELECT 2",
@@ -143,7 +174,7 @@ func TestParseStatement_errors(t *testing.T) {
 // *parser.ParseError specifically, matching the other entry points' error
 // model.
 func TestParseStatement_errorType(t *testing.T) {
-	_, err := parser.ParseStatement("CALL proc()")
+	_, err := parser.ParseStatement("GRANT ALL ON db.* TO app")
 	if err == nil {
 		t.Fatal("ParseStatement(...) expected error, got nil")
 	}
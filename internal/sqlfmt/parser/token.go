@@ -1,19 +1,23 @@
 // Package parser implements a lexer and parser for the supported subset of
-// MySQL DML (SELECT/INSERT/REPLACE/UPDATE/DELETE/UNION), DDL (CREATE TABLE,
-// ALTER TABLE, CREATE/DROP INDEX, DROP TABLE, TRUNCATE TABLE), transaction/
-// session statements (START TRANSACTION, BEGIN, COMMIT, ROLLBACK, SAVEPOINT,
-// RELEASE SAVEPOINT, SET), and admin/utility statements (SHOW TABLES/CREATE
-// TABLE/COLUMNS/INDEX/DATABASES/VARIABLES/STATUS, DESCRIBE, EXPLAIN, USE);
-// see parser-spec.md for the exact grammar. It produces the sqlast AST that
-// the formatter walks to render output. Anything outside that subset —
-// stored program syntax (CALL, PREPARE, EXECUTE, DEALLOCATE PREPARE),
-// JSON_TABLE, and other constructs not in the grammar — fails to parse
-// rather than being partially or incorrectly accepted.
+// MySQL DML (SELECT/INSERT/REPLACE/UPDATE/DELETE, and UNION/INTERSECT/EXCEPT
+// set operations), DDL (CREATE TABLE, ALTER TABLE, CREATE/DROP INDEX, DROP
+// TABLE, TRUNCATE TABLE), transaction/session statements (START TRANSACTION,
+// BEGIN, COMMIT, ROLLBACK, SAVEPOINT, RELEASE SAVEPOINT, SET),
+// admin/utility statements (SHOW TABLES/CREATE TABLE/COLUMNS/INDEX/
+// DATABASES/VARIABLES/STATUS, DESCRIBE, EXPLAIN, USE), and stored-program
+// utility statements (CALL, PREPARE, EXECUTE, DEALLOCATE PREPARE, DO); see
+// parser-spec.md for the exact grammar. It produces the sqlast AST that the
+// formatter walks to render output. Anything outside that subset — stored
+// program definitions (CREATE PROCEDURE, compound statements) and other
+// constructs not in the grammar — fails to parse rather than being
+// partially or incorrectly accepted.
 package parser
 
 import (
 	"fmt"
 	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
 )
 
 // TokenType identifies the category of a lexical token.
@@ -30,6 +34,10 @@ const (
 	HexStr      // x'1A' or X'1A'
 	BitStr      // b'101' or B'101'
 	AtVariable  // @var_name (user-defined variable)
+	Introducer  // _utf8mb4 or N immediately before a string literal
+	HintBegin   // /*+ opening an optimizer hint comment
+	HintEnd     // */ closing an optimizer hint comment
+	ExecComment // /*! ... */ executable comment, kept whole
 
 	EQ      // =
 	NE      // <> or !=
@@ -43,20 +51,35 @@ const (
 	STAR    // *
 	SLASH   // /
 	PERCENT // %
+	PIPES   // || (OR, or concatenation under ModePipesAsConcat)
+	AMPS    // && (AND)
+	PIPE    // | (bitwise OR)
+	AMP     // & (bitwise AND)
+	CARET   // ^ (bitwise XOR)
+	TILDE   // ~ (bitwise NOT)
+	SHL     // <<
+	SHR     // >>
+	ASSIGN  // :=
+	ARROW   // -> (JSON column path)
+	DARROW  // ->> (unquoting JSON column path)
 
 	LPAREN    // (
 	RPAREN    // )
+	LBRACE    // {
+	RBRACE    // }
 	COMMA     // ,
 	DOT       // .
 	COLON     // :
 	QUESTION  // ?
 	SEMICOLON // ;
+	AT        // @ before a quoted host name: 'user'@'host'
 
 	keywordBegin
 	SELECT
 	FROM
 	WHERE
 	JOIN
+	LATERAL
 	LEFT
 	RIGHT
 	INNER
@@ -89,6 +112,8 @@ const (
 	SET
 	DELETE
 	UNION
+	INTERSECT
+	EXCEPT
 	ALL
 	DISTINCT
 	ORDER
@@ -109,6 +134,7 @@ const (
 	WITH
 	RECURSIVE
 	OVER
+	WINDOW
 	PARTITION
 	ROWS
 	RANGE
@@ -184,6 +210,20 @@ const (
 	DESCRIBE
 	EXPLAIN
 	FORMAT
+	CALL
+	PREPARE
+	EXECUTE
+	DEALLOCATE
+	DO
+	VIEW
+	DIV
+	MOD
+	XOR
+	INTERVAL
+	BINARY
+	MATCH
+	AGAINST
+	FULLTEXT
 	keywordEnd
 )
 
@@ -198,6 +238,10 @@ var tokenNames = map[TokenType]string{
 	HexStr:      "HEX_STRING",
 	BitStr:      "BIT_STRING",
 	AtVariable:  "AT_VARIABLE",
+	Introducer:  "INTRODUCER",
+	HintBegin:   "/*+",
+	HintEnd:     "*/",
+	ExecComment: "EXECUTABLE_COMMENT",
 
 	EQ:      "=",
 	NE:      "<>",
@@ -211,19 +255,34 @@ var tokenNames = map[TokenType]string{
 	STAR:    "*",
 	SLASH:   "/",
 	PERCENT: "%",
+	PIPES:   "||",
+	AMPS:    "&&",
+	PIPE:    "|",
+	AMP:     "&",
+	CARET:   "^",
+	TILDE:   "~",
+	SHL:     "<<",
+	SHR:     ">>",
+	ASSIGN:  ":=",
+	ARROW:   "->",
+	DARROW:  "->>",
 
 	LPAREN:    "(",
 	RPAREN:    ")",
+	LBRACE:    "{",
+	RBRACE:    "}",
 	COMMA:     ",",
 	DOT:       ".",
 	COLON:     ":",
 	QUESTION:  "?",
 	SEMICOLON: ";",
+	AT:        "@",
 
 	SELECT:        "SELECT",
 	FROM:          "FROM",
 	WHERE:         "WHERE",
 	JOIN:          "JOIN",
+	LATERAL:       "LATERAL",
 	LEFT:          "LEFT",
 	RIGHT:         "RIGHT",
 	INNER:         "INNER",
@@ -256,6 +315,8 @@ var tokenNames = map[TokenType]string{
 	SET:           "SET",
 	DELETE:        "DELETE",
 	UNION:         "UNION",
+	INTERSECT:     "INTERSECT",
+	EXCEPT:        "EXCEPT",
 	ALL:           "ALL",
 	DISTINCT:      "DISTINCT",
 	ORDER:         "ORDER",
@@ -276,6 +337,7 @@ var tokenNames = map[TokenType]string{
 	WITH:          "WITH",
 	RECURSIVE:     "RECURSIVE",
 	OVER:          "OVER",
+	WINDOW:        "WINDOW",
 	PARTITION:     "PARTITION",
 	ROWS:          "ROWS",
 	RANGE:         "RANGE",
@@ -351,6 +413,20 @@ var tokenNames = map[TokenType]string{
 	DESCRIBE:      "DESCRIBE",
 	EXPLAIN:       "EXPLAIN",
 	FORMAT:        "FORMAT",
+	CALL:          "CALL",
+	PREPARE:       "PREPARE",
+	EXECUTE:       "EXECUTE",
+	DEALLOCATE:    "DEALLOCATE",
+	DO:            "DO",
+	VIEW:          "VIEW",
+	DIV:           "DIV",
+	MOD:           "MOD",
+	XOR:           "XOR",
+	INTERVAL:      "INTERVAL",
+	BINARY:        "BINARY",
+	MATCH:         "MATCH",
+	AGAINST:       "AGAINST",
+	FULLTEXT:      "FULLTEXT",
 }
 
 // String returns the token type's display name, used in error messages.
@@ -372,7 +448,9 @@ func (t TokenType) IsKeyword() bool {
 // non-reserved: recognized where the DDL grammar expects them (e.g. COMMENT
 // as a column/table option), but still valid as an ordinary identifier
 // everywhere else — a column or table actually named `comment`, `engine`,
-// `charset`, `no`, `action`, or `auto_increment` must keep working.
+// `charset`, `no`, `action`, `auto_increment`, or `view` must keep working. MOD is
+// reserved in MySQL, but listed here too: it also names the MOD() function,
+// which is parsed like any other function call.
 var nonReservedKeywords = map[TokenType]bool{
 	COMMENT:       true,
 	ENGINE:        true,
@@ -382,6 +460,8 @@ var nonReservedKeywords = map[TokenType]bool{
 	AutoIncrement: true,
 	FORMAT:        true,
 	STATUS:        true,
+	MOD:           true,
+	VIEW:          true,
 }
 
 // IsNonReservedKeyword reports whether t is one of nonReservedKeywords.
@@ -428,4 +508,16 @@ type Token struct {
 	Type    TokenType
 	Literal string
 	Pos     Position
+
+	// Quote is the quote character a STRING token was written with: ' or
+	// ". The formatter writes the string back with the same one, so a
+	// double-quoted token a server running ANSI_QUOTES would read as an
+	// identifier isn't turned into a string constant.
+	Quote byte
+
+	// Leading holds the ordinary comments between the previous token's line
+	// and this token, and Trailing those after this token on its line. EOF
+	// carries any comments after the last token's line as Leading.
+	Leading  []sqlast.Comment
+	Trailing []sqlast.Comment
 }
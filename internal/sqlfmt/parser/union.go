@@ -2,11 +2,13 @@ package parser
 
 import "github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
 
-// ParseUnion parses a UNION statement (two or more SELECT branches combined
-// by UNION [ALL|DISTINCT]), optionally preceded by a WITH clause and
-// followed by a trailing ORDER BY/LIMIT/locking clause on the combined
-// result. It fails with a *ParseError if input is a single SELECT with no
-// UNION keyword, since this entry point is specifically for union
+// ParseUnion parses a set operation statement (two or more query branches
+// combined by UNION, INTERSECT, or EXCEPT, each optionally [ALL|DISTINCT]),
+// optionally preceded by a WITH clause and followed by a trailing ORDER
+// BY/LIMIT/locking clause on the combined result. A branch is either a bare
+// SELECT or a parenthesized query expression with its own ORDER BY/LIMIT.
+// It fails with a *ParseError if input is a single SELECT with no set
+// operator, since this entry point is specifically for set operation
 // statements (contrast with ParseSelect, the entry point for plain SELECTs).
 //
 //nolint:nonamedreturns // the named results are mutated by the deferred recover
@@ -14,52 +16,105 @@ func ParseUnion(input string) (u *sqlast.Union, err error) {
 	defer recoverParseError(&err)
 
 	p := NewParser(input)
+	m := p.markComments()
 	result := p.parseUnionStatement()
-
-	p.consume(SEMICOLON)
-
-	if !p.at(EOF) {
-		p.failf("unexpected token %s after statement", p.tok.Type)
-	}
+	p.finishStatement(result, m)
 
 	return result, nil
 }
 
-// parseUnionStatement parses a UNION statement, optionally preceded by a
-// WITH clause. The current token must be WITH or SELECT. It fails if no
-// UNION keyword is present at all, i.e. the input is just a single SELECT.
+// parseUnionStatement parses a set operation statement, optionally preceded
+// by a WITH clause. The current token must be WITH, SELECT, or LPAREN. It
+// fails if no set operator is present at all, i.e. the input is just a
+// single SELECT.
 func (p *Parser) parseUnionStatement() *sqlast.Union {
 	with := p.parseOptionalWith()
 
 	left := p.parseUnionBranch()
 
-	if !p.at(UNION) {
-		p.failf("expected UNION, got %s", p.tok.Type)
+	if !p.atSetOperator() {
+		p.failf("expected UNION, INTERSECT, or EXCEPT, got %s", p.tok.Type)
 	}
 
+	return p.parseUnionTailAndClauses(left, with)
+}
+
+// parseUnionTailAndClauses parses the set operations following the already
+// parsed first branch left, then the trailing ORDER BY/LIMIT/locking clauses
+// that apply to the combined result. The current token must be a set
+// operator.
+func (p *Parser) parseUnionTailAndClauses(left sqlast.Statement, with *sqlast.With) *sqlast.Union {
 	u := p.parseUnionTail(left)
 
 	u.With = with
-	u.OrderBy = p.parseOptionalOrderBy()
-	u.Limit = p.parseOptionalLimit()
+	u.OrderBy = p.parseOptionalOrderBy(&u.Clauses)
+	u.Limit = p.parseOptionalLimit(&u.Clauses)
 	u.Lock, u.LockWait = p.parseOptionalLock()
 
 	return u
 }
 
-// parseUnionTail parses one or more `UNION [ALL|DISTINCT] select_core`
-// suffixes following left, left-associatively folding them into a chain of
-// nested *sqlast.Union nodes (e.g. `a UNION b UNION c` becomes a Union node
-// whose Left is itself a Union{Left: a, Right: b} and whose Right is c). The
-// current token must be UNION, guaranteeing the loop runs at least once.
+// atSetOperator reports whether the current token is UNION, INTERSECT, or
+// EXCEPT.
+func (p *Parser) atSetOperator() bool {
+	return p.at(UNION) || p.at(INTERSECT) || p.at(EXCEPT)
+}
+
+// parseUnionTail parses one or more `{UNION|INTERSECT|EXCEPT} [ALL|DISTINCT]
+// branch` suffixes following left, folding them into a tree of nested
+// *sqlast.Union nodes. INTERSECT binds tighter than UNION and EXCEPT, and
+// each level associates to the left: `a UNION b UNION c` becomes a Union
+// whose Left is Union{Left: a, Right: b} and whose Right is c, while
+// `a UNION b INTERSECT c` becomes a Union whose Right is
+// Union{Left: b, Right: c}. The current token must be a set operator,
+// guaranteeing the result is non-nil.
 func (p *Parser) parseUnionTail(left sqlast.Statement) *sqlast.Union {
+	u := p.parseIntersectTail(left)
+	if u != nil {
+		left = u
+	}
+
+	for p.at(UNION) || p.at(EXCEPT) {
+		op := sqlast.UnionSetOperator
+		if p.at(EXCEPT) {
+			op = sqlast.ExceptSetOperator
+		}
+
+		u = &sqlast.Union{Operator: op, Left: left}
+
+		m := p.markComments()
+		p.advance()
+
+		u.Distinct = p.parseOptionalUnionDistinct()
+		p.claimClause(&u.Clauses, op.ToString(), m)
+
+		u.Right = p.parseUnionBranch()
+		if intersect := p.parseIntersectTail(u.Right); intersect != nil {
+			u.Right = intersect
+		}
+
+		left = u
+	}
+
+	return u
+}
+
+// parseIntersectTail parses zero or more `INTERSECT [ALL|DISTINCT] branch`
+// suffixes following left, left-associatively. It returns nil if no
+// INTERSECT follows.
+func (p *Parser) parseIntersectTail(left sqlast.Statement) *sqlast.Union {
 	var u *sqlast.Union
 
-	for p.consume(UNION) {
-		distinct := p.parseOptionalUnionDistinct()
-		right := p.parseUnionBranch()
+	for p.at(INTERSECT) {
+		u = &sqlast.Union{Operator: sqlast.IntersectSetOperator, Left: left}
+
+		m := p.markComments()
+		p.advance()
+
+		u.Distinct = p.parseOptionalUnionDistinct()
+		p.claimClause(&u.Clauses, u.Operator.ToString(), m)
 
-		u = &sqlast.Union{Left: left, Right: right, Distinct: distinct}
+		u.Right = p.parseUnionBranch()
 		left = u
 	}
 
@@ -67,8 +122,8 @@ func (p *Parser) parseUnionTail(left sqlast.Statement) *sqlast.Union {
 }
 
 // parseOptionalUnionDistinct parses the optional [ALL|DISTINCT] modifier
-// following UNION, reporting whether the union should render as DISTINCT
-// (the default, and also what an explicit DISTINCT renders as).
+// following a set operator, reporting whether the operation should render as
+// DISTINCT (the default, and also what an explicit DISTINCT renders as).
 func (p *Parser) parseOptionalUnionDistinct() bool {
 	if p.consume(ALL) {
 		return false
@@ -79,14 +134,92 @@ func (p *Parser) parseOptionalUnionDistinct() bool {
 	return true
 }
 
-// parseUnionBranch parses a single UNION branch: just the SELECT keyword
-// through the WHERE/GROUP BY/HAVING filters, via the shared parseSelectCore.
-// A bare branch must not itself consume a trailing ORDER BY/LIMIT/locking
-// clause, since when one follows the last branch it belongs to the union as
-// a whole.
+// parseUnionBranch parses a single set operation branch. A bare branch is
+// just the SELECT keyword through the WHERE/GROUP BY/HAVING filters, via the
+// shared parseSelectCore: it must not itself consume a trailing ORDER
+// BY/LIMIT/locking clause, since when one follows the last branch it belongs
+// to the operation as a whole. A parenthesized branch is a full query
+// expression, with its own ORDER BY/LIMIT/locking clauses.
 func (p *Parser) parseUnionBranch() sqlast.Statement {
+	if p.at(LPAREN) {
+		return p.parseParenSelect()
+	}
+
 	sel := &sqlast.Select{}
 	p.parseSelectCore(sel)
+	p.rejectSelectInto(sel, "a set operation branch")
 
 	return sel
 }
+
+// parseParenSelect parses a parenthesized query expression used as a set
+// operation branch. The current token must be LPAREN.
+func (p *Parser) parseParenSelect() *sqlast.ParenSelect {
+	p.expect(LPAREN)
+
+	if !p.at(SELECT) && !p.at(WITH) && !p.at(LPAREN) {
+		return failReturn[*sqlast.ParenSelect](p, "expected SELECT, WITH, or ( in parenthesized query, got %s", p.tok.Type)
+	}
+
+	stmt := p.parseSubqueryStatement()
+
+	p.expect(RPAREN)
+
+	return &sqlast.ParenSelect{Select: stmt}
+}
+
+// atParenSetOperation reports whether the current token opens a
+// parenthesized query expression that a set operator follows, as in
+// ((SELECT 1) UNION (SELECT 2)). A derived table or subquery body starting
+// with ( could also be a parenthesized table reference or expression, and
+// only the token after the matching ) tells them apart, so this scans ahead
+// on a copy of the lexer without consuming anything.
+func (p *Parser) atParenSetOperation() bool {
+	if !p.at(LPAREN) {
+		return false
+	}
+
+	lex := *p.lex
+	buffered := []Token{p.tok, p.peekTok, p.peek2Tok}
+	next := func() TokenType {
+		if len(buffered) > 0 {
+			tok := buffered[0]
+			buffered = buffered[1:]
+
+			return tok.Type
+		}
+
+		tok, err := lex.Next()
+		if err != nil {
+			return EOF
+		}
+
+		return tok.Type
+	}
+
+	depth := 0
+
+	tt := next()
+	for ; tt == LPAREN; tt = next() {
+		depth++
+	}
+
+	if tt != SELECT && tt != WITH {
+		return false
+	}
+
+	for depth > 0 {
+		switch next() {
+		case LPAREN:
+			depth++
+		case RPAREN:
+			depth--
+		case EOF:
+			return false
+		}
+	}
+
+	tt = next()
+
+	return tt == UNION || tt == INTERSECT || tt == EXCEPT
+}
@@ -87,6 +87,107 @@ func TestParseUnion_chained_structural(t *testing.T) {
 	}
 }
 
+func TestParseUnion_setOperators(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"intersect", "SELECT 1 INTERSECT SELECT 2", "SELECT 1 INTERSECT SELECT 2"},
+		{"intersect all", "SELECT 1 INTERSECT ALL SELECT 2", "SELECT 1 INTERSECT ALL SELECT 2"},
+		{"except", "SELECT 1 EXCEPT SELECT 2", "SELECT 1 EXCEPT SELECT 2"},
+		{"except distinct renders as bare except", "SELECT 1 EXCEPT DISTINCT SELECT 2", "SELECT 1 EXCEPT SELECT 2"},
+		{"lowercase", "select 1 except all select 2", "SELECT 1 EXCEPT ALL SELECT 2"},
+		{"mixed chain", "SELECT 1 UNION SELECT 2 INTERSECT SELECT 3 EXCEPT SELECT 4",
+			"SELECT 1 UNION SELECT 2 INTERSECT SELECT 3 EXCEPT SELECT 4"},
+		{"intersect chain then union", "SELECT 1 INTERSECT SELECT 2 INTERSECT SELECT 3 UNION SELECT 4",
+			"SELECT 1 INTERSECT SELECT 2 INTERSECT SELECT 3 UNION SELECT 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertUnionRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
+// TestParseUnion_precedence verifies INTERSECT binds tighter than UNION and
+// EXCEPT: a UNION b INTERSECT c EXCEPT d groups as
+// (a UNION (b INTERSECT c)) EXCEPT d.
+func TestParseUnion_precedence(t *testing.T) {
+	u, err := parser.ParseUnion("SELECT 1 UNION SELECT 2 INTERSECT SELECT 3 EXCEPT SELECT 4")
+	if err != nil {
+		t.Fatalf("ParseUnion error = %v", err)
+	}
+
+	sel := func(n string) *sqlast.Select {
+		return &sqlast.Select{SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: num(n)}}}
+	}
+
+	want := &sqlast.Union{
+		Operator: sqlast.ExceptSetOperator,
+		Left: &sqlast.Union{
+			Left: sel("1"),
+			Right: &sqlast.Union{
+				Operator: sqlast.IntersectSetOperator,
+				Left:     sel("2"),
+				Right:    sel("3"),
+				Distinct: true,
+			},
+			Distinct: true,
+		},
+		Right:    sel("4"),
+		Distinct: true,
+	}
+
+	if !reflect.DeepEqual(u, want) {
+		t.Errorf("ParseUnion =\n  %#v\nwant\n  %#v", u, want)
+	}
+}
+
+func TestParseUnion_parenthesizedBranches(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"both branches", "(SELECT a FROM t ORDER BY a LIMIT 1) UNION (SELECT b FROM u)",
+			"(SELECT a FROM t ORDER BY a LIMIT 1) UNION (SELECT b FROM u)"},
+		{"right branch only", "SELECT a FROM t UNION ALL (SELECT b FROM u LIMIT 5) ORDER BY 1",
+			"SELECT a FROM t UNION ALL (SELECT b FROM u LIMIT 5) ORDER BY 1"},
+		{"nested set operation regroups", "SELECT 1 INTERSECT (SELECT 2 UNION SELECT 3)",
+			"SELECT 1 INTERSECT (SELECT 2 UNION SELECT 3)"},
+		{"nested parenthesized branches", "((SELECT 1) EXCEPT (SELECT 2)) UNION SELECT 3",
+			"((SELECT 1) EXCEPT (SELECT 2)) UNION SELECT 3"},
+		{"with inside branch", "(WITH c AS (SELECT 1) SELECT * FROM c) UNION SELECT 2",
+			"(WITH c AS (SELECT 1) SELECT * FROM c) UNION SELECT 2"},
+		{"with before parenthesized branch", "WITH c AS (SELECT 1) (SELECT * FROM c) UNION SELECT 2",
+			"WITH c AS (SELECT 1) (SELECT * FROM c) UNION SELECT 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertUnionRoundTrip(t, tt.in, tt.want)
+		})
+	}
+}
+
+func TestParseUnion_parenthesizedBranch_structural(t *testing.T) {
+	u, err := parser.ParseUnion("(SELECT 1 LIMIT 1) UNION SELECT 2")
+	if err != nil {
+		t.Fatalf("ParseUnion error = %v", err)
+	}
+
+	want := &sqlast.Union{
+		Left: &sqlast.ParenSelect{Select: &sqlast.Select{
+			SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: num("1")}},
+			Limit:       &sqlast.Limit{Rowcount: num("1")},
+		}},
+		Right:    &sqlast.Select{SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: num("2")}}},
+		Distinct: true,
+	}
+
+	if !reflect.DeepEqual(u, want) {
+		t.Errorf("ParseUnion =\n  %#v\nwant\n  %#v", u, want)
+	}
+}
+
 func TestParseUnion_with(t *testing.T) {
 	tests := []struct {
 		name, in, want string
@@ -185,6 +286,19 @@ func TestParseUnion_errors(t *testing.T) {
 		"SELECT 1 UNION SELECT 2 LOCK",
 		"WITH",
 		"SELECT 1 UNION SELECT 2 extra tokens FROM u",
+		"SELECT 1 INTERSECT",
+		"SELECT 1 EXCEPT ALL",
+		"INTERSECT SELECT 1",
+
+		// Parenthesized branches must hold a query and be closed, and a
+		// lone parenthesized query is not a set operation.
+		"(SELECT 1)",
+		"(SELECT 1",
+		"(SELECT 1 UNION SELECT 2",
+		"() UNION SELECT 1",
+		"(1) UNION SELECT 1",
+		"SELECT 1 UNION (SELECT 2",
+		"SELECT 1 UNION ()",
 
 		// Lexer-level errors positioned inside a union-specific position.
 		"SELECT 1 UNION 'unterminated",
@@ -18,13 +18,9 @@ func ParseUpdateWithMode(input string, mode SQLMode) (upd *sqlast.Update, err er
 	defer recoverParseError(&err)
 
 	p := NewParserWithMode(input, mode)
+	m := p.markComments()
 	result := p.parseUpdateStatement()
-
-	p.consume(SEMICOLON)
-
-	if !p.at(EOF) {
-		p.failf("unexpected token %s after statement", p.tok.Type)
-	}
+	p.finishStatement(result, m)
 
 	return result, nil
 }
@@ -43,21 +39,24 @@ func (p *Parser) parseUpdateStatementAfterWith(with *sqlast.With) *sqlast.Update
 
 	p.expect(UPDATE)
 
+	upd.Hints = p.parseOptionalHintComments()
 	upd.Ignore = p.consume(IGNORE)
 	upd.TableExprs = p.parseTableReferenceList()
 
-	p.expect(SET)
+	if !p.consumeClause(&upd.Clauses, "SET", SET) {
+		p.failf("expected %s, got %s", SET, p.tok.Type)
+	}
 
 	upd.Exprs = p.parseSetExprList()
-	upd.Where = p.parseOptionalWhereClause(WHERE)
+	upd.Where = p.parseOptionalWhereClause(&upd.Clauses, WHERE)
 
 	// ORDER BY and LIMIT are only valid for the single-table form; MySQL
 	// rejects them when TableExprs names more than one table or contains a
 	// JOIN, mirroring the same restriction on DELETE (see
 	// parseDeleteStatement).
 	if isSingleTableUpdate(upd.TableExprs) {
-		upd.OrderBy = p.parseOptionalOrderBy()
-		upd.Limit = p.parseOptionalLimit()
+		upd.OrderBy = p.parseOptionalOrderBy(&upd.Clauses)
+		upd.Limit = p.parseOptionalLimit(&upd.Clauses)
 	}
 
 	return upd
@@ -0,0 +1,87 @@
+package parser
+
+import "github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+
+// ParseValues parses a VALUES ROW(...), ... statement from input, using
+// ModeDefault.
+func ParseValues(input string) (*sqlast.ValuesStatement, error) {
+	return ParseValuesWithMode(input, ModeDefault)
+}
+
+// ParseValuesWithMode parses a VALUES ROW(...), ... statement from input,
+// decoding string literals per mode.
+func ParseValuesWithMode(input string, mode SQLMode) (*sqlast.ValuesStatement, error) {
+	return parseDDLEntry(input, mode, (*Parser).parseValuesStatement)
+}
+
+// ParseTable parses a TABLE statement from input.
+func ParseTable(input string) (*sqlast.TableStatement, error) {
+	return parseDDLEntry(input, ModeDefault, (*Parser).parseTableStatement)
+}
+
+// parseTableValueStatement dispatches a leading VALUES or TABLE to its
+// statement parser, split out of parseStatement to keep that switch's
+// cyclomatic complexity down. It reports whether the current token started
+// one of those statements.
+func (p *Parser) parseTableValueStatement() (sqlast.Statement, bool) {
+	switch {
+	case p.at(VALUES):
+		return p.parseValuesStatement(), true
+	case p.at(TABLE):
+		return p.parseTableStatement(), true
+	default:
+		return nil, false
+	}
+}
+
+// parseValuesStatement parses
+// `VALUES ROW(expr, ...) [, ROW(...) ...] [ORDER BY ...] [LIMIT ...]`.
+// The current token must be VALUES.
+func (p *Parser) parseValuesStatement() *sqlast.ValuesStatement {
+	vs := &sqlast.ValuesStatement{}
+
+	p.consumeClause(&vs.Clauses, "VALUES", VALUES)
+
+	vs.Rows = p.parseRowConstructors(false)
+	vs.OrderBy = p.parseOptionalOrderBy(&vs.Clauses)
+	vs.Limit = p.parseOptionalLimit(&vs.Clauses)
+
+	return vs
+}
+
+// parseRowConstructors parses one or more comma-separated ROW(expr, ...)
+// constructors. allowEmpty permits ROW(), which MySQL accepts only as an
+// INSERT row (where it inserts the column defaults).
+func (p *Parser) parseRowConstructors(allowEmpty bool) sqlast.Values {
+	var rows sqlast.Values
+
+	for {
+		p.expect(ROW)
+		p.expect(LPAREN)
+
+		var row []sqlast.Expr
+		if !allowEmpty || !p.at(RPAREN) {
+			row = p.parseExprList()
+		}
+
+		rows = append(rows, row)
+
+		p.expect(RPAREN)
+
+		if !p.consume(COMMA) {
+			return rows
+		}
+	}
+}
+
+// parseTableStatement parses `TABLE t [ORDER BY ...] [LIMIT ...]`. The
+// current token must be TABLE.
+func (p *Parser) parseTableStatement() *sqlast.TableStatement {
+	p.expect(TABLE)
+
+	ts := &sqlast.TableStatement{Table: p.parseTableName()}
+	ts.OrderBy = p.parseOptionalOrderBy(&ts.Clauses)
+	ts.Limit = p.parseOptionalLimit(&ts.Clauses)
+
+	return ts
+}
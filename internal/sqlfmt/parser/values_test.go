@@ -0,0 +1,112 @@
+package parser_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+func TestParseValues(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"single row", "VALUES ROW(1, 'a')", "VALUES ROW(1, 'a')"},
+		{"several rows", "values row(1, 'a'), row(2, 'b')", "VALUES ROW(1, 'a'), ROW(2, 'b')"},
+		{"order by limit", "VALUES ROW(2), ROW(1) ORDER BY column_0 LIMIT 1",
+			"VALUES ROW(2), ROW(1) ORDER BY column_0 LIMIT 1"},
+		{"expressions", "VALUES ROW(1 + 2, NOW())", "VALUES ROW(1 + 2, NOW())"},
+		{"trailing semicolon", "VALUES ROW(1);", "VALUES ROW(1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := parser.ParseValues(tt.in)
+			if err != nil {
+				t.Fatalf("ParseValues(%q) error = %v", tt.in, err)
+			}
+
+			if got := v.String(); got != tt.want {
+				t.Errorf("ParseValues(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValues_structural(t *testing.T) {
+	v, err := parser.ParseValues("VALUES ROW(1, 2), ROW(3, 4)")
+	if err != nil {
+		t.Fatalf("ParseValues error = %v", err)
+	}
+
+	want := &sqlast.ValuesStatement{Rows: sqlast.Values{{num("1"), num("2")}, {num("3"), num("4")}}}
+
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("ParseValues =\n  %#v\nwant\n  %#v", v, want)
+	}
+}
+
+func TestParseTable(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"bare", "TABLE t", "TABLE t"},
+		{"qualified", "table db.t", "TABLE db.t"},
+		{"order by limit", "TABLE t ORDER BY a DESC LIMIT 10 OFFSET 5", "TABLE t ORDER BY a DESC LIMIT 10 OFFSET 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl, err := parser.ParseTable(tt.in)
+			if err != nil {
+				t.Fatalf("ParseTable(%q) error = %v", tt.in, err)
+			}
+
+			if got := tbl.String(); got != tt.want {
+				t.Errorf("ParseTable(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValues_errors(t *testing.T) {
+	tests := []string{
+		"",
+		"VALUES",
+		"VALUES (1, 2)",
+		"VALUES ROW",
+		"VALUES ROW(",
+		"VALUES ROW()",
+		"VALUES ROW(1",
+		"VALUES ROW(1),",
+		"VALUES ROW(1) ROW(2)",
+		"VALUES ROW(1) ORDER",
+		"VALUES ROW(1) LIMIT",
+		"VALUES ROW(1) extra",
+		"VALUES ROW('unterminated)",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseValues(in); err == nil {
+			t.Errorf("ParseValues(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseTable_errors(t *testing.T) {
+	tests := []string{
+		"",
+		"TABLE",
+		"TABLE t.",
+		"TABLE t WHERE a = 1",
+		"TABLE t ORDER",
+		"TABLE t LIMIT",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseTable(in); err == nil {
+			t.Errorf("ParseTable(%q) expected error, got nil", in)
+		}
+	}
+}
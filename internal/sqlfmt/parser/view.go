@@ -0,0 +1,236 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+// ParseCreateView parses a CREATE VIEW statement from input, using
+// ModeDefault.
+func ParseCreateView(input string) (*sqlast.CreateView, error) {
+	return ParseCreateViewWithMode(input, ModeDefault)
+}
+
+// ParseCreateViewWithMode parses a CREATE VIEW statement from input,
+// decoding string literals per mode.
+func ParseCreateViewWithMode(input string, mode SQLMode) (*sqlast.CreateView, error) {
+	return parseDDLEntry(input, mode, (*Parser).parseCreateViewStatement)
+}
+
+// ParseAlterView parses an ALTER VIEW statement from input, using
+// ModeDefault.
+func ParseAlterView(input string) (*sqlast.AlterView, error) {
+	return ParseAlterViewWithMode(input, ModeDefault)
+}
+
+// ParseAlterViewWithMode parses an ALTER VIEW statement from input, decoding
+// string literals per mode.
+func ParseAlterViewWithMode(input string, mode SQLMode) (*sqlast.AlterView, error) {
+	return parseDDLEntry(input, mode, (*Parser).parseAlterViewStatement)
+}
+
+// ParseDropView parses a DROP VIEW statement from input.
+func ParseDropView(input string) (*sqlast.DropView, error) {
+	return parseDDLEntry(input, ModeDefault, (*Parser).parseDropViewStatement)
+}
+
+// peekAtViewOption reports whether the next token starts one of the
+// ALGORITHM, DEFINER, or SQL SECURITY clauses that can come between CREATE
+// [OR REPLACE] or ALTER and VIEW. None of the three words is a keyword.
+func (p *Parser) peekAtViewOption() bool {
+	if p.peekTok.Type != IDENT {
+		return false
+	}
+
+	switch strings.ToUpper(p.peekTok.Literal) {
+	case "ALGORITHM", "DEFINER", "SQL":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseCreateViewStatement parses a CREATE [OR REPLACE] VIEW statement. The
+// current token must be CREATE.
+func (p *Parser) parseCreateViewStatement() *sqlast.CreateView {
+	p.expect(CREATE)
+
+	cv := &sqlast.CreateView{}
+
+	if p.consume(OR) {
+		p.expect(REPLACE)
+
+		cv.OrReplace = true
+	}
+
+	cv.ViewDefinition = p.parseViewDefinition()
+
+	return cv
+}
+
+// parseAlterViewStatement parses an ALTER VIEW statement. The current token
+// must be ALTER.
+func (p *Parser) parseAlterViewStatement() *sqlast.AlterView {
+	p.expect(ALTER)
+
+	return &sqlast.AlterView{ViewDefinition: p.parseViewDefinition()}
+}
+
+// parseViewDefinition parses the part of CREATE VIEW and ALTER VIEW after
+// CREATE [OR REPLACE] or ALTER:
+//
+//	[ALGORITHM = {UNDEFINED | MERGE | TEMPTABLE}] [DEFINER = account]
+//	[SQL SECURITY {DEFINER | INVOKER}] VIEW view_name [(column_list)]
+//	AS select_stmt [WITH [CASCADED | LOCAL] CHECK OPTION]
+func (p *Parser) parseViewDefinition() sqlast.ViewDefinition {
+	var d sqlast.ViewDefinition
+
+	if p.consumeWord("ALGORITHM") {
+		p.expect(EQ)
+
+		d.Algorithm = p.parseViewAlgorithm()
+	}
+
+	if p.consumeWord("DEFINER") {
+		p.expect(EQ)
+
+		d.Definer = p.parseAccount()
+	}
+
+	if p.consumeWord("SQL") {
+		p.expectWord("SECURITY")
+
+		d.Security = p.parseViewSecurity()
+	}
+
+	p.expect(VIEW)
+
+	d.View = p.parseTableName()
+	d.Columns = p.parseOptionalColumnList()
+
+	p.expect(AS)
+
+	d.Select = p.parseSelectOrUnionAfterWith(p.parseOptionalWith())
+	d.CheckOption = p.parseOptionalCheckOption()
+
+	return d
+}
+
+// parseViewAlgorithm parses ALGORITHM's value: one of UNDEFINED, MERGE, or
+// TEMPTABLE, matched case-insensitively.
+func (p *Parser) parseViewAlgorithm() sqlast.ViewAlgorithm {
+	name := p.readIdent()
+
+	switch strings.ToUpper(name) {
+	case "UNDEFINED":
+		return sqlast.UndefinedAlgorithm
+	case "MERGE":
+		return sqlast.MergeAlgorithm
+	case "TEMPTABLE":
+		return sqlast.TempTableAlgorithm
+	default:
+		return failReturn[sqlast.ViewAlgorithm](
+			p, "expected UNDEFINED, MERGE, or TEMPTABLE after ALGORITHM =, got %s", name,
+		)
+	}
+}
+
+// parseViewSecurity parses SQL SECURITY's value: DEFINER or INVOKER,
+// matched case-insensitively.
+func (p *Parser) parseViewSecurity() sqlast.ViewSecurity {
+	switch {
+	case p.consumeWord("DEFINER"):
+		return sqlast.DefinerSecurity
+	case p.consumeWord("INVOKER"):
+		return sqlast.InvokerSecurity
+	default:
+		return failReturn[sqlast.ViewSecurity](p, "expected DEFINER or INVOKER after SQL SECURITY, got %s", p.tok.Type)
+	}
+}
+
+// parseAccount parses a DEFINER account, returning it as written:
+// CURRENT_USER (optionally followed by an empty argument list, which is
+// dropped), or a user name and an optional @host, each an identifier or a
+// string literal ('app'@'%', app@localhost).
+func (p *Parser) parseAccount() string {
+	if p.consumeWord("CURRENT_USER") {
+		if p.consume(LPAREN) {
+			p.expect(RPAREN)
+		}
+
+		return "CURRENT_USER"
+	}
+
+	user := p.parseAccountPart()
+
+	switch {
+	case p.at(AtVariable):
+		// An unquoted host lexes as a user variable: app@localhost.
+		user += "@" + p.tok.Literal
+		p.advance()
+	case p.consume(AT):
+		user += "@" + p.parseAccountPart()
+	}
+
+	return user
+}
+
+// parseAccountPart parses an account's user or host name: a string literal,
+// kept quoted, or an identifier.
+func (p *Parser) parseAccountPart() string {
+	if p.at(STRING) {
+		return p.parseStringLiteral().String()
+	}
+
+	return p.readIdent()
+}
+
+// parseOptionalCheckOption parses a view's optional WITH [CASCADED | LOCAL]
+// CHECK OPTION clause.
+func (p *Parser) parseOptionalCheckOption() sqlast.ViewCheckOption {
+	if !p.consume(WITH) {
+		return sqlast.NoCheckOption
+	}
+
+	check := sqlast.CheckOption
+
+	switch {
+	case p.consumeWord("CASCADED"):
+		check = sqlast.CascadedCheckOption
+	case p.consumeWord("LOCAL"):
+		check = sqlast.LocalCheckOption
+	}
+
+	p.expectWord("CHECK")
+	p.expectWord("OPTION")
+
+	return check
+}
+
+// parseDropViewStatement parses a DROP VIEW [IF EXISTS] view_name [,
+// view_name] ... [RESTRICT | CASCADE] statement. The current token must be
+// DROP.
+func (p *Parser) parseDropViewStatement() *sqlast.DropView {
+	p.expect(DROP)
+	p.expect(VIEW)
+
+	dv := &sqlast.DropView{IfExists: p.parseOptionalIfExists()}
+
+	for {
+		dv.Views = append(dv.Views, p.parseTableName())
+
+		if !p.consume(COMMA) {
+			break
+		}
+	}
+
+	switch {
+	case p.consume(RESTRICT):
+		dv.Behavior = sqlast.RestrictDrop
+	case p.consume(CASCADE):
+		dv.Behavior = sqlast.CascadeDrop
+	}
+
+	return dv
+}
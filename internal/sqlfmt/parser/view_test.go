@@ -0,0 +1,165 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+)
+
+func TestParseCreateView(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"plain", "CREATE VIEW v AS SELECT a FROM t", "CREATE VIEW v AS SELECT a FROM t"},
+		{"or replace", "create or replace view v as select 1", "CREATE OR REPLACE VIEW v AS SELECT 1"},
+		{"column list", "CREATE VIEW db.v (a, b) AS SELECT x, y FROM t", "CREATE VIEW db.v (a, b) AS SELECT x, y FROM t"},
+		{"union body", "CREATE VIEW v AS SELECT 1 UNION SELECT 2", "CREATE VIEW v AS SELECT 1 UNION SELECT 2"},
+		{"with body", "CREATE VIEW v AS WITH c AS (SELECT 1) SELECT * FROM c",
+			"CREATE VIEW v AS WITH c AS (SELECT 1) SELECT * FROM c"},
+		{"algorithm", "CREATE ALGORITHM=temptable VIEW v AS SELECT 1",
+			"CREATE ALGORITHM = TEMPTABLE VIEW v AS SELECT 1"},
+		{"quoted definer", "CREATE DEFINER = 'app'@'%' VIEW v AS SELECT 1",
+			"CREATE DEFINER = 'app'@'%' VIEW v AS SELECT 1"},
+		{"unquoted definer", "CREATE DEFINER = app@localhost VIEW v AS SELECT 1",
+			"CREATE DEFINER = app@localhost VIEW v AS SELECT 1"},
+		{"current_user definer", "CREATE DEFINER = CURRENT_USER() VIEW v AS SELECT 1",
+			"CREATE DEFINER = CURRENT_USER VIEW v AS SELECT 1"},
+		{"sql security", "CREATE SQL SECURITY invoker VIEW v AS SELECT 1",
+			"CREATE SQL SECURITY INVOKER VIEW v AS SELECT 1"},
+		{
+			"all options",
+			"CREATE OR REPLACE ALGORITHM = MERGE DEFINER = 'app'@'%' SQL SECURITY DEFINER VIEW v AS SELECT 1",
+			"CREATE OR REPLACE ALGORITHM = MERGE DEFINER = 'app'@'%' SQL SECURITY DEFINER VIEW v AS SELECT 1",
+		},
+		{"check option", "CREATE VIEW v AS SELECT a FROM t WITH CHECK OPTION",
+			"CREATE VIEW v AS SELECT a FROM t WITH CHECK OPTION"},
+		{"cascaded check option", "CREATE VIEW v AS SELECT a FROM t with cascaded check option",
+			"CREATE VIEW v AS SELECT a FROM t WITH CASCADED CHECK OPTION"},
+		{"local check option", "CREATE VIEW v AS SELECT a FROM t WITH LOCAL CHECK OPTION",
+			"CREATE VIEW v AS SELECT a FROM t WITH LOCAL CHECK OPTION"},
+		{"group by before check option", "CREATE VIEW v AS SELECT a FROM t GROUP BY a WITH CHECK OPTION",
+			"CREATE VIEW v AS SELECT a FROM t GROUP BY a WITH CHECK OPTION"},
+		{"rollup", "CREATE VIEW v AS SELECT a FROM t GROUP BY a WITH ROLLUP",
+			"CREATE VIEW v AS SELECT a FROM t GROUP BY a WITH ROLLUP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := parser.ParseCreateView(tt.in)
+			if err != nil {
+				t.Fatalf("ParseCreateView(%q) error = %v", tt.in, err)
+			}
+
+			if got := v.String(); got != tt.want {
+				t.Errorf("ParseCreateView(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCreateView_errors(t *testing.T) {
+	tests := []string{
+		"CREATE VIEW",
+		"CREATE VIEW v",
+		"CREATE VIEW v AS",
+		"CREATE VIEW v () AS SELECT 1",
+		"CREATE VIEW v AS INSERT INTO t VALUES (1)",
+		"CREATE OR VIEW v AS SELECT 1",
+		"CREATE ALGORITHM = FAST VIEW v AS SELECT 1",
+		"CREATE ALGORITHM MERGE VIEW v AS SELECT 1",
+		"CREATE DEFINER = 'app'@ VIEW v AS SELECT 1",
+		"CREATE SQL SECURITY NOBODY VIEW v AS SELECT 1",
+		"CREATE VIEW v AS SELECT 1 WITH OPTION",
+		"CREATE VIEW v AS SELECT 1 WITH CHECK",
+		"CREATE VIEW v AS SELECT 1 WITH CHECK OPTION extra",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseCreateView(in); err == nil {
+			t.Errorf("ParseCreateView(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseAlterView(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"plain", "alter view v as select 1", "ALTER VIEW v AS SELECT 1"},
+		{"options", "ALTER ALGORITHM = UNDEFINED SQL SECURITY INVOKER VIEW v (a) AS SELECT 1 WITH CHECK OPTION",
+			"ALTER ALGORITHM = UNDEFINED SQL SECURITY INVOKER VIEW v (a) AS SELECT 1 WITH CHECK OPTION"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := parser.ParseAlterView(tt.in)
+			if err != nil {
+				t.Fatalf("ParseAlterView(%q) error = %v", tt.in, err)
+			}
+
+			if got := v.String(); got != tt.want {
+				t.Errorf("ParseAlterView(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAlterView_errors(t *testing.T) {
+	tests := []string{
+		"ALTER VIEW",
+		"ALTER VIEW v SELECT 1",
+		"ALTER OR REPLACE VIEW v AS SELECT 1",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseAlterView(in); err == nil {
+			t.Errorf("ParseAlterView(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseDropView(t *testing.T) {
+	tests := []struct{ name, in, want string }{
+		{"single", "drop view v", "DROP VIEW v"},
+		{"if exists list", "DROP VIEW IF EXISTS v, db.w", "DROP VIEW IF EXISTS v, db.w"},
+		{"cascade", "drop view v1 cascade", "DROP VIEW v1 CASCADE"},
+		{"if exists list restrict", "DROP VIEW IF EXISTS v1, v2 RESTRICT", "DROP VIEW IF EXISTS v1, v2 RESTRICT"},
+		{"if exists list cascade", "DROP VIEW IF EXISTS v1, v2 CASCADE", "DROP VIEW IF EXISTS v1, v2 CASCADE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := parser.ParseDropView(tt.in)
+			if err != nil {
+				t.Fatalf("ParseDropView(%q) error = %v", tt.in, err)
+			}
+
+			if got := v.String(); got != tt.want {
+				t.Errorf("ParseDropView(%q).String() = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDropView_errors(t *testing.T) {
+	tests := []string{
+		"DROP VIEW",
+		"DROP VIEW v,",
+		"DROP VIEW v extra",
+		"DROP VIEW v CASCADE RESTRICT",
+		"DROP VIEW v RESTRICT, w",
+	}
+
+	for _, in := range tests {
+		if _, err := parser.ParseDropView(in); err == nil {
+			t.Errorf("ParseDropView(%q) expected error, got nil", in)
+		}
+	}
+}
+
+func TestParseView_columnNamedView(t *testing.T) {
+	s, err := parser.ParseStatement("SELECT view FROM t")
+	if err != nil {
+		t.Fatalf("ParseStatement error = %v", err)
+	}
+
+	if got, want := s.String(), "SELECT view FROM t"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,302 @@
+package sqlfmt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+)
+
+// verbRe matches one fmt verb at the start of the input: flags, an optional
+// explicit argument index, width, and precision, then the verb letter (or
+// the second % of a literal %%).
+var verbRe = regexp.MustCompile(`^%[-+# 0]*(?:\[\d+\])?(?:\*|\d+)?(?:\.(?:\*|\d+)?)?(?:\[\d+\])?[a-zA-Z%]`)
+
+// Keywords after which a verb stands for an identifier or a parenthesized
+// list rather than an expression. Matching is by the last word before the
+// verb, so it is a heuristic: a verb in a position it misjudges simply makes
+// the template fail to parse, leaving it unchanged.
+var (
+	identifierVerbKeywords = map[string]bool{
+		"FROM": true, "JOIN": true, "INTO": true, "UPDATE": true, "TABLE": true,
+		"EXISTS": true, "INDEX": true, "AS": true, "BY": true, "ON": true,
+	}
+	listVerbKeywords = map[string]bool{"VALUES": true, "IN": true}
+)
+
+// maskedText is a piece of a template that isn't SQL (a fmt verb, a
+// text/template action) and the sentinel that stands in for it while the
+// template is parsed and formatted.
+type maskedText struct {
+	text     string
+	sentinel string
+}
+
+// formatPrintfTemplate formats sql, a fmt format string, with its verbs
+// masked (see Options.PrintfTemplate).
+func formatPrintfTemplate(sql string, opts Options, mode parser.SQLMode) (string, bool) {
+	masked, verbs, ok := maskVerbs(sql, mode)
+	if !ok {
+		return sql, false
+	}
+
+	result, ok := formatSQLText(masked, opts, mode)
+	if !ok {
+		return sql, false
+	}
+
+	if result, ok = unmaskVerbs(result, verbs, mode); !ok {
+		return sql, false
+	}
+
+	return result, true
+}
+
+// maskVerbs replaces every fmt verb of the template sql that sits outside a
+// quoted string or identifier with a sentinel valid in its position (see
+// positionSentinel), and every literal %% there with the % it prints. Verbs
+// inside quotes are left alone: they are part of the literal's text, which
+// the formatter never changes. ok is false when a % outside quotes doesn't
+// start a verb, since unmaskVerbs couldn't tell it apart from a %% after
+// formatting.
+func maskVerbs(sql string, mode parser.SQLMode) (string, []maskedText, bool) {
+	var (
+		b     strings.Builder
+		verbs []maskedText
+	)
+
+	last := 0
+
+	for _, i := range unquotedPercents(sql, mode) {
+		if i < last {
+			continue // the second % of a %%
+		}
+
+		verb := verbRe.FindString(sql[i:])
+		if verb == "" {
+			return sql, nil, false
+		}
+
+		b.WriteString(sql[last:i])
+		last = i + len(verb)
+
+		if verb == "%%" {
+			b.WriteString("%")
+
+			continue
+		}
+
+		sentinel := positionSentinel(fmt.Sprintf("_sqla_fv_%d_", len(verbs)), b.String(), sql[last:])
+		verbs = append(verbs, maskedText{text: verb, sentinel: sentinel})
+		b.WriteString(sentinel)
+	}
+
+	b.WriteString(sql[last:])
+
+	return b.String(), verbs, true
+}
+
+// unmaskVerbs undoes maskVerbs on the formatted output: every % outside
+// quotes (a modulo operator, from a %% in the template) is doubled again,
+// and every sentinel is replaced by its original verb text. ok is false
+// when a sentinel doesn't appear exactly once.
+func unmaskVerbs(formatted string, verbs []maskedText, mode parser.SQLMode) (string, bool) {
+	var b strings.Builder
+
+	last := 0
+
+	for _, i := range unquotedPercents(formatted, mode) {
+		b.WriteString(formatted[last : i+1])
+		b.WriteString("%")
+		last = i + 1
+	}
+
+	b.WriteString(formatted[last:])
+
+	return restoreMasked(b.String(), verbs)
+}
+
+// restoreMasked replaces every sentinel in s by the text it masked. ok is
+// false when a sentinel doesn't appear exactly once.
+func restoreMasked(s string, masked []maskedText) (string, bool) {
+	for _, m := range masked {
+		if strings.Count(s, m.sentinel) != 1 {
+			return s, false
+		}
+
+		s = strings.Replace(s, m.sentinel, m.text, 1)
+	}
+
+	return s, true
+}
+
+// positionSentinel returns the sentinel for a masked piece of a template
+// (a verb, in the examples below), given the identifier it is numbered by,
+// the masked text before it, and the template text after it:
+//
+//   - a verb glued to an identifier or a dot (logs_%s, %s.id) is part of
+//     a name, and becomes the bare identifier, e.g. _sqla_fv_0_;
+//   - a verb after FROM, JOIN, AS, ... names a table, column, or alias, and
+//     becomes the same identifier;
+//   - a verb after VALUES or IN stands for a whole parenthesized list, and
+//     becomes (:_sqla_fv_0_);
+//   - any other verb is an expression, and becomes the placeholder
+//     :_sqla_fv_0_, just like a ? (see replacePlaceholders).
+//
+// Identifiers end in an underscore, which keeps _sqla_fv_1_ from matching
+// the front of _sqla_fv_10_, or of an identifier the verb is glued to (%s1).
+func positionSentinel(ident, before, after string) string {
+	switch word := strings.ToUpper(lastWord(before)); {
+	case gluedToName(before, after):
+		return ident
+	case identifierVerbKeywords[word]:
+		return ident
+	case listVerbKeywords[word]:
+		return "(:" + ident + ")"
+	default:
+		return ":" + ident
+	}
+}
+
+// gluedToName reports whether a verb between before and after is part of a
+// larger name: it directly follows or precedes an identifier character or
+// a dot.
+func gluedToName(before, after string) bool {
+	return (before != "" && isNameByte(before[len(before)-1])) ||
+		(after != "" && isNameByte(after[0]))
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c == '$' || c == '.' ||
+		('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// lastWord returns the trailing run of identifier characters of s after
+// trailing whitespace is dropped, or "" if s ends in anything else.
+func lastWord(s string) string {
+	s = strings.TrimRight(s, " \t\r\n")
+
+	i := len(s)
+	for i > 0 && isNameByte(s[i-1]) && s[i-1] != '.' {
+		i--
+	}
+
+	return s[i:]
+}
+
+// unquotedPercents returns the byte offset of every % in s that isn't
+// inside a quoted string or identifier, or a comment (see quoteState).
+func unquotedPercents(s string, mode parser.SQLMode) []int {
+	var offsets []int
+
+	q := quoteState{mode: mode}
+
+	for i := 0; i < len(s); {
+		next, unquoted := q.advance(s, i)
+		if unquoted && s[i] == '%' {
+			offsets = append(offsets, i)
+		}
+
+		i = next
+	}
+
+	return offsets
+}
+
+// quoteState tracks whether a left-to-right scan of SQL text is inside a
+// quoted string ('...', "...") or quoted identifier (`...`, and "..." under
+// ANSI_QUOTES), following the lexer's quoting rules for mode: a doubled
+// quote character stays inside the quotes, and so does a backslash-escaped
+// one in a string unless mode disables backslash escapes. It tracks
+// comments the same way, since the formatter copies them verbatim like
+// quoted text: a -- or # line comment, and a /* ... */ block comment,
+// executable /*! ... */ ones included, but not a /*+ ... */ optimizer hint.
+type quoteState struct {
+	mode  parser.SQLMode
+	quote byte
+
+	// commentEnd is the text ending the comment the scan is inside, or "".
+	commentEnd string
+}
+
+// advance scans the character of s at i (two, for an escape sequence or a
+// doubled quote, or a comment delimiter), returning the offset after it and
+// whether it is outside quotes and comments. An opening or closing quote
+// counts as inside, and so does a comment's delimiter.
+func (q *quoteState) advance(s string, i int) (int, bool) {
+	if next, ok := q.advanceComment(s, i); ok {
+		return next, false
+	}
+
+	c := s[i]
+
+	switch {
+	case q.quote == 0 && (c == '\'' || c == '"' || c == '`'):
+		q.quote = c
+
+		return i + 1, false
+	case q.quote == 0:
+		return i + 1, true
+	case c == '\\' && q.inString() && !q.mode.Has(parser.ModeNoBackslashEscapes):
+		return min(i+2, len(s)), false
+	case c == q.quote && i+1 < len(s) && s[i+1] == q.quote:
+		return i + 2, false
+	case c == q.quote:
+		q.quote = 0
+
+		return i + 1, false
+	default:
+		return i + 1, false
+	}
+}
+
+// advanceComment scans a comment delimiter or commented-out character of s
+// at i, if there is one there, returning the offset after it and true.
+func (q *quoteState) advanceComment(s string, i int) (int, bool) {
+	if q.commentEnd != "" {
+		if strings.HasPrefix(s[i:], q.commentEnd) {
+			i += len(q.commentEnd) - 1
+			q.commentEnd = ""
+		}
+
+		return i + 1, true
+	}
+
+	if q.quote != 0 {
+		return i, false
+	}
+
+	q.commentEnd = commentEnd(s[i:])
+	if q.commentEnd == "" {
+		return i, false
+	}
+
+	// Step past the whole opening delimiter, so the * of /* can't close it.
+	if s[i] == '#' {
+		return i + 1, true
+	}
+
+	return i + 2, true
+}
+
+// commentEnd returns the text ending the comment s starts with, or "" if it
+// doesn't start with one.
+func commentEnd(s string) string {
+	switch {
+	case s[0] == '#':
+		return "\n"
+	case len(s) > 2 && strings.HasPrefix(s, "--") && strings.IndexByte(" \t\r\n\f\v", s[2]) >= 0:
+		return "\n"
+	case strings.HasPrefix(s, "/*") && !strings.HasPrefix(s, "/*+"):
+		return "*/"
+	default:
+		return ""
+	}
+}
+
+// inString reports whether the scan is inside a quoted string rather than a
+// quoted identifier.
+func (q *quoteState) inString() bool {
+	return q.quote == '\'' || (q.quote == '"' && !q.mode.Has(parser.ModeANSIQuotes))
+}
@@ -0,0 +1,120 @@
+package sqlfmt_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
+)
+
+func TestFormatSQL_PrintfTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{
+			name: "identifier and expression verbs",
+			in:   "select %s from %s where id = %d limit %d",
+			want: join(
+				"SELECT",
+				"  %s",
+				"FROM",
+				"  %s",
+				"WHERE",
+				"  id = %d",
+				"LIMIT",
+				"  %d",
+			),
+			ok: true,
+		},
+		{
+			name: "verb glued to a name",
+			in:   "update logs_%s set a = ? where t.%s = %[1]v",
+			want: join(
+				"UPDATE",
+				"  logs_%s",
+				"SET",
+				"  a = ?",
+				"WHERE",
+				"  t.%s = %[1]v",
+			),
+			ok: true,
+		},
+		{
+			name: "list verbs",
+			in:   "select * from t where a in %s and b in (%s)",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  a IN %s",
+				"  AND b IN (%s)",
+			),
+			ok: true,
+		},
+		{
+			name: "verbs inside quotes are left alone",
+			in:   "select * from t where name like '%s%%' and c = %-5d",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  name LIKE '%s%%'",
+				"  AND c = %-5d",
+			),
+			ok: true,
+		},
+		{
+			name: "verbs inside comments are left alone",
+			in:   "select * from t where c = %d -- 100% sure",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  c = %d -- 100% sure",
+			),
+			ok: true,
+		},
+		{
+			name: "literal percent outside quotes",
+			in:   "select a %% b from t",
+			want: join(
+				"SELECT",
+				"  a %% b",
+				"FROM",
+				"  t",
+			),
+			ok: true,
+		},
+		{
+			name: "percent that is not a verb",
+			in:   "select a % 3 from t",
+			want: "select a % 3 from t",
+			ok:   false,
+		},
+		{
+			name: "verb in a position no sentinel fits",
+			in:   "select * from t %s",
+			want: "select * from t %s",
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLWithOptions(tt.in, sqlfmt.Options{Indent: 2, PrintfTemplate: true})
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package sqlfmt
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+)
+
+// identifierQuoteChar translates an Options.IdentifierQuote value into the
+// character quoted identifiers are rendered with, reporting whether
+// identifierQuote was a recognized value. The empty string selects
+// IdentifierQuoteBacktick, or IdentifierQuoteANSI when mode has
+// parser.ModeANSIQuotes.
+func identifierQuoteChar(identifierQuote string, mode parser.SQLMode) (byte, bool) {
+	switch identifierQuote {
+	case "":
+		if mode.Has(parser.ModeANSIQuotes) {
+			return '"', true
+		}
+
+		return '`', true
+	case IdentifierQuoteBacktick:
+		return '`', true
+	case IdentifierQuoteANSI:
+		return '"', true
+	default:
+		return 0, false
+	}
+}
+
+// requoteIdentifiers quotes every identifier in formatted, the formatted
+// output of input, that was quoted in input. The parser keeps only an
+// identifier's name, so the quotes are put back by position: for each name
+// quoted at least once in input, the nth time the name appears as a word in
+// formatted (outside quotes, matched case-sensitively) is quoted iff its
+// nth appearance in input was. ok is false when the two don't have the same
+// number of appearances of such a name, e.g. when a quoted `ORDER` column
+// sits next to an ORDER BY clause the formatter uppercased.
+func requoteIdentifiers(input, formatted string, mode parser.SQLMode, quote byte) (string, bool) {
+	tokens, ok := lexAll(input, mode)
+	if !ok {
+		return formatted, false
+	}
+
+	quoted := quotedNames(tokens)
+	if len(quoted) == 0 {
+		return formatted, true
+	}
+
+	var spans []span
+
+	for name, pattern := range quoted {
+		offsets := wordOffsets(formatted, name, mode)
+		if len(offsets) != len(pattern) {
+			return formatted, false
+		}
+
+		for i, offset := range offsets {
+			if pattern[i] {
+				spans = append(spans, span{start: offset, end: offset + len(name)})
+			}
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	var b strings.Builder
+
+	prev := 0
+
+	for _, s := range spans {
+		b.WriteString(formatted[prev:s.start])
+		b.WriteString(quoteIdentifier(formatted[s.start:s.end], quote))
+		prev = s.end
+	}
+
+	b.WriteString(formatted[prev:])
+
+	return b.String(), true
+}
+
+// quotedNames maps every name that appears as a quoted identifier in tokens
+// to whether each of its appearances as an identifier, quoted or bare, is
+// quoted, in order. A keyword token spelled the same isn't an appearance:
+// the formatter may change its case, and if it doesn't, requoteIdentifiers
+// finds one appearance too many in the output and gives up.
+func quotedNames(tokens []parser.Token) map[string][]bool {
+	quoted := make(map[string][]bool)
+
+	for _, tok := range tokens {
+		if tok.Type == parser.QuotedIdent {
+			quoted[tok.Literal] = nil
+		}
+	}
+
+	for _, tok := range tokens {
+		if _, ok := quoted[tok.Literal]; ok && (tok.Type == parser.IDENT || tok.Type == parser.QuotedIdent) {
+			quoted[tok.Literal] = append(quoted[tok.Literal], tok.Type == parser.QuotedIdent)
+		}
+	}
+
+	return quoted
+}
+
+// wordOffsets returns the offset of every appearance of name in s outside
+// quotes and comments that isn't part of a longer word.
+func wordOffsets(s, name string, mode parser.SQLMode) []int {
+	var offsets []int
+
+	q := quoteState{mode: mode}
+
+	for i := 0; i < len(s); {
+		if q.quote == 0 && q.commentEnd == "" && strings.HasPrefix(s[i:], name) &&
+			(i == 0 || !isWordByte(s[i-1])) &&
+			(i+len(name) == len(s) || !isWordByte(s[i+len(name)])) {
+			offsets = append(offsets, i)
+			i += len(name)
+
+			continue
+		}
+
+		i, _ = q.advance(s, i)
+	}
+
+	return offsets
+}
+
+func isWordByte(c byte) bool {
+	return isNameByte(c) && c != '.'
+}
+
+// quoteIdentifier quotes name with quote, doubling any quote character
+// inside it.
+func quoteIdentifier(name string, quote byte) string {
+	q := string(quote)
+
+	return q + strings.ReplaceAll(name, q, q+q) + q
+}
@@ -0,0 +1,109 @@
+package sqlfmt_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
+)
+
+func TestFormatSQL_QuotedIdentifiers(t *testing.T) {
+	in := "select `order`, `t`.`my col`, status from `t` where `order` = ? and order_id = 1 order by `order`"
+
+	tests := []struct {
+		name  string
+		quote string
+		in    string
+		want  string
+		ok    bool
+	}{
+		{
+			name:  "backticks are kept by default",
+			quote: "",
+			in:    in,
+			want: join(
+				"SELECT",
+				"  `order`,",
+				"  `t`.`my col`,",
+				"  status",
+				"FROM",
+				"  `t`",
+				"WHERE",
+				"  `order` = ?",
+				"  AND order_id = 1",
+				"ORDER BY",
+				"  `order`",
+			),
+			ok: true,
+		},
+		{
+			name:  "ansi double quotes",
+			quote: sqlfmt.IdentifierQuoteANSI,
+			in:    in,
+			want: join(
+				"SELECT",
+				`  "order",`,
+				`  "t"."my col",`,
+				"  status",
+				"FROM",
+				`  "t"`,
+				"WHERE",
+				`  "order" = ?`,
+				"  AND order_id = 1",
+				"ORDER BY",
+				`  "order"`,
+			),
+			ok: true,
+		},
+		{
+			name:  "quote characters inside a name are doubled",
+			quote: sqlfmt.IdentifierQuoteBacktick,
+			in:    "select `a``b` from t where x = 'a``b'",
+			want: join(
+				"SELECT",
+				"  `a``b`",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  x = 'a``b'",
+			),
+			ok: true,
+		},
+		{
+			name:  "names inside comments are left alone",
+			quote: sqlfmt.IdentifierQuoteBacktick,
+			in:    "select `order` from t -- order doesn't need quotes here",
+			want: join(
+				"SELECT",
+				"  `order`",
+				"FROM",
+				"  t -- order doesn't need quotes here",
+			),
+			ok: true,
+		},
+		{
+			name:  "quoted name spelled like an uppercased keyword",
+			quote: sqlfmt.IdentifierQuoteBacktick,
+			in:    "select `ORDER` from t order by id",
+			want:  "select `ORDER` from t order by id",
+			ok:    false,
+		},
+		{
+			name:  "unknown quote style",
+			quote: "brackets",
+			in:    "select `a` from t",
+			want:  "select `a` from t",
+			ok:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLWithOptions(tt.in, sqlfmt.Options{Indent: 2, IdentifierQuote: tt.quote})
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
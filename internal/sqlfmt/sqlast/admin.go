@@ -2,6 +2,8 @@ package sqlast
 
 // ShowTables represents a SHOW TABLES statement.
 type ShowTables struct {
+	Comments
+
 	Database TableIdent // empty if no FROM database clause
 	Like     Expr       // nil if no LIKE pattern
 }
@@ -23,6 +25,8 @@ func (s *ShowTables) String() string {
 
 // ShowCreateTable represents a SHOW CREATE TABLE statement.
 type ShowCreateTable struct {
+	Comments
+
 	Table TableName
 }
 
@@ -33,6 +37,8 @@ func (s *ShowCreateTable) String() string {
 
 // ShowColumns represents a SHOW COLUMNS FROM statement.
 type ShowColumns struct {
+	Comments
+
 	Table TableName
 }
 
@@ -43,6 +49,8 @@ func (s *ShowColumns) String() string {
 
 // ShowIndex represents a SHOW INDEX FROM statement.
 type ShowIndex struct {
+	Comments
+
 	Table TableName
 }
 
@@ -52,13 +60,17 @@ func (s *ShowIndex) String() string {
 }
 
 // ShowDatabases represents a SHOW DATABASES statement.
-type ShowDatabases struct{}
+type ShowDatabases struct {
+	Comments
+}
 
 // String returns ShowDatabases's SQL text.
 func (*ShowDatabases) String() string { return "SHOW DATABASES" }
 
 // ShowVariables represents a SHOW VARIABLES statement.
 type ShowVariables struct {
+	Comments
+
 	Like Expr // nil if no LIKE pattern
 }
 
@@ -75,6 +87,8 @@ func (s *ShowVariables) String() string {
 
 // ShowStatus represents a SHOW STATUS statement.
 type ShowStatus struct {
+	Comments
+
 	Like Expr // nil if no LIKE pattern
 }
 
@@ -91,6 +105,8 @@ func (s *ShowStatus) String() string {
 
 // Describe represents a DESCRIBE statement.
 type Describe struct {
+	Comments
+
 	Table  TableName
 	Column ColIdent // empty if no column name is given
 }
@@ -133,6 +149,8 @@ func (f ExplainFormat) String() string {
 // Explain represents an EXPLAIN statement wrapping a SELECT statement (or a
 // UNION of SELECT branches, optionally preceded by a WITH clause).
 type Explain struct {
+	Comments
+
 	Format    ExplainFormat
 	Statement Statement
 }
@@ -150,6 +168,8 @@ func (e *Explain) String() string {
 
 // Use represents a USE statement.
 type Use struct {
+	Comments
+
 	Database TableIdent
 }
 
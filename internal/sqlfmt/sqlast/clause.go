@@ -7,6 +7,8 @@ import (
 
 // Where represents a WHERE or HAVING clause.
 type Where struct {
+	Comments
+
 	Expr Expr
 }
 
@@ -21,6 +23,8 @@ func (w *Where) String() string {
 
 // GroupBy represents a GROUP BY clause.
 type GroupBy struct {
+	Comments
+
 	Exprs      []Expr
 	WithRollup bool
 }
@@ -46,6 +50,8 @@ func (g *GroupBy) String() string {
 
 // Order represents a single ORDER BY item.
 type Order struct {
+	Comments
+
 	Expr      Expr
 	Direction OrderDirection
 }
@@ -79,6 +85,8 @@ func (o OrderBy) String() string {
 
 // Limit represents a LIMIT clause.
 type Limit struct {
+	Comments
+
 	Offset   Expr
 	Rowcount Expr
 }
@@ -155,6 +163,237 @@ func (t LockWaitType) String() string {
 	}
 }
 
+// SelectModifiers is the set of MySQL-specific modifiers that may follow
+// SELECT (and its optional DISTINCT), such as SQL_CALC_FOUND_ROWS or
+// STRAIGHT_JOIN. The zero value is the empty set.
+type SelectModifiers uint8
+
+const (
+	HighPriorityModifier SelectModifiers = 1 << iota
+	StraightJoinModifier
+	SQLSmallResultModifier
+	SQLBigResultModifier
+	SQLBufferResultModifier
+	SQLNoCacheModifier
+	SQLCalcFoundRowsModifier
+)
+
+// selectModifierNames lists every modifier with its SQL text, in the order
+// MySQL's grammar documents them; String renders in this order regardless
+// of the order they were written in.
+var selectModifierNames = [...]struct {
+	mod  SelectModifiers
+	name string
+}{
+	{HighPriorityModifier, "HIGH_PRIORITY"},
+	{StraightJoinModifier, "STRAIGHT_JOIN"},
+	{SQLSmallResultModifier, "SQL_SMALL_RESULT"},
+	{SQLBigResultModifier, "SQL_BIG_RESULT"},
+	{SQLBufferResultModifier, "SQL_BUFFER_RESULT"},
+	{SQLNoCacheModifier, "SQL_NO_CACHE"},
+	{SQLCalcFoundRowsModifier, "SQL_CALC_FOUND_ROWS"},
+}
+
+// SelectModifierByName returns the modifier whose SQL text is name
+// (case-insensitive), reporting whether one exists.
+func SelectModifierByName(name string) (SelectModifiers, bool) {
+	for _, m := range selectModifierNames {
+		if strings.EqualFold(m.name, name) {
+			return m.mod, true
+		}
+	}
+
+	return 0, false
+}
+
+// String returns SelectModifiers' SQL text: the set's modifiers separated by
+// spaces, or "" for the empty set.
+func (m SelectModifiers) String() string {
+	var names []string
+
+	for _, n := range selectModifierNames {
+		if m&n.mod != 0 {
+			names = append(names, n.name)
+		}
+	}
+
+	return strings.Join(names, " ")
+}
+
+// IntoType represents the kind of target a SELECT ... INTO clause writes to.
+type IntoType int8
+
+const (
+	IntoVariables IntoType = iota
+	IntoOutfile
+	IntoDumpfile
+)
+
+// IntoPosition represents where a SELECT's INTO clause was written. MySQL
+// accepts it right after the select expressions, after the LIMIT clause
+// (before any locking clause), or after the locking clause; the position is
+// preserved so the statement round-trips as written.
+type IntoPosition int8
+
+const (
+	IntoBeforeFrom IntoPosition = iota
+	IntoBeforeLock
+	IntoAfterLock
+)
+
+// SelectInto represents a SELECT's INTO clause: a list of variables, or an
+// OUTFILE/DUMPFILE target. Charset and Export apply only to OUTFILE.
+type SelectInto struct {
+	Type      IntoType
+	Position  IntoPosition
+	Variables []Expr
+	File      Expr
+	Charset   string
+	Export    *ExportOptions
+}
+
+// String returns SelectInto's SQL text.
+func (s *SelectInto) String() string {
+	if s.Type == IntoVariables {
+		strs := make([]string, len(s.Variables))
+		for i, v := range s.Variables {
+			strs[i] = v.String()
+		}
+
+		return "INTO " + strings.Join(strs, ", ")
+	}
+
+	parts := []string{"INTO " + s.TargetString()}
+
+	if s.Charset != "" {
+		parts = append(parts, "CHARACTER SET "+s.Charset)
+	}
+
+	if export := s.Export.String(); export != "" {
+		parts = append(parts, export)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// TargetString returns the `OUTFILE 'file'` or `DUMPFILE 'file'` target,
+// without the leading INTO or OUTFILE's CHARACTER SET and export options. It
+// returns "" for an INTO variable list.
+func (s *SelectInto) TargetString() string {
+	switch s.Type {
+	case IntoOutfile:
+		return "OUTFILE " + s.File.String()
+	case IntoDumpfile:
+		return "DUMPFILE " + s.File.String()
+	default:
+		return ""
+	}
+}
+
+// ExportOptions represents the FIELDS (or COLUMNS) and LINES options of a
+// SELECT ... INTO OUTFILE clause. A nil Expr means the option was omitted.
+type ExportOptions struct {
+	ColumnsKeyword     bool // COLUMNS was written instead of FIELDS
+	FieldsTerminatedBy Expr
+	OptionallyEnclosed bool
+	FieldsEnclosedBy   Expr
+	FieldsEscapedBy    Expr
+	LinesStartingBy    Expr
+	LinesTerminatedBy  Expr
+}
+
+// String returns ExportOptions' SQL text.
+func (e *ExportOptions) String() string {
+	if e == nil {
+		return ""
+	}
+
+	var parts []string
+
+	if fields := e.FieldsString(); fields != "" {
+		parts = append(parts, fields)
+	}
+
+	if lines := e.LinesString(); lines != "" {
+		parts = append(parts, lines)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// FieldsString returns the FIELDS (or COLUMNS) option's SQL text, or "" if
+// it has no sub-options.
+func (e *ExportOptions) FieldsString() string {
+	var parts []string
+
+	if e.FieldsTerminatedBy != nil {
+		parts = append(parts, "TERMINATED BY "+e.FieldsTerminatedBy.String())
+	}
+
+	if e.FieldsEnclosedBy != nil {
+		enclosed := "ENCLOSED BY " + e.FieldsEnclosedBy.String()
+		if e.OptionallyEnclosed {
+			enclosed = "OPTIONALLY " + enclosed
+		}
+
+		parts = append(parts, enclosed)
+	}
+
+	if e.FieldsEscapedBy != nil {
+		parts = append(parts, "ESCAPED BY "+e.FieldsEscapedBy.String())
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	keyword := "FIELDS"
+	if e.ColumnsKeyword {
+		keyword = "COLUMNS"
+	}
+
+	return keyword + " " + strings.Join(parts, " ")
+}
+
+// LinesString returns the LINES option's SQL text, or "" if it has no
+// sub-options.
+func (e *ExportOptions) LinesString() string {
+	var parts []string
+
+	if e.LinesStartingBy != nil {
+		parts = append(parts, "STARTING BY "+e.LinesStartingBy.String())
+	}
+
+	if e.LinesTerminatedBy != nil {
+		parts = append(parts, "TERMINATED BY "+e.LinesTerminatedBy.String())
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return "LINES " + strings.Join(parts, " ")
+}
+
+// RowAlias represents the `AS alias [(col, ...)]` row alias following an
+// INSERT's VALUES or SET clause, which names the new row (and optionally its
+// columns) for ON DUPLICATE KEY UPDATE to reference in place of the
+// deprecated VALUES(col).
+type RowAlias struct {
+	Name    TableIdent
+	Columns Columns
+}
+
+// String returns RowAlias's SQL text.
+func (r *RowAlias) String() string {
+	s := "AS " + r.Name.String()
+	if len(r.Columns) > 0 {
+		s += " (" + r.Columns.String() + ")"
+	}
+
+	return s
+}
+
 // InsertAction represents INSERT or REPLACE.
 type InsertAction int8
 
@@ -350,6 +589,12 @@ type Values [][]Expr
 
 // String returns Values's SQL text.
 func (v Values) String() string {
+	return "VALUES " + v.joinRows("")
+}
+
+// joinRows renders each row as a parenthesized list preceded by prefix
+// ("ROW" for a table value constructor), joined by commas.
+func (v Values) joinRows(prefix string) string {
 	rows := make([]string, len(v))
 
 	for i, row := range v {
@@ -358,14 +603,16 @@ func (v Values) String() string {
 			vals[j] = val.String()
 		}
 
-		rows[i] = "(" + strings.Join(vals, ", ") + ")"
+		rows[i] = prefix + "(" + strings.Join(vals, ", ") + ")"
 	}
 
-	return "VALUES " + strings.Join(rows, ", ")
+	return strings.Join(rows, ", ")
 }
 
 // UpdateExpr represents a SET assignment in an UPDATE statement.
 type UpdateExpr struct {
+	Comments
+
 	Name *ColName
 	Expr Expr
 }
@@ -438,8 +685,11 @@ func (o *OverClause) String() string {
 	return "OVER (" + spec + ")"
 }
 
-// WindowSpecification represents the content of an OVER clause.
+// WindowSpecification represents the content of an OVER clause or of a
+// named window definition. Name, when set, is the existing window the
+// specification inherits from, as in OVER (w ORDER BY x).
 type WindowSpecification struct {
+	Name            ColIdent
 	PartitionClause []Expr
 	OrderClause     OrderBy
 	FrameClause     *FrameClause
@@ -453,6 +703,10 @@ func (w *WindowSpecification) String() string {
 
 	var parts []string
 
+	if !w.Name.IsEmpty() {
+		parts = append(parts, w.Name.String())
+	}
+
 	if len(w.PartitionClause) > 0 {
 		exprs := make([]string, len(w.PartitionClause))
 		for i, e := range w.PartitionClause {
@@ -478,6 +732,30 @@ func (w *WindowSpecification) String() string {
 	return strings.Join(parts, " ")
 }
 
+// NamedWindow represents one name AS (spec) definition of a WINDOW clause.
+type NamedWindow struct {
+	Name ColIdent
+	Spec *WindowSpecification
+}
+
+// String returns NamedWindow's SQL text.
+func (n *NamedWindow) String() string {
+	return n.Name.String() + " AS (" + n.Spec.String() + ")"
+}
+
+// NamedWindows represents a SELECT's WINDOW clause.
+type NamedWindows []*NamedWindow
+
+// String returns NamedWindows' SQL text.
+func (w NamedWindows) String() string {
+	strs := make([]string, len(w))
+	for i, window := range w {
+		strs[i] = window.String()
+	}
+
+	return "WINDOW " + strings.Join(strs, ", ")
+}
+
 // FrameClause represents a window frame specification.
 type FrameClause struct {
 	Unit  FrameUnitType
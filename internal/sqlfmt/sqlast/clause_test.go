@@ -94,6 +94,65 @@ func TestLockWaitType_String(t *testing.T) {
 	assertEqual(t, "SKIP LOCKED", sqlast.SkipLockedType.String())
 }
 
+func TestSelectModifiers_String(t *testing.T) {
+	assertEqual(t, "", sqlast.SelectModifiers(0).String())
+	assertEqual(t, "SQL_CALC_FOUND_ROWS", sqlast.SQLCalcFoundRowsModifier.String())
+	assertEqual(t, "HIGH_PRIORITY SQL_NO_CACHE",
+		(sqlast.SQLNoCacheModifier | sqlast.HighPriorityModifier).String())
+
+	mod, ok := sqlast.SelectModifierByName("sql_big_result")
+	if !ok || mod != sqlast.SQLBigResultModifier {
+		t.Errorf("SelectModifierByName(sql_big_result) = %v, %v", mod, ok)
+	}
+
+	if _, ok := sqlast.SelectModifierByName("sql_cache"); ok {
+		t.Error("SelectModifierByName(sql_cache) reported a modifier")
+	}
+}
+
+func TestSelectInto_String(t *testing.T) {
+	tests := []struct {
+		name string
+		into *sqlast.SelectInto
+		want string
+	}{
+		{"variables", &sqlast.SelectInto{Variables: exprs("@a", "b")}, "INTO @a, b"},
+		{"dumpfile", &sqlast.SelectInto{Type: sqlast.IntoDumpfile, File: lit("'/tmp/f'")}, "INTO DUMPFILE '/tmp/f'"},
+		{"outfile", &sqlast.SelectInto{Type: sqlast.IntoOutfile, File: lit("'/tmp/f'")}, "INTO OUTFILE '/tmp/f'"},
+		{
+			"outfile with options",
+			&sqlast.SelectInto{
+				Type:    sqlast.IntoOutfile,
+				File:    lit("'/tmp/f'"),
+				Charset: "utf8mb4",
+				Export: &sqlast.ExportOptions{
+					FieldsTerminatedBy: lit("','"),
+					OptionallyEnclosed: true,
+					FieldsEnclosedBy:   lit(`'"'`),
+					LinesTerminatedBy:  lit(`'\n'`),
+				},
+			},
+			`INTO OUTFILE '/tmp/f' CHARACTER SET utf8mb4 FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '"' ` +
+				`LINES TERMINATED BY '\n'`,
+		},
+		{
+			"columns keyword",
+			&sqlast.SelectInto{
+				Type:   sqlast.IntoOutfile,
+				File:   lit("'f'"),
+				Export: &sqlast.ExportOptions{ColumnsKeyword: true, FieldsEscapedBy: lit("'!'")},
+			},
+			"INTO OUTFILE 'f' COLUMNS ESCAPED BY '!'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, tt.want, tt.into.String())
+		})
+	}
+}
+
 func TestInsertAction_String(t *testing.T) {
 	assertEqual(t, "INSERT", sqlast.InsertAct.String())
 	assertEqual(t, "REPLACE", sqlast.ReplaceAct.String())
@@ -184,6 +243,11 @@ func TestColumns_String(t *testing.T) {
 	assertEqual(t, "id, name, email", c.String())
 }
 
+func TestRowAlias_String(t *testing.T) {
+	assertEqual(t, "AS new", (&sqlast.RowAlias{Name: "new"}).String())
+	assertEqual(t, "AS new (x, y)", (&sqlast.RowAlias{Name: "new", Columns: sqlast.Columns{"x", "y"}}).String())
+}
+
 func TestValues_String(t *testing.T) {
 	v := sqlast.Values{
 		{lit("1"), lit("'a'")},
@@ -286,6 +350,10 @@ func TestWindowSpecification_String(t *testing.T) {
 				Start: &sqlast.FramePoint{Type: sqlast.UnboundedPrecedingType},
 			},
 		}, "ORDER BY id ROWS UNBOUNDED PRECEDING"},
+		{"inherited name", &sqlast.WindowSpecification{
+			Name:        "w",
+			OrderClause: sqlast.OrderBy{{Expr: lit("id")}},
+		}, "w ORDER BY id"},
 	}
 
 	for _, tt := range tests {
@@ -295,6 +363,16 @@ func TestWindowSpecification_String(t *testing.T) {
 	}
 }
 
+func TestNamedWindows_String(t *testing.T) {
+	windows := sqlast.NamedWindows{
+		{Name: "w1", Spec: &sqlast.WindowSpecification{PartitionClause: exprs("dept")}},
+		{Name: "w2", Spec: &sqlast.WindowSpecification{Name: "w1"}},
+		{Name: "w3", Spec: &sqlast.WindowSpecification{}},
+	}
+
+	assertEqual(t, "WINDOW w1 AS (PARTITION BY dept), w2 AS (w1), w3 AS ()", windows.String())
+}
+
 func TestFrameClause_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,53 @@
+package sqlast
+
+import "strings"
+
+// Comment is an ordinary SQL comment — a -- or # line comment, or a
+// /* ... */ block comment — carried on the node it was written next to so
+// the formatter can write it back. No String method renders one: comments
+// are the formatter's business, and a node's String is its SQL alone.
+type Comment struct {
+	// Text is the comment as written, delimiters included, less a line
+	// comment's trailing whitespace.
+	Text string
+
+	// OwnLine reports whether the comment started its own line, rather than
+	// following SQL or another comment on the same line.
+	OwnLine bool
+}
+
+// IsLine reports whether c is a line comment, which runs to the end of its
+// line, so nothing can follow it there.
+func (c Comment) IsLine() bool {
+	return strings.HasPrefix(c.Text, "--") || strings.HasPrefix(c.Text, "#")
+}
+
+// Comments holds the comments a node carries: Leading were written on their
+// own lines before it, and Trailing after its first token, up to the end of
+// the line its last token is on. A comment inside a node that carries none
+// of its own belongs to the nearest enclosing node that does.
+type Comments struct {
+	Leading  []Comment
+	Trailing []Comment
+}
+
+// NodeComments returns c, so a node embedding Comments implements Commented.
+func (c *Comments) NodeComments() *Comments { return c }
+
+// IsEmpty reports whether c holds no comments.
+func (c Comments) IsEmpty() bool {
+	return len(c.Leading) == 0 && len(c.Trailing) == 0
+}
+
+// Commented is implemented by the nodes that carry comments: every
+// statement, select item, and table expression, and the clauses and list
+// items the formatter writes on lines of their own.
+type Commented interface {
+	NodeComments() *Comments
+}
+
+// ClauseComments holds the comments written around a statement's clause
+// keywords, keyed by the keyword as the formatter writes it (SELECT, FROM,
+// ORDER BY, ...): Leading on their own lines before the keyword, and
+// Trailing after it on its line.
+type ClauseComments map[string]Comments
@@ -0,0 +1,36 @@
+package sqlast_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+func TestComment_IsLine(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"-- dash", true},
+		{"# hash", true},
+		{"/* block */", false},
+		{"/* spanning\nlines */", false},
+	}
+
+	for _, tt := range tests {
+		if got := (sqlast.Comment{Text: tt.text}).IsLine(); got != tt.want {
+			t.Errorf("Comment{Text: %q}.IsLine() = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestComments_IsEmpty(t *testing.T) {
+	if !(sqlast.Comments{}).IsEmpty() {
+		t.Error("Comments{}.IsEmpty() = false, want true")
+	}
+
+	c := sqlast.Comments{Trailing: []sqlast.Comment{{Text: "-- x"}}}
+	if c.IsEmpty() {
+		t.Error("IsEmpty() = true for a trailing comment, want false")
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 // CreateTable represents a CREATE TABLE statement.
 type CreateTable struct {
+	Comments
+
 	IfNotExists bool
 	Table       TableName
 	Elements    []TableElement
@@ -44,6 +46,8 @@ func (c *CreateTable) String() string {
 // AlterTable represents an ALTER TABLE statement with one or more
 // comma-separated actions.
 type AlterTable struct {
+	Comments
+
 	Table   TableName
 	Actions []AlterAction
 }
@@ -60,24 +64,46 @@ func (a *AlterTable) String() string {
 
 // CreateIndex represents a CREATE INDEX statement.
 type CreateIndex struct {
-	Unique  bool
-	Name    TableIdent
-	Table   TableName
-	Columns []IndexColumn
+	Comments
+
+	Unique   bool
+	Fulltext bool
+	Name     TableIdent
+	Table    TableName
+	Columns  []IndexColumn
+	Parser   string // full-text parser plugin (WITH PARSER name); "" if none
 }
 
 // String returns CreateIndex's SQL text.
 func (c *CreateIndex) String() string {
 	s := "CREATE "
-	if c.Unique {
+
+	switch {
+	case c.Unique:
 		s += "UNIQUE "
+	case c.Fulltext:
+		s += "FULLTEXT "
+	}
+
+	s += "INDEX " + c.Name.String() + " ON " + c.Table.String() + " (" + indexColumnsString(c.Columns) + ")"
+
+	return s + withParserString(c.Parser)
+}
+
+// withParserString renders a full-text index's " WITH PARSER name" option,
+// or "" if parser is empty.
+func withParserString(parser string) string {
+	if parser == "" {
+		return ""
 	}
 
-	return s + "INDEX " + c.Name.String() + " ON " + c.Table.String() + " (" + indexColumnsString(c.Columns) + ")"
+	return " WITH PARSER " + parser
 }
 
 // DropIndex represents a DROP INDEX statement.
 type DropIndex struct {
+	Comments
+
 	Name  TableIdent
 	Table TableName
 }
@@ -90,6 +116,8 @@ func (d *DropIndex) String() string {
 // DropTable represents a DROP TABLE statement, optionally naming multiple
 // tables.
 type DropTable struct {
+	Comments
+
 	IfExists bool
 	Tables   []TableName
 }
@@ -111,6 +139,8 @@ func (d *DropTable) String() string {
 
 // TruncateTable represents a TRUNCATE TABLE statement.
 type TruncateTable struct {
+	Comments
+
 	Table TableName
 }
 
@@ -122,6 +152,8 @@ func (t *TruncateTable) String() string {
 // ColumnDef represents a single column definition inside CREATE TABLE, or
 // ALTER TABLE's ADD/MODIFY COLUMN actions.
 type ColumnDef struct {
+	Comments
+
 	Name          ColIdent
 	Type          DataType
 	Nullability   Nullability
@@ -270,6 +302,8 @@ func indexColumnsString(cols []IndexColumn) string {
 
 // PrimaryKeyConstraint represents a table-level PRIMARY KEY (cols) constraint.
 type PrimaryKeyConstraint struct {
+	Comments
+
 	ConstraintName TableIdent // optional CONSTRAINT symbol
 	Columns        []IndexColumn
 }
@@ -282,6 +316,8 @@ func (c *PrimaryKeyConstraint) String() string {
 // UniqueConstraint represents a table-level UNIQUE [KEY] [name] (cols)
 // constraint.
 type UniqueConstraint struct {
+	Comments
+
 	ConstraintName TableIdent // optional CONSTRAINT symbol
 	IndexName      TableIdent
 	Columns        []IndexColumn
@@ -298,24 +334,36 @@ func (c *UniqueConstraint) String() string {
 }
 
 // IndexConstraint represents a table-level INDEX/KEY [name] (cols) secondary
-// index (no CONSTRAINT symbol — MySQL doesn't allow one on a plain index).
+// index, or with Fulltext set a FULLTEXT [INDEX|KEY] [name] (cols) [WITH
+// PARSER name] one (no CONSTRAINT symbol — MySQL doesn't allow one on
+// either).
 type IndexConstraint struct {
+	Comments
+
+	Fulltext  bool
 	IndexName TableIdent
 	Columns   []IndexColumn
+	Parser    string // full-text parser plugin (WITH PARSER name); "" if none
 }
 
 // String returns IndexConstraint's SQL text.
 func (c *IndexConstraint) String() string {
 	s := "INDEX"
+	if c.Fulltext {
+		s = "FULLTEXT INDEX"
+	}
+
 	if !c.IndexName.IsEmpty() {
 		s += " " + c.IndexName.String()
 	}
 
-	return s + " (" + indexColumnsString(c.Columns) + ")"
+	return s + " (" + indexColumnsString(c.Columns) + ")" + withParserString(c.Parser)
 }
 
 // ForeignKeyConstraint represents a table-level FOREIGN KEY constraint.
 type ForeignKeyConstraint struct {
+	Comments
+
 	ConstraintName TableIdent // optional CONSTRAINT symbol
 	IndexName      TableIdent
 	Columns        Columns
@@ -112,6 +112,17 @@ func TestCreateIndex_String(t *testing.T) {
 			},
 			want: "CREATE UNIQUE INDEX idx_email ON users (email)",
 		},
+		{
+			name: "fulltext with parser",
+			c: &sqlast.CreateIndex{
+				Fulltext: true,
+				Name:     "ft_body",
+				Table:    sqlast.TableName{Name: "posts"},
+				Columns:  []sqlast.IndexColumn{{Column: "body"}},
+				Parser:   "ngram",
+			},
+			want: "CREATE FULLTEXT INDEX ft_body ON posts (body) WITH PARSER ngram",
+		},
 	}
 
 	for _, tt := range tests {
@@ -358,6 +369,16 @@ func TestIndexConstraint_String(t *testing.T) {
 			c:    &sqlast.IndexConstraint{IndexName: "idx_name", Columns: []sqlast.IndexColumn{{Column: "name"}}},
 			want: "INDEX idx_name (name)",
 		},
+		{
+			name: "fulltext with parser",
+			c: &sqlast.IndexConstraint{
+				Fulltext:  true,
+				IndexName: "ft_body",
+				Columns:   []sqlast.IndexColumn{{Column: "body"}},
+				Parser:    "ngram",
+			},
+			want: "FULLTEXT INDEX ft_body (body) WITH PARSER ngram",
+		},
 	}
 
 	for _, tt := range tests {
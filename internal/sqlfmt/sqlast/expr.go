@@ -17,6 +17,71 @@ func (c *ComparisonExpr) String() string {
 	return fmt.Sprintf("%s %s %s", c.Left.String(), strings.ToUpper(c.Operator.ToString()), c.Right.String())
 }
 
+// JSONExtractExpr represents a column's col->'path' JSON extraction, MySQL's
+// shorthand for JSON_EXTRACT(col, 'path').
+type JSONExtractExpr struct {
+	Column *ColName
+	Path   Expr
+}
+
+// String returns JSONExtractExpr's SQL text.
+func (j *JSONExtractExpr) String() string {
+	return j.Column.String() + "->" + j.Path.String()
+}
+
+// JSONUnquoteExtractExpr represents a column's col->>'path' JSON extraction,
+// MySQL's shorthand for JSON_UNQUOTE(JSON_EXTRACT(col, 'path')).
+type JSONUnquoteExtractExpr struct {
+	Column *ColName
+	Path   Expr
+}
+
+// String returns JSONUnquoteExtractExpr's SQL text.
+func (j *JSONUnquoteExtractExpr) String() string {
+	return j.Column.String() + "->>" + j.Path.String()
+}
+
+// Quantifier represents the ANY, SOME, or ALL of a quantified subquery
+// comparison. SOME is a synonym for ANY, kept distinct so it renders as
+// written.
+type Quantifier int8
+
+const (
+	AnyQuantifier Quantifier = iota
+	SomeQuantifier
+	AllQuantifier
+)
+
+var quantifierStrings = [...]string{
+	AnyQuantifier:  "ANY",
+	SomeQuantifier: "SOME",
+	AllQuantifier:  "ALL",
+}
+
+// ToString returns Quantifier's SQL text.
+func (q Quantifier) ToString() string {
+	if q >= 0 && int(q) < len(quantifierStrings) {
+		return quantifierStrings[q]
+	}
+
+	return ""
+}
+
+// QuantifiedComparisonExpr represents a comparison against every row of a
+// subquery, e.g. price > ALL (SELECT ...) or id = ANY (SELECT ...).
+type QuantifiedComparisonExpr struct {
+	Operator   ComparisonOperator
+	Quantifier Quantifier
+	Left       Expr
+	Subquery   *Subquery
+}
+
+// String returns QuantifiedComparisonExpr's SQL text.
+func (q *QuantifiedComparisonExpr) String() string {
+	return fmt.Sprintf("%s %s %s %s",
+		q.Left.String(), strings.ToUpper(q.Operator.ToString()), q.Quantifier.ToString(), q.Subquery.String())
+}
+
 // RangeCond represents a [NOT] BETWEEN ... AND ... expression.
 type RangeCond struct {
 	Not  bool
@@ -64,7 +129,7 @@ func (v ValTuple) String() string {
 	return "(" + strings.Join(strs, ", ") + ")"
 }
 
-// ArithmeticOperator represents a binary arithmetic operator.
+// ArithmeticOperator represents a binary arithmetic or bitwise operator.
 type ArithmeticOperator int8
 
 const (
@@ -73,14 +138,28 @@ const (
 	MultOp
 	DivOp
 	ModOp
+	IntDivOp
+	ModKeywordOp
+	BitOrOp
+	BitAndOp
+	BitXorOp
+	ShiftLeftOp
+	ShiftRightOp
 )
 
 var arithmeticOpStrings = [...]string{
-	PlusOp:  "+",
-	MinusOp: "-",
-	MultOp:  "*",
-	DivOp:   "/",
-	ModOp:   "%",
+	PlusOp:       "+",
+	MinusOp:      "-",
+	MultOp:       "*",
+	DivOp:        "/",
+	ModOp:        "%",
+	IntDivOp:     "DIV",
+	ModKeywordOp: "MOD",
+	BitOrOp:      "|",
+	BitAndOp:     "&",
+	BitXorOp:     "^",
+	ShiftLeftOp:  "<<",
+	ShiftRightOp: ">>",
 }
 
 // ToString returns ArithmeticOperator's SQL operator text.
@@ -104,24 +183,43 @@ func (a *ArithmeticExpr) String() string {
 	return fmt.Sprintf("%s %s %s", a.Left.String(), a.Operator.ToString(), a.Right.String())
 }
 
-// UnaryOperator represents a unary + or - operator.
+// ConcatExpr represents a || string concatenation, which || only means
+// under MySQL's PIPES_AS_CONCAT sql_mode (otherwise it is OR, an OrExpr).
+type ConcatExpr struct {
+	Left  Expr
+	Right Expr
+}
+
+// String returns ConcatExpr's SQL text.
+func (c *ConcatExpr) String() string {
+	return fmt.Sprintf("%s || %s", c.Left.String(), c.Right.String())
+}
+
+// UnaryOperator represents a unary +, -, ~, or BINARY operator.
 type UnaryOperator int8
 
 const (
 	UPlusOp UnaryOperator = iota
 	UMinusOp
+	BitNotOp
+	BinaryOp
 )
 
 // ToString returns UnaryOperator's SQL operator text.
 func (u UnaryOperator) ToString() string {
-	if u == UMinusOp {
+	switch u {
+	case UMinusOp:
 		return "-"
+	case BitNotOp:
+		return "~"
+	case BinaryOp:
+		return "BINARY"
+	default:
+		return "+"
 	}
-
-	return "+"
 }
 
-// UnaryExpr represents a unary +expr or -expr.
+// UnaryExpr represents a unary +expr, -expr, ~expr, or BINARY expr.
 type UnaryExpr struct {
 	Operator UnaryOperator
 	Expr     Expr
@@ -129,11 +227,152 @@ type UnaryExpr struct {
 
 // String returns UnaryExpr's SQL text.
 func (u *UnaryExpr) String() string {
+	if u.Operator == BinaryOp {
+		return u.Operator.ToString() + " " + u.Expr.String()
+	}
+
 	return u.Operator.ToString() + u.Expr.String()
 }
 
+// CollateExpr represents an expr COLLATE collation expression. Collation is
+// a Literal holding the collation name as written (bare or quoted).
+type CollateExpr struct {
+	Expr      Expr
+	Collation Expr
+}
+
+// String returns CollateExpr's SQL text.
+func (c *CollateExpr) String() string {
+	return fmt.Sprintf("%s COLLATE %s", c.Expr.String(), c.Collation.String())
+}
+
+// ExecutableCommentExpr represents an operand followed by a /*! ... */
+// executable comment, such as 1 /*! + 1 */, or a /*!40001 , b */ after a
+// select list item. MySQL splices the comment's content into the statement
+// around it, so the comment is kept opaque and written right after the
+// operand it was read after.
+type ExecutableCommentExpr struct {
+	Expr    Expr
+	Comment *ExecutableComment
+}
+
+// String returns ExecutableCommentExpr's SQL text.
+func (e *ExecutableCommentExpr) String() string {
+	return e.Expr.String() + " " + e.Comment.String()
+}
+
+// IntroducedLiteral represents a string literal with a character set
+// introducer (_utf8mb4'abc') or the N'abc' national character set
+// shorthand. Introducer holds the introducer as written (_utf8mb4 or N), and
+// Val the quoted string.
+type IntroducedLiteral struct {
+	Introducer string
+	Val        string
+}
+
+// String returns IntroducedLiteral's SQL text.
+func (i *IntroducedLiteral) String() string {
+	return i.Introducer + i.Val
+}
+
+// MatchModifier represents the search modifier of a MATCH ... AGAINST
+// expression.
+type MatchModifier int8
+
+const (
+	NoMatchModifier MatchModifier = iota
+	NaturalLanguageModeModifier
+	NaturalLanguageModeWithQueryExpansionModifier
+	BooleanModeModifier
+	QueryExpansionModifier
+)
+
+var matchModifierStrings = [...]string{
+	NoMatchModifier:                               "",
+	NaturalLanguageModeModifier:                   "IN NATURAL LANGUAGE MODE",
+	NaturalLanguageModeWithQueryExpansionModifier: "IN NATURAL LANGUAGE MODE WITH QUERY EXPANSION",
+	BooleanModeModifier:                           "IN BOOLEAN MODE",
+	QueryExpansionModifier:                        "WITH QUERY EXPANSION",
+}
+
+// ToString returns MatchModifier's SQL text, or "" for NoMatchModifier.
+func (m MatchModifier) ToString() string {
+	if m >= 0 && int(m) < len(matchModifierStrings) {
+		return matchModifierStrings[m]
+	}
+
+	return ""
+}
+
+// MatchExpr represents a full-text search, MATCH (col, ...) AGAINST (expr
+// [modifier]).
+type MatchExpr struct {
+	Columns  []*ColName
+	Against  Expr
+	Modifier MatchModifier
+}
+
+// String returns MatchExpr's SQL text.
+func (m *MatchExpr) String() string {
+	cols := make([]string, len(m.Columns))
+	for i, c := range m.Columns {
+		cols[i] = c.String()
+	}
+
+	against := m.Against.String()
+	if mod := m.Modifier.ToString(); mod != "" {
+		against += " " + mod
+	}
+
+	return fmt.Sprintf("MATCH (%s) AGAINST (%s)", strings.Join(cols, ", "), against)
+}
+
+// CastExpr represents CAST(expr AS type [ARRAY]).
+type CastExpr struct {
+	Expr  Expr
+	Type  DataType
+	Array bool
+}
+
+// String returns CastExpr's SQL text.
+func (c *CastExpr) String() string {
+	var b strings.Builder
+
+	b.WriteString("CAST(")
+	b.WriteString(c.Expr.String())
+	b.WriteString(" AS ")
+	b.WriteString(c.Type.String())
+
+	if c.Array {
+		b.WriteString(" ARRAY")
+	}
+
+	b.WriteString(")")
+
+	return b.String()
+}
+
+// ConvertExpr represents CONVERT(expr, type) or, when Type is nil,
+// CONVERT(expr USING charset).
+type ConvertExpr struct {
+	Expr    Expr
+	Type    *DataType
+	Charset string
+}
+
+// String returns ConvertExpr's SQL text.
+func (c *ConvertExpr) String() string {
+	if c.Type == nil {
+		return fmt.Sprintf("CONVERT(%s USING %s)", c.Expr.String(), c.Charset)
+	}
+
+	return fmt.Sprintf("CONVERT(%s, %s)", c.Expr.String(), c.Type.String())
+}
+
 // AndExpr represents an AND expression.
 type AndExpr struct {
+	Comments
+
 	Left  Expr
 	Right Expr
 }
@@ -145,6 +384,8 @@ func (a *AndExpr) String() string {
 
 // OrExpr represents an OR expression.
 type OrExpr struct {
+	Comments
+
 	Left  Expr
 	Right Expr
 }
@@ -154,6 +395,170 @@ func (o *OrExpr) String() string {
 	return fmt.Sprintf("%s OR %s", o.Left.String(), o.Right.String())
 }
 
+// IntervalUnit represents the unit of an INTERVAL expression.
+type IntervalUnit int8
+
+const (
+	MicrosecondUnit IntervalUnit = iota
+	SecondUnit
+	MinuteUnit
+	HourUnit
+	DayUnit
+	WeekUnit
+	MonthUnit
+	QuarterUnit
+	YearUnit
+	SecondMicrosecondUnit
+	MinuteMicrosecondUnit
+	MinuteSecondUnit
+	HourMicrosecondUnit
+	HourSecondUnit
+	HourMinuteUnit
+	DayMicrosecondUnit
+	DaySecondUnit
+	DayMinuteUnit
+	DayHourUnit
+	YearMonthUnit
+)
+
+var intervalUnitStrings = [...]string{
+	MicrosecondUnit:       "MICROSECOND",
+	SecondUnit:            "SECOND",
+	MinuteUnit:            "MINUTE",
+	HourUnit:              "HOUR",
+	DayUnit:               "DAY",
+	WeekUnit:              "WEEK",
+	MonthUnit:             "MONTH",
+	QuarterUnit:           "QUARTER",
+	YearUnit:              "YEAR",
+	SecondMicrosecondUnit: "SECOND_MICROSECOND",
+	MinuteMicrosecondUnit: "MINUTE_MICROSECOND",
+	MinuteSecondUnit:      "MINUTE_SECOND",
+	HourMicrosecondUnit:   "HOUR_MICROSECOND",
+	HourSecondUnit:        "HOUR_SECOND",
+	HourMinuteUnit:        "HOUR_MINUTE",
+	DayMicrosecondUnit:    "DAY_MICROSECOND",
+	DaySecondUnit:         "DAY_SECOND",
+	DayMinuteUnit:         "DAY_MINUTE",
+	DayHourUnit:           "DAY_HOUR",
+	YearMonthUnit:         "YEAR_MONTH",
+}
+
+// ToString returns IntervalUnit's SQL keyword.
+func (u IntervalUnit) ToString() string {
+	if u >= 0 && int(u) < len(intervalUnitStrings) {
+		return intervalUnitStrings[u]
+	}
+
+	return "DAY"
+}
+
+// LookupIntervalUnit returns the IntervalUnit named by name, matched
+// case-insensitively, and whether there is one.
+func LookupIntervalUnit(name string) (IntervalUnit, bool) {
+	for i, s := range intervalUnitStrings {
+		if strings.EqualFold(s, name) {
+			return IntervalUnit(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// IntervalExpr represents an INTERVAL expr unit expression (e.g., INTERVAL
+// 7 DAY).
+type IntervalExpr struct {
+	Expr Expr
+	Unit IntervalUnit
+}
+
+// String returns IntervalExpr's SQL text.
+func (i *IntervalExpr) String() string {
+	return fmt.Sprintf("INTERVAL %s %s", i.Expr.String(), i.Unit.ToString())
+}
+
+// TemporalLiteralType represents the type of a TemporalLiteral.
+type TemporalLiteralType int8
+
+const (
+	DateLiteral TemporalLiteralType = iota
+	TimeLiteral
+	TimestampLiteral
+)
+
+var temporalLiteralTypeStrings = [...]string{
+	DateLiteral:      "DATE",
+	TimeLiteral:      "TIME",
+	TimestampLiteral: "TIMESTAMP",
+}
+
+// ToString returns TemporalLiteralType's SQL keyword.
+func (t TemporalLiteralType) ToString() string {
+	if t >= 0 && int(t) < len(temporalLiteralTypeStrings) {
+		return temporalLiteralTypeStrings[t]
+	}
+
+	return "DATE"
+}
+
+var odbcTemporalPrefixes = [...]string{
+	DateLiteral:      "d",
+	TimeLiteral:      "t",
+	TimestampLiteral: "ts",
+}
+
+// TemporalLiteral represents a typed temporal literal (DATE '2024-01-01'),
+// or, when ODBC is set, the equivalent ODBC escape ({d '2024-01-01'}). Val
+// holds the quoted string.
+type TemporalLiteral struct {
+	Type TemporalLiteralType
+	Val  string
+	ODBC bool
+}
+
+// String returns TemporalLiteral's SQL text.
+func (t *TemporalLiteral) String() string {
+	if t.ODBC && t.Type >= 0 && int(t.Type) < len(odbcTemporalPrefixes) {
+		return fmt.Sprintf("{%s %s}", odbcTemporalPrefixes[t.Type], t.Val)
+	}
+
+	return t.Type.ToString() + " " + t.Val
+}
+
+// XorExpr represents a logical XOR expression.
+type XorExpr struct {
+	Left  Expr
+	Right Expr
+}
+
+// String returns XorExpr's SQL text.
+func (x *XorExpr) String() string {
+	return fmt.Sprintf("%s XOR %s", x.Left.String(), x.Right.String())
+}
+
+// UserVariable represents a reference to a user-defined variable (@name).
+// Name is held without its leading "@".
+type UserVariable struct {
+	Name string
+}
+
+// String returns UserVariable's SQL text.
+func (u *UserVariable) String() string {
+	return "@" + u.Name
+}
+
+// AssignmentExpr represents a user-variable assignment inside an expression
+// (@name := expr). Name is held without its leading "@".
+type AssignmentExpr struct {
+	Name string
+	Expr Expr
+}
+
+// String returns AssignmentExpr's SQL text.
+func (a *AssignmentExpr) String() string {
+	return fmt.Sprintf("@%s := %s", a.Name, a.Expr.String())
+}
+
 // NotExpr represents a NOT expression.
 type NotExpr struct {
 	Expr Expr
@@ -164,17 +569,26 @@ func (n *NotExpr) String() string {
 	return "NOT " + n.Expr.String()
 }
 
-// When represents a WHEN clause in a CASE expression.
+// When represents a WHEN clause in a CASE expression. Its comments are
+// those from WHEN through its value, which the formatter writes on a line
+// of their own.
 type When struct {
+	Comments
+
 	Cond Expr
 	Val  Expr
 }
 
-// CaseExpr represents a CASE expression.
+// CaseExpr represents a CASE expression. Its comments are those from CASE
+// to the first WHEN, on the CASE line, and ElseComments those from ELSE
+// through its value, on the ELSE line.
 type CaseExpr struct {
-	Expr  Expr
-	Whens []*When
-	Else  Expr
+	Comments
+
+	Expr         Expr
+	Whens        []*When
+	Else         Expr
+	ElseComments Comments
 }
 
 // String returns CaseExpr's SQL text.
@@ -402,6 +816,49 @@ type Max struct {
 // String returns Max's SQL text.
 func (m *Max) String() string { return formatDistinctAgg("MAX", m.Arg, m.Distinct, m.OverClause) }
 
+// GroupConcat represents GROUP_CONCAT([DISTINCT] expr, ... [ORDER BY ...]
+// [SEPARATOR str]).
+type GroupConcat struct {
+	Args       []Expr
+	Distinct   bool
+	OrderBy    OrderBy
+	Separator  Expr // string literal; nil if absent
+	OverClause *OverClause
+}
+
+// String returns GroupConcat's SQL text.
+func (g *GroupConcat) String() string {
+	var b strings.Builder
+
+	args := make([]string, len(g.Args))
+	for i, a := range g.Args {
+		args[i] = a.String()
+	}
+
+	b.WriteString("GROUP_CONCAT(")
+
+	if g.Distinct {
+		b.WriteString("DISTINCT ")
+	}
+
+	b.WriteString(strings.Join(args, ", "))
+
+	if len(g.OrderBy) > 0 {
+		b.WriteString(" ")
+		b.WriteString(g.OrderBy.String())
+	}
+
+	if g.Separator != nil {
+		b.WriteString(" SEPARATOR ")
+		b.WriteString(g.Separator.String())
+	}
+
+	b.WriteString(")")
+	appendOver(&b, g.OverClause)
+
+	return b.String()
+}
+
 // BitAnd represents BIT_AND(expr).
 type BitAnd struct {
 	Arg        Expr
@@ -626,15 +1083,29 @@ func (l *LagLeadExpr) String() string {
 	return b.String()
 }
 
-// JSONArrayAgg represents JSON_ARRAYAGG(expr).
+// JSONArrayAgg represents JSON_ARRAYAGG(expr [ORDER BY ...]).
 type JSONArrayAgg struct {
 	Expr       Expr
+	OrderBy    OrderBy
 	OverClause *OverClause
 }
 
 // String returns JSONArrayAgg's SQL text.
 func (j *JSONArrayAgg) String() string {
-	return formatSimpleAgg("JSON_ARRAYAGG", j.Expr, j.OverClause)
+	if len(j.OrderBy) == 0 {
+		return formatSimpleAgg("JSON_ARRAYAGG", j.Expr, j.OverClause)
+	}
+
+	var b strings.Builder
+
+	b.WriteString("JSON_ARRAYAGG(")
+	b.WriteString(j.Expr.String())
+	b.WriteString(" ")
+	b.WriteString(j.OrderBy.String())
+	b.WriteString(")")
+	appendOver(&b, j.OverClause)
+
+	return b.String()
 }
 
 // JSONObjectAgg represents JSON_OBJECTAGG(key, value).
@@ -26,6 +26,65 @@ func TestOrExpr_String(t *testing.T) {
 	assertEqual(t, "a OR b", e.String())
 }
 
+func TestIntervalExpr_String(t *testing.T) {
+	assertEqual(t, "INTERVAL 7 DAY", (&sqlast.IntervalExpr{Expr: lit("7"), Unit: sqlast.DayUnit}).String())
+	assertEqual(t, "INTERVAL '1:2' HOUR_MINUTE",
+		(&sqlast.IntervalExpr{Expr: lit("'1:2'"), Unit: sqlast.HourMinuteUnit}).String())
+}
+
+func TestIntervalUnit_ToString(t *testing.T) {
+	assertEqual(t, "YEAR_MONTH", sqlast.YearMonthUnit.ToString())
+	assertEqual(t, "DAY", sqlast.IntervalUnit(99).ToString())
+	assertEqual(t, "DAY", sqlast.IntervalUnit(-1).ToString())
+}
+
+func TestLookupIntervalUnit(t *testing.T) {
+	if u, ok := sqlast.LookupIntervalUnit("second_microsecond"); !ok || u != sqlast.SecondMicrosecondUnit {
+		t.Errorf("LookupIntervalUnit(second_microsecond) = %v, %v", u, ok)
+	}
+
+	if _, ok := sqlast.LookupIntervalUnit("fortnight"); ok {
+		t.Error("LookupIntervalUnit(fortnight) ok = true, want false")
+	}
+}
+
+func TestTemporalLiteral_String(t *testing.T) {
+	tests := []struct {
+		lit  *sqlast.TemporalLiteral
+		want string
+	}{
+		{&sqlast.TemporalLiteral{Type: sqlast.DateLiteral, Val: "'2024-01-02'"}, "DATE '2024-01-02'"},
+		{&sqlast.TemporalLiteral{Type: sqlast.TimeLiteral, Val: "'12:00'"}, "TIME '12:00'"},
+		{&sqlast.TemporalLiteral{Type: sqlast.TimestampLiteral, Val: "'x'"}, "TIMESTAMP 'x'"},
+		{&sqlast.TemporalLiteral{Type: sqlast.DateLiteral, Val: "'2024-01-02'", ODBC: true}, "{d '2024-01-02'}"},
+		{&sqlast.TemporalLiteral{Type: sqlast.TimeLiteral, Val: "'12:00'", ODBC: true}, "{t '12:00'}"},
+		{&sqlast.TemporalLiteral{Type: sqlast.TimestampLiteral, Val: "'x'", ODBC: true}, "{ts 'x'}"},
+		{&sqlast.TemporalLiteral{Type: sqlast.TemporalLiteralType(99), Val: "'x'"}, "DATE 'x'"},
+	}
+
+	for _, tt := range tests {
+		assertEqual(t, tt.want, tt.lit.String())
+	}
+}
+
+func TestXorExpr_String(t *testing.T) {
+	e := &sqlast.XorExpr{Left: lit("a"), Right: lit("b")}
+	assertEqual(t, "a XOR b", e.String())
+}
+
+func TestAssignmentExpr_String(t *testing.T) {
+	e := &sqlast.AssignmentExpr{
+		Name: "rank",
+		Expr: &sqlast.ArithmeticExpr{Left: &sqlast.UserVariable{Name: "rank"}, Operator: sqlast.PlusOp, Right: lit("1")},
+	}
+	assertEqual(t, "@rank := @rank + 1", e.String())
+}
+
+func TestConcatExpr_String(t *testing.T) {
+	e := &sqlast.ConcatExpr{Left: lit("a"), Right: lit("b")}
+	assertEqual(t, "a || b", e.String())
+}
+
 func TestNotExpr_String(t *testing.T) {
 	e := &sqlast.NotExpr{Expr: lit("a")}
 	assertEqual(t, "NOT a", e.String())
@@ -112,6 +171,13 @@ func TestArithmeticExpr_String(t *testing.T) {
 		{sqlast.MultOp, "a * b"},
 		{sqlast.DivOp, "a / b"},
 		{sqlast.ModOp, "a % b"},
+		{sqlast.IntDivOp, "a DIV b"},
+		{sqlast.ModKeywordOp, "a MOD b"},
+		{sqlast.BitOrOp, "a | b"},
+		{sqlast.BitAndOp, "a & b"},
+		{sqlast.BitXorOp, "a ^ b"},
+		{sqlast.ShiftLeftOp, "a << b"},
+		{sqlast.ShiftRightOp, "a >> b"},
 	}
 
 	for _, tt := range tests {
@@ -128,6 +194,55 @@ func TestArithmeticOperator_ToString(t *testing.T) {
 func TestUnaryExpr_String(t *testing.T) {
 	assertEqual(t, "-a", (&sqlast.UnaryExpr{Operator: sqlast.UMinusOp, Expr: lit("a")}).String())
 	assertEqual(t, "+a", (&sqlast.UnaryExpr{Operator: sqlast.UPlusOp, Expr: lit("a")}).String())
+	assertEqual(t, "~a", (&sqlast.UnaryExpr{Operator: sqlast.BitNotOp, Expr: lit("a")}).String())
+	assertEqual(t, "BINARY a", (&sqlast.UnaryExpr{Operator: sqlast.BinaryOp, Expr: lit("a")}).String())
+}
+
+func TestCollateExpr_String(t *testing.T) {
+	e := &sqlast.CollateExpr{Expr: lit("name"), Collation: lit("utf8mb4_bin")}
+	assertEqual(t, "name COLLATE utf8mb4_bin", e.String())
+}
+
+func TestIntroducedLiteral_String(t *testing.T) {
+	assertEqual(t, "_utf8mb4'abc'", (&sqlast.IntroducedLiteral{Introducer: "_utf8mb4", Val: "'abc'"}).String())
+	assertEqual(t, "N'abc'", (&sqlast.IntroducedLiteral{Introducer: "N", Val: "'abc'"}).String())
+}
+
+func TestMatchExpr_String(t *testing.T) {
+	e := &sqlast.MatchExpr{
+		Columns: []*sqlast.ColName{{Name: "title"}, {Name: "body"}},
+		Against: lit("?"),
+	}
+	assertEqual(t, "MATCH (title, body) AGAINST (?)", e.String())
+
+	e.Modifier = sqlast.NaturalLanguageModeWithQueryExpansionModifier
+	assertEqual(t, "MATCH (title, body) AGAINST (? IN NATURAL LANGUAGE MODE WITH QUERY EXPANSION)", e.String())
+
+	e.Modifier = sqlast.BooleanModeModifier
+	assertEqual(t, "MATCH (title, body) AGAINST (? IN BOOLEAN MODE)", e.String())
+}
+
+func TestJSONExtractExpr_String(t *testing.T) {
+	c := &sqlast.ColName{Name: "data"}
+
+	assertEqual(t, "data->'$.a'", (&sqlast.JSONExtractExpr{Column: c, Path: lit("'$.a'")}).String())
+	assertEqual(t, "data->>'$.a'", (&sqlast.JSONUnquoteExtractExpr{Column: c, Path: lit("'$.a'")}).String())
+}
+
+func TestCastExpr_String(t *testing.T) {
+	e := &sqlast.CastExpr{Expr: lit("a"), Type: sqlast.DataType{Name: "CHAR", Params: []string{"10"}, Charset: "utf8mb4"}}
+	assertEqual(t, "CAST(a AS CHAR(10) CHARACTER SET utf8mb4)", e.String())
+
+	e = &sqlast.CastExpr{Expr: lit("a"), Type: sqlast.DataType{Name: "UNSIGNED"}, Array: true}
+	assertEqual(t, "CAST(a AS UNSIGNED ARRAY)", e.String())
+}
+
+func TestConvertExpr_String(t *testing.T) {
+	e := &sqlast.ConvertExpr{Expr: lit("a"), Type: &sqlast.DataType{Name: "SIGNED"}}
+	assertEqual(t, "CONVERT(a, SIGNED)", e.String())
+
+	e = &sqlast.ConvertExpr{Expr: lit("a"), Charset: "utf8mb4"}
+	assertEqual(t, "CONVERT(a USING utf8mb4)", e.String())
 }
 
 func TestUnaryOperator_ToString(t *testing.T) {
@@ -148,6 +263,21 @@ func TestExistsExpr_String(t *testing.T) {
 	assertEqual(t, "EXISTS (SELECT 1 FROM t)", e.String())
 }
 
+func TestQuantifiedComparisonExpr_String(t *testing.T) {
+	e := &sqlast.QuantifiedComparisonExpr{
+		Left:       lit("price"),
+		Operator:   sqlast.GreaterThanOp,
+		Quantifier: sqlast.AllQuantifier,
+		Subquery: &sqlast.Subquery{
+			Select: &sqlast.Select{SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: lit("1")}}},
+		},
+	}
+	assertEqual(t, "price > ALL (SELECT 1)", e.String())
+
+	e.Quantifier = sqlast.SomeQuantifier
+	assertEqual(t, "price > SOME (SELECT 1)", e.String())
+}
+
 func TestSubquery_String(t *testing.T) {
 	s := &sqlast.Subquery{
 		Select: &sqlast.Select{
@@ -296,7 +426,16 @@ func TestWindowFunctionTypes_String(t *testing.T) {
 			NullTreatmentClause: &sqlast.NullTreatmentClause{Type: sqlast.RespectNullsType},
 			OverClause:          over,
 		}, "LAG(val) RESPECT NULLS OVER (ORDER BY id)"},
+		{"GROUP_CONCAT", &sqlast.GroupConcat{
+			Args: []sqlast.Expr{lit("name")}, Distinct: true,
+			OrderBy:   sqlast.OrderBy{{Expr: lit("name"), Direction: sqlast.DescOrder}},
+			Separator: lit("', '"), OverClause: over,
+		}, "GROUP_CONCAT(DISTINCT name ORDER BY name DESC SEPARATOR ', ') OVER (ORDER BY id)"},
 		{"JSON_ARRAYAGG", &sqlast.JSONArrayAgg{Expr: lit("col")}, "JSON_ARRAYAGG(col)"},
+		{"JSON_ARRAYAGG ORDER BY", &sqlast.JSONArrayAgg{
+			Expr:    lit("col"),
+			OrderBy: sqlast.OrderBy{{Expr: lit("id"), Direction: sqlast.DescOrder}},
+		}, "JSON_ARRAYAGG(col ORDER BY id DESC)"},
 		{"JSON_OBJECTAGG", &sqlast.JSONObjectAgg{Key: lit("k"), Value: lit("v")}, "JSON_OBJECTAGG(k, v)"},
 	}
 
@@ -0,0 +1,210 @@
+package sqlast
+
+import "strings"
+
+// HintComments is the run of comments MySQL reads right after a SELECT,
+// INSERT, REPLACE, UPDATE, or DELETE keyword: at most one /*+ ... */
+// optimizer hint comment, and any number of /*! ... */ executable comments,
+// in source order.
+type HintComments []HintComment
+
+// String returns HintComments's SQL text, its comments separated by spaces.
+func (h HintComments) String() string {
+	parts := make([]string, len(h))
+	for i, c := range h {
+		parts[i] = c.String()
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// OptimizerHints is a /*+ ... */ optimizer hint comment: the hints it holds,
+// in order.
+type OptimizerHints []OptimizerHint
+
+// String returns OptimizerHints's SQL text.
+func (h OptimizerHints) String() string {
+	parts := make([]string, len(h))
+	for i, hint := range h {
+		parts[i] = hint.String()
+	}
+
+	return "/*+ " + strings.Join(parts, " ") + " */"
+}
+
+// ExecutableComment is a /*! ... */ executable comment (including the
+// version-gated /*!50700 ... */ form). Its content is SQL MySQL only runs on
+// a new enough server, so it's kept opaque: Text is the comment exactly as
+// written, delimiters included.
+type ExecutableComment struct {
+	Text string
+}
+
+// String returns ExecutableComment's SQL text.
+func (c *ExecutableComment) String() string { return c.Text }
+
+// ExecutableCommentStatement is a statement that is nothing but a /*! ... */
+// executable comment, the form mysqldump writes its session settings in
+// (/*!40101 SET NAMES utf8 */). Like an ExecutableComment, it's kept opaque.
+type ExecutableCommentStatement struct {
+	Comments
+
+	Text string
+}
+
+// String returns ExecutableCommentStatement's SQL text.
+func (s *ExecutableCommentStatement) String() string { return s.Text }
+
+// HintTable is a table named in an optimizer hint, optionally qualified
+// with the query block it belongs to: t1, or t1@qb1.
+type HintTable struct {
+	Name       TableIdent
+	QueryBlock string
+}
+
+// String returns HintTable's SQL text.
+func (t HintTable) String() string {
+	if t.QueryBlock == "" {
+		return t.Name.String()
+	}
+
+	return t.Name.String() + "@" + t.QueryBlock
+}
+
+// TableLevelHint represents a table-level or join-order optimizer hint, such
+// as BKA, NO_MERGE, HASH_JOIN, or JOIN_ORDER: an optional leading
+// @query_block followed by the tables it applies to, if any.
+type TableLevelHint struct {
+	Name       string
+	QueryBlock string
+	Tables     []HintTable
+}
+
+// String returns TableLevelHint's SQL text.
+func (h *TableLevelHint) String() string {
+	tables := make([]string, len(h.Tables))
+	for i, t := range h.Tables {
+		tables[i] = t.String()
+	}
+
+	return h.Name + "(" + queryBlockPrefix(h.QueryBlock, strings.Join(tables, ", ")) + ")"
+}
+
+// IndexLevelHint represents an index-level optimizer hint, such as INDEX,
+// NO_ICP, or SKIP_SCAN: an optional leading @query_block, the table it
+// applies to, and the indexes of that table it names, if any.
+type IndexLevelHint struct {
+	Name       string
+	QueryBlock string
+	Table      HintTable
+	Indexes    []ColIdent
+}
+
+// String returns IndexLevelHint's SQL text.
+func (h *IndexLevelHint) String() string {
+	args := h.Table.String()
+
+	if len(h.Indexes) > 0 {
+		idxs := make([]string, len(h.Indexes))
+		for i, idx := range h.Indexes {
+			idxs[i] = idx.String()
+		}
+
+		args += " " + strings.Join(idxs, ", ")
+	}
+
+	return h.Name + "(" + queryBlockPrefix(h.QueryBlock, args) + ")"
+}
+
+// SubqueryHint represents a SEMIJOIN, NO_SEMIJOIN, or SUBQUERY optimizer
+// hint: an optional leading @query_block followed by the execution
+// strategies it permits, such as FIRSTMATCH or MATERIALIZATION.
+type SubqueryHint struct {
+	Name       string
+	QueryBlock string
+	Strategies []string
+}
+
+// String returns SubqueryHint's SQL text.
+func (h *SubqueryHint) String() string {
+	return h.Name + "(" + queryBlockPrefix(h.QueryBlock, strings.Join(h.Strategies, ", ")) + ")"
+}
+
+// MaxExecutionTimeHint represents a MAX_EXECUTION_TIME(N) optimizer hint;
+// Timeout is N, the limit in milliseconds, as written.
+type MaxExecutionTimeHint struct {
+	Timeout string
+}
+
+// String returns MaxExecutionTimeHint's SQL text.
+func (h *MaxExecutionTimeHint) String() string {
+	return "MAX_EXECUTION_TIME(" + h.Timeout + ")"
+}
+
+// SetVarHint represents a SET_VAR(name = value) optimizer hint, which sets a
+// system variable for the statement's duration. Value is the SQL text of
+// the value: a number (optionally with a K/M/G suffix, as in 16M), a
+// keyword such as ON, or a quoted string.
+type SetVarHint struct {
+	Name  string
+	Value string
+}
+
+// String returns SetVarHint's SQL text.
+func (h *SetVarHint) String() string {
+	return "SET_VAR(" + h.Name + " = " + h.Value + ")"
+}
+
+// ResourceGroupHint represents a RESOURCE_GROUP(name) optimizer hint.
+type ResourceGroupHint struct {
+	Name string
+}
+
+// String returns ResourceGroupHint's SQL text.
+func (h *ResourceGroupHint) String() string {
+	return "RESOURCE_GROUP(" + h.Name + ")"
+}
+
+// QBNameHint represents a QB_NAME(name) optimizer hint, which names its
+// query block so other hints can refer to it as @name.
+type QBNameHint struct {
+	Name string
+}
+
+// String returns QBNameHint's SQL text.
+func (h *QBNameHint) String() string {
+	return "QB_NAME(" + h.Name + ")"
+}
+
+// queryBlockPrefix prepends an optional @queryBlock to a hint's remaining
+// arguments args, space-separated when both are present.
+func queryBlockPrefix(queryBlock, args string) string {
+	switch {
+	case queryBlock == "":
+		return args
+	case args == "":
+		return "@" + queryBlock
+	default:
+		return "@" + queryBlock + " " + args
+	}
+}
+
+// writeHintComments writes hints, preceded by a space, if there are any.
+func writeHintComments(b *strings.Builder, hints HintComments) {
+	if len(hints) == 0 {
+		return
+	}
+
+	b.WriteString(" ")
+	b.WriteString(hints.String())
+}
+
+// UnknownHint is an optimizer hint MySQL doesn't know, which it ignores with
+// a warning rather than failing the statement. It's kept opaque: Text is the
+// hint exactly as written, its name and any parenthesized arguments.
+type UnknownHint struct {
+	Text string
+}
+
+// String returns UnknownHint's SQL text.
+func (h *UnknownHint) String() string { return h.Text }
@@ -0,0 +1,150 @@
+package sqlast_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+func TestOptimizerHint_String(t *testing.T) {
+	tests := []struct {
+		name string
+		hint sqlast.OptimizerHint
+		want string
+	}{
+		{"table-level, no args", &sqlast.TableLevelHint{Name: "BKA"}, "BKA()"},
+		{
+			"table-level, query block only",
+			&sqlast.TableLevelHint{Name: "NO_MERGE", QueryBlock: "qb1"},
+			"NO_MERGE(@qb1)",
+		},
+		{
+			"table-level, query block and tables",
+			&sqlast.TableLevelHint{
+				Name:       "JOIN_ORDER",
+				QueryBlock: "qb1",
+				Tables:     []sqlast.HintTable{{Name: "t1"}, {Name: "t2", QueryBlock: "qb2"}},
+			},
+			"JOIN_ORDER(@qb1 t1, t2@qb2)",
+		},
+		{
+			"index-level, table only",
+			&sqlast.IndexLevelHint{Name: "NO_ICP", Table: sqlast.HintTable{Name: "t"}},
+			"NO_ICP(t)",
+		},
+		{
+			"index-level, query block and indexes",
+			&sqlast.IndexLevelHint{
+				Name:       "INDEX",
+				QueryBlock: "qb1",
+				Table:      sqlast.HintTable{Name: "t"},
+				Indexes:    []sqlast.ColIdent{"i1", "i2"},
+			},
+			"INDEX(@qb1 t i1, i2)",
+		},
+		{"subquery, no strategies", &sqlast.SubqueryHint{Name: "SEMIJOIN"}, "SEMIJOIN()"},
+		{
+			"subquery, strategies",
+			&sqlast.SubqueryHint{Name: "SEMIJOIN", QueryBlock: "qb1", Strategies: []string{"FIRSTMATCH", "LOOSESCAN"}},
+			"SEMIJOIN(@qb1 FIRSTMATCH, LOOSESCAN)",
+		},
+		{"max execution time", &sqlast.MaxExecutionTimeHint{Timeout: "1000"}, "MAX_EXECUTION_TIME(1000)"},
+		{"set var", &sqlast.SetVarHint{Name: "sort_buffer_size", Value: "16M"}, "SET_VAR(sort_buffer_size = 16M)"},
+		{"resource group", &sqlast.ResourceGroupHint{Name: "batch"}, "RESOURCE_GROUP(batch)"},
+		{"query block name", &sqlast.QBNameHint{Name: "qb1"}, "QB_NAME(qb1)"},
+		{"unknown hint is verbatim", &sqlast.UnknownHint{Text: "Foo(x  y)"}, "Foo(x  y)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, tt.want, tt.hint.String())
+		})
+	}
+}
+
+func TestHintComments_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		hints sqlast.HintComments
+		want  string
+	}{
+		{"empty", nil, ""},
+		{
+			"optimizer hints",
+			sqlast.HintComments{sqlast.OptimizerHints{
+				&sqlast.MaxExecutionTimeHint{Timeout: "10"},
+				&sqlast.TableLevelHint{Name: "BKA"},
+			}},
+			"/*+ MAX_EXECUTION_TIME(10) BKA() */",
+		},
+		{
+			"executable comment is verbatim",
+			sqlast.HintComments{&sqlast.ExecutableComment{Text: "/*!40001  sql_no_cache */"}},
+			"/*!40001  sql_no_cache */",
+		},
+		{
+			"mixed, in order",
+			sqlast.HintComments{
+				&sqlast.ExecutableComment{Text: "/*! a */"},
+				sqlast.OptimizerHints{&sqlast.QBNameHint{Name: "q"}},
+			},
+			"/*! a */ /*+ QB_NAME(q) */",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, tt.want, tt.hints.String())
+		})
+	}
+}
+
+func TestStatement_StringWithHints(t *testing.T) {
+	hints := sqlast.HintComments{sqlast.OptimizerHints{&sqlast.TableLevelHint{Name: "BKA"}}}
+	table := sqlast.TableName{Name: "t"}
+
+	tests := []struct {
+		name string
+		stmt sqlast.Statement
+		want string
+	}{
+		{
+			"select",
+			&sqlast.Select{Hints: hints, Distinct: true, SelectExprs: []sqlast.SelectExpr{&sqlast.StarExpr{}}},
+			"SELECT /*+ BKA() */ DISTINCT *",
+		},
+		{
+			"insert",
+			&sqlast.Insert{Hints: hints, Ignore: true, Table: table, Rows: sqlast.Values{{lit("1")}}},
+			"INSERT /*+ BKA() */ IGNORE INTO t VALUES (1)",
+		},
+		{
+			"update",
+			&sqlast.Update{
+				Hints:      hints,
+				TableExprs: []sqlast.TableExpr{&sqlast.AliasedTableExpr{Expr: table}},
+				Exprs:      []*sqlast.UpdateExpr{{Name: &sqlast.ColName{Name: "a"}, Expr: lit("1")}},
+			},
+			"UPDATE /*+ BKA() */ t SET a = 1",
+		},
+		{
+			"delete",
+			&sqlast.Delete{Hints: hints, TableExprs: []sqlast.TableExpr{&sqlast.AliasedTableExpr{Expr: table}}},
+			"DELETE /*+ BKA() */ FROM t",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEqual(t, tt.want, tt.stmt.String())
+		})
+	}
+}
+
+func TestExecutableComment_StringInStatementAndExpr(t *testing.T) {
+	comment := &sqlast.ExecutableComment{Text: "/*!40001 , b */"}
+
+	assertEqual(t, "/*!40101 SET NAMES utf8 */",
+		(&sqlast.ExecutableCommentStatement{Text: "/*!40101 SET NAMES utf8 */"}).String())
+	assertEqual(t, "a /*!40001 , b */", (&sqlast.ExecutableCommentExpr{Expr: lit("a"), Comment: comment}).String())
+}
@@ -9,90 +9,127 @@ package sqlast
 
 // --- Expr ---
 
-func (*ComparisonExpr) iExpr()         {}
-func (*RangeCond) iExpr()              {}
-func (*IsExpr) iExpr()                 {}
-func (ValTuple) iExpr()                {}
-func (*ArithmeticExpr) iExpr()         {}
-func (*UnaryExpr) iExpr()              {}
-func (*AndExpr) iExpr()                {}
-func (*OrExpr) iExpr()                 {}
-func (*NotExpr) iExpr()                {}
-func (*CaseExpr) iExpr()               {}
-func (*ExistsExpr) iExpr()             {}
-func (*Subquery) iExpr()               {}
-func (*ColName) iExpr()                {}
-func (*Literal) iExpr()                {}
-func (*FuncExpr) iExpr()               {}
-func (*ParenExpr) iExpr()              {}
-func (*Count) iExpr()                  {}
-func (*CountStar) iExpr()              {}
-func (*Sum) iExpr()                    {}
-func (*Avg) iExpr()                    {}
-func (*Min) iExpr()                    {}
-func (*Max) iExpr()                    {}
-func (*BitAnd) iExpr()                 {}
-func (*BitOr) iExpr()                  {}
-func (*BitXor) iExpr()                 {}
-func (*Std) iExpr()                    {}
-func (*StdDev) iExpr()                 {}
-func (*StdPop) iExpr()                 {}
-func (*StdSamp) iExpr()                {}
-func (*Variance) iExpr()               {}
-func (*VarPop) iExpr()                 {}
-func (*VarSamp) iExpr()                {}
-func (*ArgumentLessWindowExpr) iExpr() {}
-func (*FirstOrLastValueExpr) iExpr()   {}
-func (*NtileExpr) iExpr()              {}
-func (*NTHValueExpr) iExpr()           {}
-func (*LagLeadExpr) iExpr()            {}
-func (*JSONArrayAgg) iExpr()           {}
-func (*JSONObjectAgg) iExpr()          {}
+func (*ComparisonExpr) iExpr()           {}
+func (*RangeCond) iExpr()                {}
+func (*IsExpr) iExpr()                   {}
+func (ValTuple) iExpr()                  {}
+func (*ArithmeticExpr) iExpr()           {}
+func (*ConcatExpr) iExpr()               {}
+func (*UnaryExpr) iExpr()                {}
+func (*AndExpr) iExpr()                  {}
+func (*OrExpr) iExpr()                   {}
+func (*XorExpr) iExpr()                  {}
+func (*UserVariable) iExpr()             {}
+func (*AssignmentExpr) iExpr()           {}
+func (*IntervalExpr) iExpr()             {}
+func (*TemporalLiteral) iExpr()          {}
+func (*JSONExtractExpr) iExpr()          {}
+func (*JSONUnquoteExtractExpr) iExpr()   {}
+func (*CollateExpr) iExpr()              {}
+func (*IntroducedLiteral) iExpr()        {}
+func (*CastExpr) iExpr()                 {}
+func (*MatchExpr) iExpr()                {}
+func (*ConvertExpr) iExpr()              {}
+func (*NotExpr) iExpr()                  {}
+func (*CaseExpr) iExpr()                 {}
+func (*ExistsExpr) iExpr()               {}
+func (*QuantifiedComparisonExpr) iExpr() {}
+func (*Subquery) iExpr()                 {}
+func (*ColName) iExpr()                  {}
+func (*Literal) iExpr()                  {}
+func (*FuncExpr) iExpr()                 {}
+func (*ParenExpr) iExpr()                {}
+func (*Count) iExpr()                    {}
+func (*CountStar) iExpr()                {}
+func (*Sum) iExpr()                      {}
+func (*Avg) iExpr()                      {}
+func (*Min) iExpr()                      {}
+func (*Max) iExpr()                      {}
+func (*BitAnd) iExpr()                   {}
+func (*BitOr) iExpr()                    {}
+func (*BitXor) iExpr()                   {}
+func (*Std) iExpr()                      {}
+func (*StdDev) iExpr()                   {}
+func (*StdPop) iExpr()                   {}
+func (*StdSamp) iExpr()                  {}
+func (*Variance) iExpr()                 {}
+func (*VarPop) iExpr()                   {}
+func (*VarSamp) iExpr()                  {}
+func (*ArgumentLessWindowExpr) iExpr()   {}
+func (*FirstOrLastValueExpr) iExpr()     {}
+func (*NtileExpr) iExpr()                {}
+func (*NTHValueExpr) iExpr()             {}
+func (*LagLeadExpr) iExpr()              {}
+func (*GroupConcat) iExpr()              {}
+func (*JSONArrayAgg) iExpr()             {}
+func (*JSONObjectAgg) iExpr()            {}
+func (*ExecutableCommentExpr) iExpr()    {}
 
 // --- Statement ---
 
-func (*Select) iStatement()           {}
-func (*Insert) iStatement()           {}
-func (*Update) iStatement()           {}
-func (*Delete) iStatement()           {}
-func (*Union) iStatement()            {}
-func (*CreateTable) iStatement()      {}
-func (*AlterTable) iStatement()       {}
-func (*CreateIndex) iStatement()      {}
-func (*DropIndex) iStatement()        {}
-func (*DropTable) iStatement()        {}
-func (*TruncateTable) iStatement()    {}
-func (*StartTransaction) iStatement() {}
-func (*Begin) iStatement()            {}
-func (*Commit) iStatement()           {}
-func (*Rollback) iStatement()         {}
-func (*Savepoint) iStatement()        {}
-func (*ReleaseSavepoint) iStatement() {}
-func (*SetVariable) iStatement()      {}
-func (*SetNames) iStatement()         {}
-func (*ShowTables) iStatement()       {}
-func (*ShowCreateTable) iStatement()  {}
-func (*ShowColumns) iStatement()      {}
-func (*ShowIndex) iStatement()        {}
-func (*ShowDatabases) iStatement()    {}
-func (*ShowVariables) iStatement()    {}
-func (*ShowStatus) iStatement()       {}
-func (*Describe) iStatement()         {}
-func (*Explain) iStatement()          {}
-func (*Use) iStatement()              {}
+func (*Select) iStatement()                     {}
+func (*Insert) iStatement()                     {}
+func (*Update) iStatement()                     {}
+func (*Delete) iStatement()                     {}
+func (*Union) iStatement()                      {}
+func (*ValuesStatement) iStatement()            {}
+func (*TableStatement) iStatement()             {}
+func (*ParenSelect) iStatement()                {}
+func (*CreateTable) iStatement()                {}
+func (*AlterTable) iStatement()                 {}
+func (*CreateIndex) iStatement()                {}
+func (*DropIndex) iStatement()                  {}
+func (*DropTable) iStatement()                  {}
+func (*TruncateTable) iStatement()              {}
+func (*CreateView) iStatement()                 {}
+func (*AlterView) iStatement()                  {}
+func (*DropView) iStatement()                   {}
+func (*StartTransaction) iStatement()           {}
+func (*Begin) iStatement()                      {}
+func (*Commit) iStatement()                     {}
+func (*Rollback) iStatement()                   {}
+func (*Savepoint) iStatement()                  {}
+func (*ReleaseSavepoint) iStatement()           {}
+func (*SetVariable) iStatement()                {}
+func (*SetNames) iStatement()                   {}
+func (*ShowTables) iStatement()                 {}
+func (*ShowCreateTable) iStatement()            {}
+func (*ShowColumns) iStatement()                {}
+func (*ShowIndex) iStatement()                  {}
+func (*ShowDatabases) iStatement()              {}
+func (*ShowVariables) iStatement()              {}
+func (*ShowStatus) iStatement()                 {}
+func (*Describe) iStatement()                   {}
+func (*Explain) iStatement()                    {}
+func (*Use) iStatement()                        {}
+func (*Call) iStatement()                       {}
+func (*Prepare) iStatement()                    {}
+func (*Execute) iStatement()                    {}
+func (*DeallocatePrepare) iStatement()          {}
+func (*Do) iStatement()                         {}
+func (*ExecutableCommentStatement) iStatement() {}
 
 // --- InsertRows ---
 
-func (*Select) iInsertRows()  {}
-func (*Union) iInsertRows()   {}
-func (Values) iInsertRows()   {}
-func (SetExprs) iInsertRows() {}
+func (*Select) iInsertRows()          {}
+func (*Union) iInsertRows()           {}
+func (Values) iInsertRows()           {}
+func (*ValuesStatement) iInsertRows() {}
+func (*TableStatement) iInsertRows()  {}
+func (SetExprs) iInsertRows()         {}
 
 // --- TableExpr ---
 
 func (*AliasedTableExpr) iTableExpr() {}
 func (*JoinTableExpr) iTableExpr()    {}
 func (*ParenTableExpr) iTableExpr()   {}
+func (*JSONTableExpr) iTableExpr()    {}
+
+// --- JSONTableColumn ---
+
+func (*JSONTableOrdinalityColumn) iJSONTableColumn() {}
+func (*JSONTablePathColumn) iJSONTableColumn()       {}
+func (*JSONTableNestedPath) iJSONTableColumn()       {}
 
 // --- SimpleTableExpr ---
 
@@ -127,3 +164,19 @@ func (*DropColumnAction) iAlterAction()    {}
 func (*DropIndexAction) iAlterAction()     {}
 func (*ModifyColumnAction) iAlterAction()  {}
 func (*RenameTableAction) iAlterAction()   {}
+
+// --- HintComment ---
+
+func (OptimizerHints) iHintComment()     {}
+func (*ExecutableComment) iHintComment() {}
+
+// --- OptimizerHint ---
+
+func (*TableLevelHint) iOptimizerHint()       {}
+func (*IndexLevelHint) iOptimizerHint()       {}
+func (*SubqueryHint) iOptimizerHint()         {}
+func (*MaxExecutionTimeHint) iOptimizerHint() {}
+func (*SetVarHint) iOptimizerHint()           {}
+func (*ResourceGroupHint) iOptimizerHint()    {}
+func (*QBNameHint) iOptimizerHint()           {}
+func (*UnknownHint) iOptimizerHint()          {}
@@ -8,6 +8,7 @@ type SQLNode interface {
 // Statement represents a SQL statement.
 type Statement interface {
 	SQLNode
+	Commented
 	iStatement()
 }
 
@@ -20,12 +21,14 @@ type Expr interface {
 // TableExpr represents a table expression in a FROM clause.
 type TableExpr interface {
 	SQLNode
+	Commented
 	iTableExpr()
 }
 
 // SelectExpr represents an expression in a SELECT clause.
 type SelectExpr interface {
 	SQLNode
+	Commented
 	iSelectExpr()
 }
 
@@ -45,6 +48,7 @@ type InsertRows interface {
 // column list: either a ColumnDef or a TableConstraint variant.
 type TableElement interface {
 	SQLNode
+	Commented
 	iTableElement()
 }
 
@@ -58,12 +62,32 @@ type TableConstraint interface {
 	iTableConstraint()
 }
 
+// JSONTableColumn represents one entry of a JSON_TABLE COLUMNS list: an
+// ordinality column, a path column, or a NESTED PATH with its own list.
+type JSONTableColumn interface {
+	SQLNode
+	iJSONTableColumn()
+}
+
 // AlterAction represents a single action within an ALTER TABLE statement.
 type AlterAction interface {
 	SQLNode
 	iAlterAction()
 }
 
+// HintComment represents one comment of a HintComments run: an
+// OptimizerHints comment or an ExecutableComment.
+type HintComment interface {
+	SQLNode
+	iHintComment()
+}
+
+// OptimizerHint represents a single hint inside an OptimizerHints comment.
+type OptimizerHint interface {
+	SQLNode
+	iOptimizerHint()
+}
+
 // ColIdent represents a column identifier.
 type ColIdent string
 
@@ -0,0 +1,95 @@
+package sqlast
+
+import "strings"
+
+// Call represents a CALL statement.
+type Call struct {
+	Comments
+
+	Procedure TableName
+	Parens    bool // whether the procedure name was followed by a (possibly empty) argument list
+	Args      []Expr
+}
+
+// String returns Call's SQL text.
+func (c *Call) String() string {
+	str := "CALL " + c.Procedure.String()
+
+	if c.Parens {
+		str += "(" + exprsString(c.Args) + ")"
+	}
+
+	return str
+}
+
+// Prepare represents a PREPARE statement. From is the statement text: a
+// string literal or a user variable holding one.
+type Prepare struct {
+	Comments
+
+	Name TableIdent
+	From Expr
+}
+
+// String returns Prepare's SQL text.
+func (p *Prepare) String() string {
+	return "PREPARE " + p.Name.String() + " FROM " + p.From.String()
+}
+
+// Execute represents an EXECUTE statement.
+type Execute struct {
+	Comments
+
+	Name  TableIdent
+	Using []Expr // the USING user variables; nil if no USING clause
+}
+
+// String returns Execute's SQL text.
+func (e *Execute) String() string {
+	str := "EXECUTE " + e.Name.String()
+
+	if len(e.Using) > 0 {
+		str += " USING " + exprsString(e.Using)
+	}
+
+	return str
+}
+
+// DeallocatePrepare represents a DEALLOCATE PREPARE statement, or its DROP
+// PREPARE synonym when Drop is set, kept distinct so it renders as written.
+type DeallocatePrepare struct {
+	Comments
+
+	Drop bool
+	Name TableIdent
+}
+
+// String returns DeallocatePrepare's SQL text.
+func (d *DeallocatePrepare) String() string {
+	if d.Drop {
+		return "DROP PREPARE " + d.Name.String()
+	}
+
+	return "DEALLOCATE PREPARE " + d.Name.String()
+}
+
+// Do represents a DO statement.
+type Do struct {
+	Comments
+
+	Exprs []Expr
+}
+
+// String returns Do's SQL text.
+func (d *Do) String() string {
+	return "DO " + exprsString(d.Exprs)
+}
+
+func exprsString(exprs []Expr) string {
+	strs := make([]string, len(exprs))
+	for i, e := range exprs {
+		strs[i] = e.String()
+	}
+
+	return strings.Join(strs, ", ")
+}
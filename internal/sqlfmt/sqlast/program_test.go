@@ -0,0 +1,102 @@
+package sqlast_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+func TestCall_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *sqlast.Call
+		want string
+	}{
+		{"no argument list", &sqlast.Call{Procedure: sqlast.TableName{Name: "refresh"}}, "CALL refresh"},
+		{"empty argument list", &sqlast.Call{Procedure: sqlast.TableName{Name: "refresh"}, Parens: true}, "CALL refresh()"},
+		{
+			"qualified with arguments",
+			&sqlast.Call{
+				Procedure: sqlast.TableName{Qualifier: "app", Name: "refresh_stats"},
+				Parens:    true,
+				Args:      []sqlast.Expr{lit("1"), &sqlast.UserVariable{Name: "day"}},
+			},
+			"CALL app.refresh_stats(1, @day)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.want {
+				t.Errorf("Call.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepare_String(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *sqlast.Prepare
+		want string
+	}{
+		{"string literal", &sqlast.Prepare{Name: "stmt", From: lit("'SELECT 1'")}, "PREPARE stmt FROM 'SELECT 1'"},
+		{"user variable", &sqlast.Prepare{Name: "stmt", From: &sqlast.UserVariable{Name: "sql"}}, "PREPARE stmt FROM @sql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.String(); got != tt.want {
+				t.Errorf("Prepare.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    *sqlast.Execute
+		want string
+	}{
+		{"plain", &sqlast.Execute{Name: "stmt"}, "EXECUTE stmt"},
+		{
+			"using",
+			&sqlast.Execute{Name: "stmt", Using: []sqlast.Expr{&sqlast.UserVariable{Name: "a"}, &sqlast.UserVariable{Name: "b"}}},
+			"EXECUTE stmt USING @a, @b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("Execute.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeallocatePrepare_String(t *testing.T) {
+	d := &sqlast.DeallocatePrepare{Name: "stmt"}
+
+	want := "DEALLOCATE PREPARE stmt"
+	if got := d.String(); got != want {
+		t.Errorf("DeallocatePrepare.String() = %q, want %q", got, want)
+	}
+
+	d.Drop = true
+
+	want = "DROP PREPARE stmt"
+	if got := d.String(); got != want {
+		t.Errorf("DeallocatePrepare.String() = %q, want %q", got, want)
+	}
+}
+
+func TestDo_String(t *testing.T) {
+	d := &sqlast.Do{Exprs: []sqlast.Expr{&sqlast.FuncExpr{Name: "SLEEP", Exprs: []sqlast.Expr{lit("1")}}, lit("2")}}
+
+	want := "DO SLEEP(1), 2"
+	if got := d.String(); got != want {
+		t.Errorf("Do.String() = %q, want %q", got, want)
+	}
+}
@@ -26,6 +26,8 @@ func (m TransactionMode) String() string {
 
 // StartTransaction represents a START TRANSACTION statement.
 type StartTransaction struct {
+	Comments
+
 	Mode TransactionMode
 }
 
@@ -41,13 +43,17 @@ func (s *StartTransaction) String() string {
 }
 
 // Begin represents a BEGIN statement.
-type Begin struct{}
+type Begin struct {
+	Comments
+}
 
 // String returns Begin's SQL text.
 func (*Begin) String() string { return "BEGIN" }
 
 // Commit represents a COMMIT statement.
-type Commit struct{}
+type Commit struct {
+	Comments
+}
 
 // String returns Commit's SQL text.
 func (*Commit) String() string { return "COMMIT" }
@@ -55,6 +61,8 @@ func (*Commit) String() string { return "COMMIT" }
 // Rollback represents a ROLLBACK statement, or a ROLLBACK TO [SAVEPOINT]
 // statement when SavepointName is non-empty.
 type Rollback struct {
+	Comments
+
 	SavepointName TableIdent
 }
 
@@ -69,6 +77,8 @@ func (r *Rollback) String() string {
 
 // Savepoint represents a SAVEPOINT statement.
 type Savepoint struct {
+	Comments
+
 	Name TableIdent
 }
 
@@ -79,6 +89,8 @@ func (s *Savepoint) String() string {
 
 // ReleaseSavepoint represents a RELEASE SAVEPOINT statement.
 type ReleaseSavepoint struct {
+	Comments
+
 	Name TableIdent
 }
 
@@ -113,6 +125,8 @@ func (s VariableScope) String() string {
 // (IsUserVariable true, Name holding the name without its leading "@") or a
 // session/global system variable.
 type SetVariable struct {
+	Comments
+
 	Scope          VariableScope
 	IsUserVariable bool
 	Name           string
@@ -143,6 +157,8 @@ func (s *SetVariable) String() string {
 
 // SetNames represents a SET NAMES statement.
 type SetNames struct {
+	Comments
+
 	Charset Expr
 	Collate Expr // nil if no COLLATE clause is present
 }
@@ -7,17 +7,24 @@ import (
 
 // Select represents a SELECT statement.
 type Select struct {
+	Comments
+
 	With        *With
+	Hints       HintComments
 	Distinct    bool
+	Modifiers   SelectModifiers
 	SelectExprs []SelectExpr
+	Into        *SelectInto
 	From        []TableExpr
 	Where       *Where
 	GroupBy     *GroupBy
 	Having      *Where
+	Window      NamedWindows
 	OrderBy     OrderBy
 	Limit       *Limit
 	Lock        Lock
 	LockWait    LockWaitType
+	Clauses     ClauseComments
 }
 
 // String returns Select's SQL text.
@@ -26,32 +33,53 @@ func (s *Select) String() string {
 
 	writeOptionalPrefix(&b, s.With)
 
-	b.WriteString("SELECT ")
+	b.WriteString("SELECT")
+	writeHintComments(&b, s.Hints)
+	b.WriteString(" ")
 
 	if s.Distinct {
 		b.WriteString("DISTINCT ")
 	}
 
+	if s.Modifiers != 0 {
+		b.WriteString(s.Modifiers.String())
+		b.WriteString(" ")
+	}
+
 	writeSelectExprs(&b, s.SelectExprs)
+	writeSelectInto(&b, s.Into, IntoBeforeFrom)
 	writeFromClause(&b, s.From)
 	writeWhereClause(&b, "WHERE", s.Where)
 	writeGroupBy(&b, s.GroupBy)
 	writeWhereClause(&b, "HAVING", s.Having)
+	writeWindowClause(&b, s.Window)
 	writeOrderBy(&b, s.OrderBy)
 	writeLimit(&b, s.Limit)
+	writeSelectInto(&b, s.Into, IntoBeforeLock)
 	writeLock(&b, s.Lock, s.LockWait)
+	writeSelectInto(&b, s.Into, IntoAfterLock)
 
 	return b.String()
 }
 
 // Insert represents an INSERT or REPLACE statement.
 type Insert struct {
-	Action  InsertAction
-	Ignore  bool
-	Table   TableName
-	Columns Columns
-	Rows    InsertRows
-	OnDup   OnDup
+	Comments
+
+	Action   InsertAction
+	Hints    HintComments
+	Ignore   bool
+	Table    TableName
+	Columns  Columns
+	Rows     InsertRows
+	RowAlias *RowAlias
+	OnDup    OnDup
+	Clauses  ClauseComments
+
+	// ColumnComments holds the comments of each of Columns, by index, or is
+	// nil if none has any: the formatter writes each column on a line of
+	// its own.
+	ColumnComments []Comments
 }
 
 // String returns Insert's SQL text.
@@ -59,6 +87,7 @@ func (ins *Insert) String() string {
 	var b strings.Builder
 
 	b.WriteString(ins.Action.String())
+	writeHintComments(&b, ins.Hints)
 
 	if ins.Ignore {
 		b.WriteString(" IGNORE")
@@ -76,6 +105,11 @@ func (ins *Insert) String() string {
 	b.WriteString(" ")
 	b.WriteString(ins.Rows.String())
 
+	if ins.RowAlias != nil {
+		b.WriteString(" ")
+		b.WriteString(ins.RowAlias.String())
+	}
+
 	if len(ins.OnDup) > 0 {
 		b.WriteString(" ")
 		b.WriteString(ins.OnDup.String())
@@ -86,13 +120,17 @@ func (ins *Insert) String() string {
 
 // Update represents an UPDATE statement.
 type Update struct {
+	Comments
+
 	With       *With
+	Hints      HintComments
 	Ignore     bool
 	TableExprs []TableExpr
 	Exprs      []*UpdateExpr
 	Where      *Where
 	OrderBy    OrderBy
 	Limit      *Limit
+	Clauses    ClauseComments
 }
 
 // String returns Update's SQL text.
@@ -102,6 +140,7 @@ func (u *Update) String() string {
 	writeOptionalPrefix(&b, u.With)
 
 	b.WriteString("UPDATE")
+	writeHintComments(&b, u.Hints)
 
 	if u.Ignore {
 		b.WriteString(" IGNORE")
@@ -133,13 +172,17 @@ func (u *Update) String() string {
 
 // Delete represents a DELETE statement.
 type Delete struct {
+	Comments
+
 	With       *With
+	Hints      HintComments
 	Ignore     bool
 	Targets    []TableName
 	TableExprs []TableExpr
 	Where      *Where
 	OrderBy    OrderBy
 	Limit      *Limit
+	Clauses    ClauseComments
 }
 
 // String returns Delete's SQL text.
@@ -149,6 +192,7 @@ func (d *Delete) String() string {
 	writeOptionalPrefix(&b, d.With)
 
 	b.WriteString("DELETE")
+	writeHintComments(&b, d.Hints)
 
 	if d.Ignore {
 		b.WriteString(" IGNORE")
@@ -163,9 +207,95 @@ func (d *Delete) String() string {
 	return b.String()
 }
 
-// Union represents a UNION statement.
+// ValuesStatement represents a table value constructor:
+// VALUES ROW(...), ROW(...) with an optional ORDER BY/LIMIT. It stands alone
+// as a statement, as a derived table, or as INSERT's row source.
+type ValuesStatement struct {
+	Comments
+
+	Rows    Values
+	OrderBy OrderBy
+	Limit   *Limit
+	Clauses ClauseComments
+}
+
+// String returns ValuesStatement's SQL text.
+func (v *ValuesStatement) String() string {
+	var b strings.Builder
+
+	b.WriteString("VALUES ")
+	b.WriteString(v.Rows.joinRows("ROW"))
+	writeOrderBy(&b, v.OrderBy)
+	writeLimit(&b, v.Limit)
+
+	return b.String()
+}
+
+// TableStatement represents a TABLE statement: TABLE t with an optional
+// ORDER BY/LIMIT, shorthand for SELECT * FROM t.
+type TableStatement struct {
+	Comments
+
+	Table   TableName
+	OrderBy OrderBy
+	Limit   *Limit
+	Clauses ClauseComments
+}
+
+// String returns TableStatement's SQL text.
+func (t *TableStatement) String() string {
+	var b strings.Builder
+
+	b.WriteString("TABLE ")
+	b.WriteString(t.Table.String())
+	writeOrderBy(&b, t.OrderBy)
+	writeLimit(&b, t.Limit)
+
+	return b.String()
+}
+
+// SetOperator represents the operator combining a Union's two operands.
+type SetOperator int8
+
+const (
+	UnionSetOperator SetOperator = iota
+	IntersectSetOperator
+	ExceptSetOperator
+)
+
+var setOperatorStrings = [...]string{
+	UnionSetOperator:     "UNION",
+	IntersectSetOperator: "INTERSECT",
+	ExceptSetOperator:    "EXCEPT",
+}
+
+// ToString returns SetOperator's SQL text.
+func (op SetOperator) ToString() string {
+	if op >= 0 && int(op) < len(setOperatorStrings) {
+		return setOperatorStrings[op]
+	}
+
+	return setOperatorStrings[UnionSetOperator]
+}
+
+// precedence reports how tightly op binds: INTERSECT binds tighter than
+// UNION and EXCEPT, which share a level and associate to the left.
+func (op SetOperator) precedence() int {
+	if op == IntersectSetOperator {
+		return 1
+	}
+
+	return 0
+}
+
+// Union represents a set operation statement: UNION, INTERSECT, or EXCEPT
+// (selected by Operator) combining two query operands. Chains of set
+// operations nest as a tree whose shape follows operator precedence.
 type Union struct {
+	Comments
+
 	With     *With
+	Operator SetOperator
 	Left     Statement
 	Right    Statement
 	Distinct bool
@@ -173,6 +303,7 @@ type Union struct {
 	Limit    *Limit
 	Lock     Lock
 	LockWait LockWaitType
+	Clauses  ClauseComments
 }
 
 // String returns Union's SQL text.
@@ -181,15 +312,18 @@ func (u *Union) String() string {
 
 	writeOptionalPrefix(&b, u.With)
 
-	b.WriteString(u.Left.String())
+	writeUnionOperand(&b, u.Left, u.Operator, false)
+
+	b.WriteString(" ")
+	b.WriteString(u.Operator.ToString())
 
 	if u.Distinct {
-		b.WriteString(" UNION ")
+		b.WriteString(" ")
 	} else {
-		b.WriteString(" UNION ALL ")
+		b.WriteString(" ALL ")
 	}
 
-	writeUnionOperand(&b, u.Right)
+	writeUnionOperand(&b, u.Right, u.Operator, true)
 	writeOrderBy(&b, u.OrderBy)
 	writeLimit(&b, u.Limit)
 	writeLock(&b, u.Lock, u.LockWait)
@@ -197,13 +331,15 @@ func (u *Union) String() string {
 	return b.String()
 }
 
-// writeUnionOperand writes stmt as a UNION's right-hand operand, wrapping it
-// in parentheses if it is itself a *Union: without them, a nested Union's own
-// ORDER BY/LIMIT/locking clause would render as if it scoped the whole
-// outer UNION rather than just that operand.
-func writeUnionOperand(b *strings.Builder, stmt Statement) {
+// writeUnionOperand writes stmt as an operand of a set operation using op,
+// wrapping it in parentheses if it is itself a *Union that would otherwise
+// regroup: one carrying its own WITH/ORDER BY/LIMIT/locking clause (which
+// would render as if it scoped the whole outer operation), one whose
+// operator binds more loosely than op, or a right-hand operand at the same
+// level (since set operations associate to the left).
+func writeUnionOperand(b *strings.Builder, stmt Statement, op SetOperator, right bool) {
 	nested, ok := stmt.(*Union)
-	if !ok {
+	if !ok || !nested.needsParens(op, right) {
 		b.WriteString(stmt.String())
 
 		return
@@ -214,6 +350,34 @@ func writeUnionOperand(b *strings.Builder, stmt Statement) {
 	b.WriteByte(')')
 }
 
+func (u *Union) needsParens(parent SetOperator, right bool) bool {
+	if u.With != nil || len(u.OrderBy) > 0 || u.Limit != nil || u.Lock != NoLock {
+		return true
+	}
+
+	if right {
+		return u.Operator.precedence() <= parent.precedence()
+	}
+
+	return u.Operator.precedence() < parent.precedence()
+}
+
+// ParenSelect represents a parenthesized query expression used as a set
+// operation operand, e.g. the (SELECT ... LIMIT 1) in
+// (SELECT ... LIMIT 1) UNION (SELECT ...). Select is the SELECT or Union
+// inside the parentheses, along with any ORDER BY/LIMIT/locking clause of
+// its own.
+type ParenSelect struct {
+	Comments
+
+	Select Statement
+}
+
+// String returns ParenSelect's SQL text.
+func (p *ParenSelect) String() string {
+	return "(" + p.Select.String() + ")"
+}
+
 // With represents a WITH (CTE) clause.
 type With struct {
 	CTEs      []*CommonTableExpr
@@ -309,6 +473,15 @@ func writeGroupBy(b *strings.Builder, g *GroupBy) {
 	b.WriteString(g.String())
 }
 
+func writeWindowClause(b *strings.Builder, w NamedWindows) {
+	if len(w) == 0 {
+		return
+	}
+
+	b.WriteString(" ")
+	b.WriteString(w.String())
+}
+
 func writeOrderBy(b *strings.Builder, o OrderBy) {
 	if len(o) == 0 {
 		return
@@ -327,6 +500,16 @@ func writeLimit(b *strings.Builder, l *Limit) {
 	b.WriteString(l.String())
 }
 
+// writeSelectInto writes into if it was written at position pos.
+func writeSelectInto(b *strings.Builder, into *SelectInto, pos IntoPosition) {
+	if into == nil || into.Position != pos {
+		return
+	}
+
+	b.WriteString(" ")
+	b.WriteString(into.String())
+}
+
 func writeLock(b *strings.Builder, l Lock, wait LockWaitType) {
 	if l == NoLock {
 		return
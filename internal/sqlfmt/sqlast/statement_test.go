@@ -95,6 +95,38 @@ func TestSelect_String(t *testing.T) {
 			},
 			want: "WITH cte1 AS (SELECT 1) SELECT * FROM cte1",
 		},
+		{
+			name: "modifiers and into before from",
+			s: &sqlast.Select{
+				Distinct:    true,
+				Modifiers:   sqlast.SQLCalcFoundRowsModifier | sqlast.StraightJoinModifier,
+				SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: lit("id")}},
+				Into:        &sqlast.SelectInto{Variables: exprs("@id")},
+				From:        []sqlast.TableExpr{&sqlast.AliasedTableExpr{Expr: sqlast.TableName{Name: "t"}}},
+			},
+			want: "SELECT DISTINCT STRAIGHT_JOIN SQL_CALC_FOUND_ROWS id INTO @id FROM t",
+		},
+		{
+			name: "into before lock",
+			s: &sqlast.Select{
+				SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: lit("id")}},
+				From:        []sqlast.TableExpr{&sqlast.AliasedTableExpr{Expr: sqlast.TableName{Name: "t"}}},
+				Limit:       &sqlast.Limit{Rowcount: lit("1")},
+				Into:        &sqlast.SelectInto{Position: sqlast.IntoBeforeLock, Variables: exprs("@id")},
+				Lock:        sqlast.ForUpdateLock,
+			},
+			want: "SELECT id FROM t LIMIT 1 INTO @id FOR UPDATE",
+		},
+		{
+			name: "into after lock",
+			s: &sqlast.Select{
+				SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: lit("id")}},
+				From:        []sqlast.TableExpr{&sqlast.AliasedTableExpr{Expr: sqlast.TableName{Name: "t"}}},
+				Into:        &sqlast.SelectInto{Position: sqlast.IntoAfterLock, Variables: exprs("@id")},
+				Lock:        sqlast.ForShareLock,
+			},
+			want: "SELECT id FROM t FOR SHARE INTO @id",
+		},
 	}
 
 	for _, tt := range tests {
@@ -144,6 +176,29 @@ func TestInsert_String(t *testing.T) {
 			},
 			want: "INSERT INTO t (a, b) VALUES (1, 2) ON DUPLICATE KEY UPDATE b = 3",
 		},
+		{
+			name: "with row alias",
+			ins: &sqlast.Insert{
+				Action:   sqlast.InsertAct,
+				Table:    sqlast.TableName{Name: "t"},
+				Columns:  sqlast.Columns{"a", "b"},
+				Rows:     sqlast.Values{{lit("1"), lit("2")}},
+				RowAlias: &sqlast.RowAlias{Name: "new", Columns: sqlast.Columns{"x", "y"}},
+				OnDup: sqlast.OnDup{
+					{Name: &sqlast.ColName{Name: "b"}, Expr: &sqlast.ColName{Qualifier: sqlast.TableName{Name: "new"}, Name: "y"}},
+				},
+			},
+			want: "INSERT INTO t (a, b) VALUES (1, 2) AS new (x, y) ON DUPLICATE KEY UPDATE b = new.y",
+		},
+		{
+			name: "table rows",
+			ins: &sqlast.Insert{
+				Action: sqlast.InsertAct,
+				Table:  sqlast.TableName{Name: "t"},
+				Rows:   &sqlast.TableStatement{Table: sqlast.TableName{Name: "u"}},
+			},
+			want: "INSERT INTO t TABLE u",
+		},
 		{
 			name: "set rows",
 			ins: &sqlast.Insert{
@@ -324,6 +379,61 @@ func TestUnion_String(t *testing.T) {
 
 		assertEqual(t, "SELECT 1 UNION (SELECT 1 UNION SELECT 2 ORDER BY 1)", outer.String())
 	})
+
+	t.Run("intersect and except", func(t *testing.T) {
+		intersect := &sqlast.Union{Operator: sqlast.IntersectSetOperator, Left: left, Right: right, Distinct: true}
+		except := &sqlast.Union{Operator: sqlast.ExceptSetOperator, Left: left, Right: right}
+
+		assertEqual(t, "SELECT 1 INTERSECT SELECT 2", intersect.String())
+		assertEqual(t, "SELECT 1 EXCEPT ALL SELECT 2", except.String())
+	})
+
+	t.Run("tighter nested operand renders without parens", func(t *testing.T) {
+		inner := &sqlast.Union{Operator: sqlast.IntersectSetOperator, Left: left, Right: right, Distinct: true}
+		outer := &sqlast.Union{Left: left, Right: inner, Distinct: true}
+
+		assertEqual(t, "SELECT 1 UNION SELECT 1 INTERSECT SELECT 2", outer.String())
+	})
+
+	t.Run("looser nested operand is parenthesized", func(t *testing.T) {
+		inner := &sqlast.Union{Left: left, Right: right, Distinct: true}
+		outer := &sqlast.Union{Operator: sqlast.IntersectSetOperator, Left: inner, Right: right, Distinct: true}
+
+		assertEqual(t, "(SELECT 1 UNION SELECT 2) INTERSECT SELECT 2", outer.String())
+	})
+}
+
+func TestValuesStatement_String(t *testing.T) {
+	v := &sqlast.ValuesStatement{Rows: sqlast.Values{{lit("1"), lit("'a'")}, {lit("2"), lit("'b'")}}}
+	assertEqual(t, "VALUES ROW(1, 'a'), ROW(2, 'b')", v.String())
+
+	v.OrderBy = sqlast.OrderBy{{Expr: lit("column_0")}}
+	v.Limit = &sqlast.Limit{Rowcount: lit("1")}
+	assertEqual(t, "VALUES ROW(1, 'a'), ROW(2, 'b') ORDER BY column_0 LIMIT 1", v.String())
+}
+
+func TestTableStatement_String(t *testing.T) {
+	s := &sqlast.TableStatement{Table: sqlast.TableName{Qualifier: "db", Name: "t"}}
+	assertEqual(t, "TABLE db.t", s.String())
+
+	s.OrderBy = sqlast.OrderBy{{Expr: lit("a"), Direction: sqlast.DescOrder}}
+	s.Limit = &sqlast.Limit{Rowcount: lit("2")}
+	assertEqual(t, "TABLE db.t ORDER BY a DESC LIMIT 2", s.String())
+}
+
+func TestSetOperator_ToString(t *testing.T) {
+	assertEqual(t, "UNION", sqlast.UnionSetOperator.ToString())
+	assertEqual(t, "INTERSECT", sqlast.IntersectSetOperator.ToString())
+	assertEqual(t, "EXCEPT", sqlast.ExceptSetOperator.ToString())
+}
+
+func TestParenSelect_String(t *testing.T) {
+	sel := &sqlast.Select{
+		SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: lit("1")}},
+		Limit:       &sqlast.Limit{Rowcount: lit("1")},
+	}
+
+	assertEqual(t, "(SELECT 1 LIMIT 1)", (&sqlast.ParenSelect{Select: sel}).String())
 }
 
 func TestWith_String(t *testing.T) {
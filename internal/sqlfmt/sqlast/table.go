@@ -4,8 +4,16 @@ import "strings"
 
 // AliasedTableExpr represents a table expression with an optional alias.
 type AliasedTableExpr struct {
-	Expr  SimpleTableExpr
-	As    TableIdent
+	Comments
+
+	Expr SimpleTableExpr
+	As   TableIdent
+
+	// Columns is a derived table's column list, following As: the
+	// (a, b) in (VALUES ROW(1, 2)) AS v (a, b). Only a *DerivedTable Expr
+	// takes one.
+	Columns Columns
+
 	Hints IndexHints
 }
 
@@ -20,6 +28,10 @@ func (a *AliasedTableExpr) String() string {
 		b.WriteString(a.As.String())
 	}
 
+	if len(a.Columns) > 0 {
+		b.WriteString(" (" + a.Columns.String() + ")")
+	}
+
 	for _, hint := range a.Hints {
 		b.WriteString(" ")
 		b.WriteString(hint.String())
@@ -30,6 +42,8 @@ func (a *AliasedTableExpr) String() string {
 
 // JoinTableExpr represents a JOIN expression.
 type JoinTableExpr struct {
+	Comments
+
 	LeftExpr  TableExpr
 	Join      JoinType
 	RightExpr TableExpr
@@ -62,6 +76,8 @@ func (j *JoinTableExpr) String() string {
 
 // ParenTableExpr represents a parenthesized list of table expressions.
 type ParenTableExpr struct {
+	Comments
+
 	Exprs []TableExpr
 }
 
@@ -75,18 +91,141 @@ func (p *ParenTableExpr) String() string {
 	return "(" + strings.Join(strs, ", ") + ")"
 }
 
-// DerivedTable represents a subquery used as a table.
+// JSONTableExpr represents JSON_TABLE(expr, path COLUMNS (...)) alias, which
+// turns a JSON document into a table. MySQL requires the alias.
+type JSONTableExpr struct {
+	Comments
+
+	Expr    Expr
+	Path    Expr
+	Columns []JSONTableColumn
+	As      TableIdent
+}
+
+// String returns JSONTableExpr's SQL text.
+func (j *JSONTableExpr) String() string {
+	s := "JSON_TABLE(" + j.Expr.String() + ", " + j.Path.String() + " " + jsonTableColumnsString(j.Columns) + ")"
+	if !j.As.IsEmpty() {
+		s += " " + j.As.String()
+	}
+
+	return s
+}
+
+func jsonTableColumnsString(cols []JSONTableColumn) string {
+	strs := make([]string, len(cols))
+	for i, c := range cols {
+		strs[i] = c.String()
+	}
+
+	return "COLUMNS (" + strings.Join(strs, ", ") + ")"
+}
+
+// JSONTableOrdinalityColumn represents a JSON_TABLE name FOR ORDINALITY
+// column, which numbers the rows.
+type JSONTableOrdinalityColumn struct {
+	Name ColIdent
+}
+
+// String returns JSONTableOrdinalityColumn's SQL text.
+func (c *JSONTableOrdinalityColumn) String() string {
+	return c.Name.String() + " FOR ORDINALITY"
+}
+
+// JSONTablePathColumn represents a JSON_TABLE name type [EXISTS] PATH path
+// column, with optional ON EMPTY/ON ERROR handling (never set together with
+// Exists, which MySQL doesn't allow them on).
+type JSONTablePathColumn struct {
+	Name    ColIdent
+	Type    DataType
+	Exists  bool
+	Path    Expr
+	OnEmpty *JSONTableResponse
+	OnError *JSONTableResponse
+}
+
+// String returns JSONTablePathColumn's SQL text.
+func (c *JSONTablePathColumn) String() string {
+	s := c.Name.String() + " " + c.Type.String()
+	if c.Exists {
+		s += " EXISTS"
+	}
+
+	s += " PATH " + c.Path.String()
+
+	if c.OnEmpty != nil {
+		s += " " + c.OnEmpty.String() + " ON EMPTY"
+	}
+
+	if c.OnError != nil {
+		s += " " + c.OnError.String() + " ON ERROR"
+	}
+
+	return s
+}
+
+// JSONTableResponseType identifies what a JSON_TABLE path column yields
+// when its path is missing (ON EMPTY) or invalid (ON ERROR).
+type JSONTableResponseType int8
+
+const (
+	JSONTableNullResponse JSONTableResponseType = iota
+	JSONTableErrorResponse
+	JSONTableDefaultResponse
+)
+
+// JSONTableResponse represents a JSON_TABLE path column's NULL, ERROR, or
+// DEFAULT value response.
+type JSONTableResponse struct {
+	Type    JSONTableResponseType
+	Default Expr // set only for JSONTableDefaultResponse
+}
+
+// String returns JSONTableResponse's SQL text.
+func (r *JSONTableResponse) String() string {
+	switch r.Type {
+	case JSONTableErrorResponse:
+		return "ERROR"
+	case JSONTableDefaultResponse:
+		return "DEFAULT " + r.Default.String()
+	default:
+		return "NULL"
+	}
+}
+
+// JSONTableNestedPath represents a JSON_TABLE NESTED PATH path COLUMNS
+// (...) entry, which flattens a nested array into further columns.
+type JSONTableNestedPath struct {
+	Path    Expr
+	Columns []JSONTableColumn
+}
+
+// String returns JSONTableNestedPath's SQL text.
+func (n *JSONTableNestedPath) String() string {
+	return "NESTED PATH " + n.Path.String() + " " + jsonTableColumnsString(n.Columns)
+}
+
+// DerivedTable represents a subquery used as a table. Lateral marks a
+// LATERAL derived table, which may refer to columns of the tables before it
+// in the same FROM clause.
 type DerivedTable struct {
-	Select Statement
+	Lateral bool
+	Select  Statement
 }
 
 // String returns DerivedTable's SQL text.
 func (d *DerivedTable) String() string {
+	if d.Lateral {
+		return "LATERAL (" + d.Select.String() + ")"
+	}
+
 	return "(" + d.Select.String() + ")"
 }
 
 // AliasedExpr represents an expression with an optional alias in a SELECT clause.
 type AliasedExpr struct {
+	Comments
+
 	Expr Expr
 	As   ColIdent
 }
@@ -103,6 +242,8 @@ func (a *AliasedExpr) String() string {
 
 // StarExpr represents a * or table.* expression in a SELECT clause.
 type StarExpr struct {
+	Comments
+
 	TableName TableName
 }
 
@@ -79,6 +79,40 @@ func TestParenTableExpr_String(t *testing.T) {
 	assertEqual(t, "(a, b)", e.String())
 }
 
+func TestJSONTableExpr_String(t *testing.T) {
+	e := &sqlast.JSONTableExpr{
+		Expr: lit("data"),
+		Path: lit("'$[*]'"),
+		Columns: []sqlast.JSONTableColumn{
+			&sqlast.JSONTableOrdinalityColumn{Name: "rn"},
+			&sqlast.JSONTablePathColumn{
+				Name:    "id",
+				Type:    sqlast.DataType{Name: "INT"},
+				Path:    lit("'$.id'"),
+				OnEmpty: &sqlast.JSONTableResponse{Type: sqlast.JSONTableDefaultResponse, Default: lit("'0'")},
+				OnError: &sqlast.JSONTableResponse{Type: sqlast.JSONTableErrorResponse},
+			},
+			&sqlast.JSONTablePathColumn{Name: "ok", Type: sqlast.DataType{Name: "BOOL"}, Exists: true, Path: lit("'$.ok'")},
+			&sqlast.JSONTableNestedPath{
+				Path: lit("'$.tags[*]'"),
+				Columns: []sqlast.JSONTableColumn{
+					&sqlast.JSONTablePathColumn{
+						Name:    "tag",
+						Type:    sqlast.DataType{Name: "TEXT"},
+						Path:    lit("'$'"),
+						OnError: &sqlast.JSONTableResponse{Type: sqlast.JSONTableNullResponse},
+					},
+				},
+			},
+		},
+		As: "jt",
+	}
+
+	assertEqual(t, "JSON_TABLE(data, '$[*]' COLUMNS (rn FOR ORDINALITY, "+
+		"id INT PATH '$.id' DEFAULT '0' ON EMPTY ERROR ON ERROR, ok BOOL EXISTS PATH '$.ok', "+
+		"NESTED PATH '$.tags[*]' COLUMNS (tag TEXT PATH '$' NULL ON ERROR))) jt", e.String())
+}
+
 func TestDerivedTable_String(t *testing.T) {
 	d := &sqlast.DerivedTable{
 		Select: &sqlast.Select{
@@ -87,6 +121,9 @@ func TestDerivedTable_String(t *testing.T) {
 	}
 
 	assertEqual(t, "(SELECT 1)", d.String())
+
+	d.Lateral = true
+	assertEqual(t, "LATERAL (SELECT 1)", d.String())
 }
 
 func TestAliasedExpr_String(t *testing.T) {
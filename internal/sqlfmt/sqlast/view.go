@@ -0,0 +1,202 @@
+package sqlast
+
+import "strings"
+
+// ViewAlgorithm represents a view's optional ALGORITHM clause.
+type ViewAlgorithm int8
+
+const (
+	NoViewAlgorithm ViewAlgorithm = iota
+	UndefinedAlgorithm
+	MergeAlgorithm
+	TempTableAlgorithm
+)
+
+// String returns ViewAlgorithm's SQL text, or "" for NoViewAlgorithm.
+func (a ViewAlgorithm) String() string {
+	switch a {
+	case UndefinedAlgorithm:
+		return "UNDEFINED"
+	case MergeAlgorithm:
+		return "MERGE"
+	case TempTableAlgorithm:
+		return "TEMPTABLE"
+	default:
+		return ""
+	}
+}
+
+// ViewSecurity represents a view's optional SQL SECURITY clause.
+type ViewSecurity int8
+
+const (
+	NoViewSecurity ViewSecurity = iota
+	DefinerSecurity
+	InvokerSecurity
+)
+
+// String returns ViewSecurity's SQL text, or "" for NoViewSecurity.
+func (s ViewSecurity) String() string {
+	switch s {
+	case DefinerSecurity:
+		return "DEFINER"
+	case InvokerSecurity:
+		return "INVOKER"
+	default:
+		return ""
+	}
+}
+
+// ViewCheckOption represents a view's optional WITH [CASCADED | LOCAL] CHECK
+// OPTION clause.
+type ViewCheckOption int8
+
+const (
+	NoCheckOption ViewCheckOption = iota
+	CheckOption
+	CascadedCheckOption
+	LocalCheckOption
+)
+
+// String returns ViewCheckOption's SQL text, or "" for NoCheckOption.
+func (c ViewCheckOption) String() string {
+	switch c {
+	case CheckOption:
+		return "WITH CHECK OPTION"
+	case CascadedCheckOption:
+		return "WITH CASCADED CHECK OPTION"
+	case LocalCheckOption:
+		return "WITH LOCAL CHECK OPTION"
+	default:
+		return ""
+	}
+}
+
+// DropViewBehavior represents DROP VIEW's optional RESTRICT or CASCADE
+// suffix, which MySQL parses and ignores.
+type DropViewBehavior int8
+
+const (
+	NoDropBehavior DropViewBehavior = iota
+	RestrictDrop
+	CascadeDrop
+)
+
+// String returns DropViewBehavior's SQL text, or "" for NoDropBehavior.
+func (d DropViewBehavior) String() string {
+	switch d {
+	case RestrictDrop:
+		return "RESTRICT"
+	case CascadeDrop:
+		return "CASCADE"
+	default:
+		return ""
+	}
+}
+
+// ViewDefinition holds what CREATE VIEW and ALTER VIEW share: everything
+// from the ALGORITHM clause to the check option.
+type ViewDefinition struct {
+	Algorithm   ViewAlgorithm
+	Definer     string // the account as written ('app'@'%', CURRENT_USER); "" if no DEFINER clause
+	Security    ViewSecurity
+	View        TableName
+	Columns     Columns   // nil if no column list
+	Select      Statement // the view body: a *Select or *Union
+	CheckOption ViewCheckOption
+}
+
+// Options returns the ALGORITHM, DEFINER, and SQL SECURITY clauses that come
+// before VIEW, each followed by a space, or "" if there are none.
+func (d *ViewDefinition) Options() string {
+	var b strings.Builder
+
+	if algorithm := d.Algorithm.String(); algorithm != "" {
+		b.WriteString("ALGORITHM = " + algorithm + " ")
+	}
+
+	if d.Definer != "" {
+		b.WriteString("DEFINER = " + d.Definer + " ")
+	}
+
+	if security := d.Security.String(); security != "" {
+		b.WriteString("SQL SECURITY " + security + " ")
+	}
+
+	return b.String()
+}
+
+// String returns ViewDefinition's SQL text.
+func (d *ViewDefinition) String() string {
+	str := d.Options() + "VIEW " + d.View.String()
+
+	if len(d.Columns) > 0 {
+		str += " (" + d.Columns.String() + ")"
+	}
+
+	str += " AS " + d.Select.String()
+
+	if check := d.CheckOption.String(); check != "" {
+		str += " " + check
+	}
+
+	return str
+}
+
+// CreateView represents a CREATE VIEW statement.
+type CreateView struct {
+	Comments
+	ViewDefinition
+
+	OrReplace bool
+}
+
+// String returns CreateView's SQL text.
+func (c *CreateView) String() string {
+	if c.OrReplace {
+		return "CREATE OR REPLACE " + c.ViewDefinition.String()
+	}
+
+	return "CREATE " + c.ViewDefinition.String()
+}
+
+// AlterView represents an ALTER VIEW statement.
+type AlterView struct {
+	Comments
+	ViewDefinition
+}
+
+// String returns AlterView's SQL text.
+func (a *AlterView) String() string {
+	return "ALTER " + a.ViewDefinition.String()
+}
+
+// DropView represents a DROP VIEW statement.
+type DropView struct {
+	Comments
+
+	IfExists bool
+	Views    []TableName
+	Behavior DropViewBehavior
+}
+
+// String returns DropView's SQL text.
+func (d *DropView) String() string {
+	names := make([]string, len(d.Views))
+	for i, v := range d.Views {
+		names[i] = v.String()
+	}
+
+	s := "DROP VIEW "
+	if d.IfExists {
+		s += "IF EXISTS "
+	}
+
+	s += strings.Join(names, ", ")
+
+	if d.Behavior != NoDropBehavior {
+		s += " " + d.Behavior.String()
+	}
+
+	return s
+}
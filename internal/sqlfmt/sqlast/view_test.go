@@ -0,0 +1,100 @@
+package sqlast_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/sqlast"
+)
+
+func viewSelect() *sqlast.Select {
+	return &sqlast.Select{
+		SelectExprs: []sqlast.SelectExpr{&sqlast.AliasedExpr{Expr: &sqlast.ColName{Name: "a"}}},
+		From:        []sqlast.TableExpr{&sqlast.AliasedTableExpr{Expr: sqlast.TableName{Name: "t"}}},
+	}
+}
+
+func TestCreateView_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *sqlast.CreateView
+		want string
+	}{
+		{
+			"plain",
+			&sqlast.CreateView{ViewDefinition: sqlast.ViewDefinition{View: sqlast.TableName{Name: "v"}, Select: viewSelect()}},
+			"CREATE VIEW v AS SELECT a FROM t",
+		},
+		{
+			"all clauses",
+			&sqlast.CreateView{
+				OrReplace: true,
+				ViewDefinition: sqlast.ViewDefinition{
+					Algorithm:   sqlast.MergeAlgorithm,
+					Definer:     "'app'@'%'",
+					Security:    sqlast.InvokerSecurity,
+					View:        sqlast.TableName{Qualifier: "db", Name: "v"},
+					Columns:     sqlast.Columns{"x"},
+					Select:      viewSelect(),
+					CheckOption: sqlast.LocalCheckOption,
+				},
+			},
+			"CREATE OR REPLACE ALGORITHM = MERGE DEFINER = 'app'@'%' SQL SECURITY INVOKER VIEW db.v (x) " +
+				"AS SELECT a FROM t WITH LOCAL CHECK OPTION",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.want {
+				t.Errorf("CreateView.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlterView_String(t *testing.T) {
+	a := &sqlast.AlterView{ViewDefinition: sqlast.ViewDefinition{
+		Algorithm:   sqlast.TempTableAlgorithm,
+		View:        sqlast.TableName{Name: "v"},
+		Select:      viewSelect(),
+		CheckOption: sqlast.CheckOption,
+	}}
+
+	want := "ALTER ALGORITHM = TEMPTABLE VIEW v AS SELECT a FROM t WITH CHECK OPTION"
+	if got := a.String(); got != want {
+		t.Errorf("AlterView.String() = %q, want %q", got, want)
+	}
+}
+
+func TestDropView_String(t *testing.T) {
+	tests := []struct {
+		name string
+		d    *sqlast.DropView
+		want string
+	}{
+		{"single", &sqlast.DropView{Views: []sqlast.TableName{{Name: "v"}}}, "DROP VIEW v"},
+		{
+			"if exists multiple",
+			&sqlast.DropView{IfExists: true, Views: []sqlast.TableName{{Name: "v"}, {Qualifier: "db", Name: "w"}}},
+			"DROP VIEW IF EXISTS v, db.w",
+		},
+		{
+			"cascade",
+			&sqlast.DropView{Views: []sqlast.TableName{{Name: "v"}}, Behavior: sqlast.CascadeDrop},
+			"DROP VIEW v CASCADE",
+		},
+		{
+			"restrict",
+			&sqlast.DropView{Views: []sqlast.TableName{{Name: "v"}}, Behavior: sqlast.RestrictDrop},
+			"DROP VIEW v RESTRICT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.String(); got != tt.want {
+				t.Errorf("DropView.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
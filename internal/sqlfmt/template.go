@@ -0,0 +1,255 @@
+package sqlfmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt/parser"
+)
+
+// controlKeywords are the text/template keywords that start a control
+// action: one that shapes the template's output rather than producing a
+// value in the SQL.
+var controlKeywords = map[string]bool{
+	"if": true, "else": true, "end": true, "range": true, "with": true,
+	"define": true, "block": true, "template": true, "break": true, "continue": true,
+}
+
+// controlAction is a text/template control action ({{if .X}}, {{end}},
+// {{/* a comment */}}, ...) removed from a template before parsing, and the
+// offset of the masked text it was removed at.
+type controlAction struct {
+	text   string
+	offset int
+}
+
+// templateMask is a text/template SQL template with its actions masked.
+type templateMask struct {
+	masked   string
+	values   []maskedText
+	controls []controlAction
+}
+
+// formatTextTemplate formats sql, a text/template SQL template, with its
+// actions masked (see Options.TextTemplate).
+func formatTextTemplate(sql string, opts Options, mode parser.SQLMode) (string, bool) {
+	tmpl, ok := maskTemplate(sql, mode)
+	if !ok {
+		return sql, false
+	}
+
+	opts.TextTemplate = false
+
+	result, ok := FormatSQLWithOptions(tmpl.masked, opts)
+	if !ok {
+		return sql, false
+	}
+
+	if result, ok = unmaskTemplate(result, tmpl, mode); !ok {
+		return sql, false
+	}
+
+	return result, true
+}
+
+// maskTemplate masks every action of the template sql that sits outside a
+// quoted string or identifier. A value action ({{.Table}}) is replaced by a
+// sentinel valid in its position (see positionSentinel); a control action
+// is replaced by a space, so the SQL on either side of it is parsed as if
+// every branch of the template were taken. Actions inside quotes are left
+// alone, like the verbs maskVerbs skips. ok is false when an action is
+// unterminated.
+func maskTemplate(sql string, mode parser.SQLMode) (templateMask, bool) {
+	var (
+		b    strings.Builder
+		tmpl templateMask
+	)
+
+	q := quoteState{mode: mode}
+
+	for i := 0; i < len(sql); {
+		if !strings.HasPrefix(sql[i:], "{{") {
+			next, _ := q.advance(sql, i)
+			b.WriteString(sql[i:next])
+			i = next
+
+			continue
+		}
+
+		end, ok := actionEnd(sql, i)
+		if !ok {
+			return templateMask{}, false
+		}
+
+		action := sql[i:end]
+
+		switch {
+		case q.quote != 0:
+			b.WriteString(action)
+		case isControlAction(action):
+			tmpl.controls = append(tmpl.controls, controlAction{text: action, offset: b.Len()})
+			b.WriteString(" ")
+		default:
+			sentinel := positionSentinel(fmt.Sprintf("_sqla_tpl_%d_", len(tmpl.values)), b.String(), sql[end:])
+			tmpl.values = append(tmpl.values, maskedText{text: action, sentinel: sentinel})
+			b.WriteString(sentinel)
+		}
+
+		i = end
+	}
+
+	tmpl.masked = b.String()
+
+	return tmpl, true
+}
+
+// unmaskTemplate undoes maskTemplate on formatted, the formatted masked
+// text: every value sentinel is replaced by its action, and every control
+// action is put back on a line of its own, indented like the line it
+// precedes, except that {{else}} and {{end}} line up with the action that
+// opened their block (see blockIndent). A control action goes before the formatted line holding the
+// token that followed it in the template, which must start that line (see
+// clauseBoundary), or at the end when no token followed it; ok is false
+// when either has no such place.
+func unmaskTemplate(formatted string, tmpl templateMask, mode parser.SQLMode) (string, bool) {
+	var (
+		b    strings.Builder
+		open []string
+	)
+
+	prev := 0
+
+	for _, c := range tmpl.controls {
+		rest, ok := lexAll(tmpl.masked[c.offset:], mode)
+		if !ok {
+			return formatted, false
+		}
+
+		cut := len(formatted)
+		if len(rest) > 0 {
+			if cut, ok = clauseBoundary(tmpl.masked, c.offset, formatted, mode); !ok {
+				return formatted, false
+			}
+		}
+
+		if cut < prev {
+			return formatted, false
+		}
+
+		indent := formatted[cut:]
+		indent = indent[:len(indent)-len(strings.TrimLeft(indent, " \t"))]
+
+		b.WriteString(formatted[prev:cut])
+		b.WriteString(blockIndent(&open, c.text, indent) + c.text + "\n")
+		prev = cut
+	}
+
+	b.WriteString(formatted[prev:])
+
+	return restoreMasked(b.String(), tmpl.values)
+}
+
+// blockOpeners are the controlKeywords that open a block closed by
+// {{end}}.
+var blockOpeners = map[string]bool{
+	"if": true, "range": true, "with": true, "define": true, "block": true,
+}
+
+// blockIndent returns the indentation of the control action, given indent,
+// the indentation of the line it precedes. open holds the indentation of
+// every block opened so far and not yet ended: an action opening one pushes
+// indent, an {{else}} takes the innermost block's indentation, and an
+// {{end}} pops it, so each block's actions line up however the SQL between
+// them was indented.
+func blockIndent(open *[]string, action, indent string) string {
+	keyword := actionKeyword(action)
+
+	if blockOpeners[keyword] {
+		*open = append(*open, indent)
+
+		return indent
+	}
+
+	n := len(*open)
+	if n == 0 || (keyword != "else" && keyword != "end") {
+		return indent
+	}
+
+	indent = (*open)[n-1]
+	if keyword == "end" {
+		*open = (*open)[:n-1]
+	}
+
+	return indent
+}
+
+// isControlAction reports whether action, a complete {{...}} action, is a
+// comment or starts with one of controlKeywords.
+func isControlAction(action string) bool {
+	keyword := actionKeyword(action)
+
+	return strings.HasPrefix(keyword, "/*") || controlKeywords[keyword]
+}
+
+// actionKeyword returns the first word of action, a complete {{...}}
+// action, inside its delimiters and any trim markers, or "" when it is
+// empty.
+func actionKeyword(action string) string {
+	inner := strings.TrimSuffix(strings.TrimPrefix(action, "{{"), "}}")
+	inner = strings.TrimPrefix(inner, "- ")
+	inner = strings.TrimSuffix(inner, " -")
+
+	fields := strings.Fields(inner)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[0]
+}
+
+// actionEnd returns the offset just past the }} closing the action that
+// starts at s[start:] with {{. A }} inside one of the action's string, raw
+// string, or character constants, or inside its comment, doesn't close it.
+func actionEnd(s string, start int) (int, bool) {
+	for i := start + len("{{"); i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "}}"):
+			return i + len("}}"), true
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return 0, false
+			}
+
+			i += 2 + end + 2
+		case s[i] == '"' || s[i] == '\'' || s[i] == '`':
+			end, ok := goQuotedEnd(s, i)
+			if !ok {
+				return 0, false
+			}
+
+			i = end
+		default:
+			i++
+		}
+	}
+
+	return 0, false
+}
+
+// goQuotedEnd returns the offset just past the Go string, raw string, or
+// character constant starting at s[start].
+func goQuotedEnd(s string, start int) (int, bool) {
+	quote := s[start]
+
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote != '`':
+			i++
+		case s[i] == quote:
+			return i + 1, true
+		}
+	}
+
+	return 0, false
+}
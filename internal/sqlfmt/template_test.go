@@ -0,0 +1,128 @@
+package sqlfmt_test
+
+import (
+	"testing"
+
+	"github.com/Eagle-Konbu/sanat/internal/sqlfmt"
+)
+
+func TestFormatSQL_TextTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{
+			name: "value and control actions",
+			in:   "select * from {{.Table}} where deleted_at is null {{if .Name}}and name = {{.Name}}{{end}} order by id",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  {{.Table}}",
+				"WHERE",
+				"  deleted_at IS NULL",
+				"  {{if .Name}}",
+				"  AND name = {{.Name}}",
+				"  {{end}}",
+				"ORDER BY",
+				"  id",
+			),
+			ok: true,
+		},
+		{
+			name: "end at the end lines up with its block",
+			in:   "select * from t where x = 1 {{if .Y}}and y = 2{{end}}",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  x = 1",
+				"  {{if .Y}}",
+				"  AND y = 2",
+				"  {{end}}",
+			),
+			ok: true,
+		},
+		{
+			name: "else and nested blocks",
+			in: "select id from t where deleted_at is null " +
+				"{{if .Name}}and name = {{.Name}} {{range .Tags}}and tag = {{.}}{{end}}" +
+				"{{else}}and name is null{{end}} order by id",
+			want: join(
+				"SELECT",
+				"  id",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  deleted_at IS NULL",
+				"  {{if .Name}}",
+				"  AND name = {{.Name}}",
+				"  {{range .Tags}}",
+				"  AND tag = {{.}}",
+				"  {{end}}",
+				"  {{else}}",
+				"  AND name IS NULL",
+				"  {{end}}",
+				"ORDER BY",
+				"  id",
+			),
+			ok: true,
+		},
+		{
+			name: "leading and trailing control actions",
+			in:   `{{define "q"}}select id from t where id in {{.IDs}}{{end}}`,
+			want: join(
+				`{{define "q"}}`,
+				"SELECT",
+				"  id",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  id IN {{.IDs}}",
+				"{{end}}",
+			),
+			ok: true,
+		},
+		{
+			name: "actions inside quotes and comments with braces",
+			in:   "select * from t where name = '{{.Name}}' {{- /* a }} b */ -}}",
+			want: join(
+				"SELECT",
+				"  *",
+				"FROM",
+				"  t",
+				"WHERE",
+				"  name = '{{.Name}}'",
+				"{{- /* a }} b */ -}}",
+			),
+			ok: true,
+		},
+		{
+			name: "control action with no line of its own",
+			in:   "update t set a = ? {{with .B}}, b = {{.}}{{end}} where id = 1",
+			want: "update t set a = ? {{with .B}}, b = {{.}}{{end}} where id = 1",
+			ok:   false,
+		},
+		{
+			name: "unterminated action",
+			in:   "select * from {{.Table",
+			want: "select * from {{.Table",
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := sqlfmt.FormatSQLWithOptions(tt.in, sqlfmt.Options{Indent: 2, TextTemplate: true})
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+
+			assertSQL(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package concat
+
+import "database/sql"
+
+const usersTable = "users"
+
+const archiveSuffix = "archive"
+
+func queries(db *sql.DB, name string) {
+	// Concatenated at clause boundaries: each fragment keeps its share
+	db.Query(`
+SELECT
+  id,
+  name
+`+
+		`FROM
+  users
+`+
+		`WHERE
+  id = ?
+`, 1)
+
+	// Spliced constant: formatted around the splice
+	db.Query(`
+SELECT
+  id
+FROM
+  `+
+		usersTable+`
+WHERE
+  id = ?
+`, 1)
+
+	// Should NOT be changed: boundary splits a token
+	db.Query(`select id, na` + `me from users`)
+
+	// Should NOT be changed: not SQL
+	db.Query(`hello ` + `world`)
+
+	// Should NOT be changed: splice glued to a name
+	db.Query(`select id from users_` + archiveSuffix)
+
+	// Should NOT be changed: splice inside quotes
+	db.Query(`select id from users where name = '` + name + `'`)
+}
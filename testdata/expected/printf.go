@@ -0,0 +1,34 @@
+package printf
+
+import (
+	"fmt"
+	"io"
+)
+
+func queries(w io.Writer, table string, shard, id int) (string, error) {
+	// Verbs are masked while formatting and restored exactly
+	q := fmt.Sprintf(`
+SELECT
+  %s
+FROM
+  %s
+WHERE
+  id = %d
+  AND name LIKE '%s%%'
+`, "id, name", table, id)
+
+	// Verb glued to an identifier, with flags
+	fmt.Fprintf(w, `
+SELECT
+  *
+FROM
+  logs_%02d
+WHERE
+  created_at > ?
+LIMIT
+  %d
+`, shard, 100)
+
+	// Should NOT be changed: the verb stands for a whole clause
+	return q, fmt.Errorf(`select * from users %s`, "where id = 1")
+}
@@ -0,0 +1,22 @@
+package template
+
+import "text/template"
+
+// Value actions are restored in place, control actions on lines of their own
+var listUsers = template.Must(template.New("listUsers").Parse(`
+SELECT
+  id,
+  name
+FROM
+  {{.Table}}
+WHERE
+  deleted_at IS NULL
+  {{if .Name}}
+  AND name = ?
+  {{end}}
+ORDER BY
+  id
+`))
+
+// Should NOT be changed: the control action has no line of its own
+var updateUser = template.Must(template.New("updateUser").Parse(`update users set name = ? {{if .Email}}, email = ?{{end}} where id = ?`))
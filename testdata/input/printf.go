@@ -0,0 +1,17 @@
+package printf
+
+import (
+	"fmt"
+	"io"
+)
+
+func queries(w io.Writer, table string, shard, id int) (string, error) {
+	// Verbs are masked while formatting and restored exactly
+	q := fmt.Sprintf(`select %s from %s where id = %d and name like '%s%%'`, "id, name", table, id)
+
+	// Verb glued to an identifier, with flags
+	fmt.Fprintf(w, `select * from logs_%02d where created_at > ? limit %d`, shard, 100)
+
+	// Should NOT be changed: the verb stands for a whole clause
+	return q, fmt.Errorf(`select * from users %s`, "where id = 1")
+}
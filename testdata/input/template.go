@@ -0,0 +1,9 @@
+package template
+
+import "text/template"
+
+// Value actions are restored in place, control actions on lines of their own
+var listUsers = template.Must(template.New("listUsers").Parse(`select id, name from {{.Table}} where deleted_at is null {{if .Name}}and name = ?{{end}} order by id`))
+
+// Should NOT be changed: the control action has no line of its own
+var updateUser = template.Must(template.New("updateUser").Parse(`update users set name = ? {{if .Email}}, email = ?{{end}} where id = ?`))